@@ -2,6 +2,7 @@ package player
 
 import (
 	"context"
+	"io"
 
 	"github.com/luno/reflex"
 )
@@ -19,9 +20,36 @@ type Client interface {
 	// GetParts returns a Player's parts received for a given round.
 	GetParts(ctx context.Context, externalID int64) ([]Part, error)
 
+	// GetRoundParts returns every part a Player holds for a given round,
+	// both its own and those it collected for its peers.
+	GetRoundParts(ctx context.Context, externalID int64) ([]Part, error)
+
 	// GetRound returns a local rounds from a Player's DB.
 	GetRound(ctx context.Context, roundID int64) (*Round, error)
 
+	// GetRoundAudit returns the audit trail of a local round, recording why
+	// it transitioned between statuses.
+	GetRoundAudit(ctx context.Context, roundID int64) ([]RoundAudit, error)
+
+	// GetStats returns the aggregate results of the Player's completed
+	// rounds.
+	GetStats(ctx context.Context) (*Stats, error)
+
+	// CheckTotal compares the total and parts another Player is about to
+	// submit against the parts this Player collected from it.
+	CheckTotal(ctx context.Context, claim TotalClaim) (*TotalCheck, error)
+
+	// Export writes the Player's round results ("rounds") or their summary
+	// ("summary") to w, encoded as "csv" or "json".
+	Export(ctx context.Context, format, kind string, w io.Writer) error
+
 	// GetName returns a Player's name.
 	GetName(ctx context.Context) (string, error)
+
+	// Hello returns a Player's identity, protocol version and the optional
+	// features it supports, so peers can check they are compatible.
+	Hello(ctx context.Context) (*Hello, error)
+
+	// Leave notifies a Player that the named peer is shutting down.
+	Leave(ctx context.Context, name string) error
 }
@@ -1,17 +1,26 @@
 package client
 
 import (
+	"strings"
+
 	"github.com/luno/jettison/errors"
 
 	"unsure/player"
 	"unsure/player/client/grpc"
+	"unsure/player/client/http"
 )
 
 // Make returns a Player client communicating on an appropriate communication
-// protocol.
-func Make() (player.Client, error) {
-	if grpc.IsEnabled() {
-		return grpc.New()
+// protocol with the Player at the provided address. HTTP(S) URLs connect to
+// the Player's HTTP gateway, while host:port addresses use gRPC.
+func Make(address string, conf player.TLSConfig) (player.Client, error) {
+	if strings.HasPrefix(address, "http://") ||
+		strings.HasPrefix(address, "https://") {
+		return http.New(http.WithAddress(address))
+	}
+
+	if address != "" {
+		return grpc.New(grpc.WithAddress(address), grpc.WithTLS(conf))
 	}
 
 	return nil, errors.New("failed to make player client")
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+)
+
+//go:generate stringer -type=BreakerState -trimprefix=BreakerState
+
+// BreakerState defines the state of a client's circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerStateClosed indicates that calls are allowed through.
+	BreakerStateClosed BreakerState = 0
+
+	// BreakerStateOpen indicates that the Player has been unreachable and
+	// calls fail fast until the cooldown has passed.
+	BreakerStateOpen BreakerState = 1
+
+	// BreakerStateHalfOpen indicates that the cooldown has passed and a
+	// single trial call is allowed through.
+	BreakerStateHalfOpen BreakerState = 2
+)
+
+// Breaker is implemented by Player clients that expose the state of their
+// circuit breaker.
+type Breaker interface {
+	BreakerState() BreakerState
+}
+
+// breaker opens after a number of consecutive failures and allows a trial
+// call once the cooldown has passed.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+}
+
+// allow returns whether a call may proceed.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerStateOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerStateHalfOpen
+		return true
+	case BreakerStateHalfOpen:
+		// Only the first trial call is allowed through.
+		return false
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of a call.
+func (b *breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.state = BreakerStateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerStateHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerStateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *breaker) current() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerStateOpen && time.Since(b.openedAt) >= b.cooldown {
+		return BreakerStateHalfOpen
+	}
+
+	return b.state
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	tests := []struct {
+		name   string
+		failed []bool
+		cooled bool
+		want   BreakerState
+		allow  bool
+	}{
+		{
+			name:  "new",
+			want:  BreakerStateClosed,
+			allow: true,
+		},
+		{
+			name:   "below threshold",
+			failed: []bool{true, true},
+			want:   BreakerStateClosed,
+			allow:  true,
+		},
+		{
+			name:   "success resets failures",
+			failed: []bool{true, true, false, true, true},
+			want:   BreakerStateClosed,
+			allow:  true,
+		},
+		{
+			name:   "opens at threshold",
+			failed: []bool{true, true, true},
+			want:   BreakerStateOpen,
+			allow:  false,
+		},
+		{
+			name:   "half open after cooldown",
+			failed: []bool{true, true, true},
+			cooled: true,
+			want:   BreakerStateHalfOpen,
+			allow:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &breaker{threshold: 3, cooldown: time.Hour}
+			for _, failed := range test.failed {
+				b.record(failed)
+			}
+			if test.cooled {
+				b.openedAt = b.openedAt.Add(-2 * b.cooldown)
+			}
+
+			if s := b.current(); s != test.want {
+				t.Errorf("got state %v, want %v", s, test.want)
+			}
+			if ok := b.allow(); ok != test.allow {
+				t.Errorf("got allow %v, want %v", ok, test.allow)
+			}
+		})
+	}
+}
+
+// TestBreakerTrial checks that only one trial call is allowed once the
+// cooldown has passed, and that its outcome closes or reopens the breaker.
+func TestBreakerTrial(t *testing.T) {
+	tests := []struct {
+		name   string
+		failed bool
+		want   BreakerState
+		allow  bool
+	}{
+		{name: "trial succeeds", want: BreakerStateClosed, allow: true},
+		{name: "trial fails", failed: true, want: BreakerStateOpen},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &breaker{threshold: 3, cooldown: time.Hour}
+			b.record(true)
+			b.record(true)
+			b.record(true)
+			b.openedAt = b.openedAt.Add(-2 * b.cooldown)
+
+			if !b.allow() {
+				t.Fatal("trial call not allowed")
+			}
+			if b.allow() {
+				t.Error("second trial call allowed")
+			}
+
+			b.record(test.failed)
+
+			if s := b.current(); s != test.want {
+				t.Errorf("got state %v, want %v", s, test.want)
+			}
+			if ok := b.allow(); ok != test.allow {
+				t.Errorf("got allow %v, want %v", ok, test.allow)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Code generated by "stringer -type=BreakerState -trimprefix=BreakerState"; DO NOT EDIT.
+
+package grpc
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[BreakerStateClosed-0]
+	_ = x[BreakerStateOpen-1]
+	_ = x[BreakerStateHalfOpen-2]
+}
+
+const _BreakerState_name = "ClosedOpenHalfOpen"
+
+var _BreakerState_index = [...]uint8{0, 6, 10, 18}
+
+func (i BreakerState) String() string {
+	if i < 0 || i >= BreakerState(len(_BreakerState_index)-1) {
+		return "BreakerState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _BreakerState_name[_BreakerState_index[i]:_BreakerState_index[i+1]]
+}
@@ -0,0 +1,332 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"testing"
+
+	"github.com/corverroos/unsure"
+	"github.com/corverroos/unsure/engine"
+	"github.com/luno/reflex/reflexpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"unsure/player"
+	"unsure/player/internal/db"
+	"unsure/player/internal/db/rounds"
+	"unsure/player/internal/grpctls"
+	"unsure/player/internal/signing"
+	"unsure/player/ops"
+	pbv1 "unsure/player/playerpb"
+	pb "unsure/player/playerpb/v2"
+	"unsure/player/server"
+)
+
+var insecure = player.TLSConfig{Insecure: true}
+
+// TestClientCompat checks that the client talks to Players on builds which
+// serve both versions of the API, and on older builds which only serve the
+// first.
+func TestClientCompat(t *testing.T) {
+	defer cheatServerFate(t)()
+
+	dbc, id := setupCompat(t)
+	defer dbc.Close()
+
+	tests := []struct {
+		name        string
+		legacy      bool
+		wantVersion int
+	}{
+		{name: "v2 server", wantVersion: 2},
+		{name: "v1 server", legacy: true, wantVersion: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			addr, stop := serveCompat(t, dbc, test.legacy)
+			defer stop()
+
+			cl, err := New(WithAddress(addr), WithTLS(insecure))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(
+				unsure.ContextWithFate(context.Background(), 0))
+			defer cancel()
+
+			r, err := cl.GetRound(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Status != player.RoundStatusJoined {
+				t.Errorf("got status %v, want %v", r.Status,
+					player.RoundStatusJoined)
+			}
+
+			sc, err := cl.StreamEvents(ctx, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, want := range []player.RoundStatus{
+				player.RoundStatusJoin, player.RoundStatusJoined} {
+				e, err := sc.Recv()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if e.Type.ReflexType() != want.ReflexType() {
+					t.Errorf("got event type %v, want %v",
+						e.Type.ReflexType(), want)
+				}
+			}
+
+			if v := cl.(*client).version; v != test.wantVersion {
+				t.Errorf("got version %d, want %d", v, test.wantVersion)
+			}
+		})
+	}
+}
+
+// TestServerCompat checks the wire format of each version of the API served
+// by a build serving both, as seen by clients generated from either.
+func TestServerCompat(t *testing.T) {
+	defer cheatServerFate(t)()
+
+	dbc, id := setupCompat(t)
+	defer dbc.Close()
+
+	addr, stop := serveCompat(t, dbc, false)
+	defer stop()
+
+	conn, err := grpctls.NewClient(addr, insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(
+		unsure.ContextWithFate(context.Background(), 0))
+	defer cancel()
+
+	t.Run("v1 client", func(t *testing.T) {
+		cl := pbv1.NewPlayerClient(conn)
+
+		res, err := cl.GetRound(ctx, &pbv1.GetRoundReq{RoundId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Round.Status != int32(player.RoundStatusJoined) {
+			t.Errorf("got status %d, want %d", res.Round.Status,
+				player.RoundStatusJoined)
+		}
+
+		sc, err := cl.StreamRoundEvents(ctx, &reflexpb.StreamRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkEventTypes(t, sc, int32(player.RoundStatusJoin),
+			int32(player.RoundStatusJoined))
+	})
+
+	t.Run("v2 client", func(t *testing.T) {
+		cl := pb.NewPlayerClient(conn)
+
+		res, err := cl.GetRound(ctx, &pb.GetRoundReq{RoundId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Round.Status != pb.RoundStatus_ROUND_STATUS_JOINED {
+			t.Errorf("got status %v, want %v", res.Round.Status,
+				pb.RoundStatus_ROUND_STATUS_JOINED)
+		}
+
+		sc, err := cl.StreamRoundEvents(ctx, &reflexpb.StreamRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkEventTypes(t, sc, int32(pb.RoundStatus_ROUND_STATUS_JOIN),
+			int32(pb.RoundStatus_ROUND_STATUS_JOINED))
+	})
+}
+
+// TestStandbyUnavailable checks that a replica standing by refuses requests
+// which change the Player's state as unavailable, so that clients retry them
+// against the replica leading, while still serving reads.
+func TestStandbyUnavailable(t *testing.T) {
+	defer cheatServerFate(t)()
+
+	dbc, id := setupCompat(t)
+	defer dbc.Close()
+
+	b := &compatBackends{dbc: dbc}
+	b.standby.Set(true)
+
+	addr, stop := serveBackends(t, b, false)
+	defer stop()
+
+	// Dial without the jettison interceptors, which hide the status code.
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := unsure.ContextWithFate(context.Background(), 0)
+	cl := pb.NewPlayerClient(conn)
+
+	_, err = cl.Leave(ctx, &pb.LeaveReq{Name: "bob"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("leave: got %v, want unavailable", err)
+	}
+	if b.departures.Has("bob") {
+		t.Error("standby recorded departure")
+	}
+
+	_, err = cl.CheckTotal(ctx, &pb.TotalClaim{ExternalId: 42,
+		Player: "bob"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("check total: got %v, want unavailable", err)
+	}
+
+	if _, err := cl.GetRound(ctx, &pb.GetRoundReq{RoundId: id}); err != nil {
+		t.Errorf("get round: %v", err)
+	}
+}
+
+// TestReplicaFailover checks that the client reaches the replica leading
+// when a peer's address lists each of its replicas, and keeps reaching it
+// once the replica standing by goes down.
+func TestReplicaFailover(t *testing.T) {
+	defer cheatServerFate(t)()
+
+	dbc, id := setupCompat(t)
+	defer dbc.Close()
+
+	leader := &compatBackends{dbc: dbc}
+	standby := &compatBackends{dbc: dbc}
+	standby.standby.Set(true)
+
+	leaderAddr, stopLeader := serveBackends(t, leader, false)
+	defer stopLeader()
+
+	standbyAddr, stopStandby := serveBackends(t, standby, false)
+
+	cl, err := New(WithAddress(standbyAddr+"|"+leaderAddr), WithTLS(insecure))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := unsure.ContextWithFate(context.Background(), 0)
+
+	for _, name := range []string{"bob", "carol", "dave"} {
+		if err := cl.Leave(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+		if !leader.departures.Has(name) {
+			t.Errorf("leader didn't record %s leaving", name)
+		}
+	}
+
+	stopStandby()
+
+	for i := 0; i < 3; i++ {
+		if _, err := cl.GetRound(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkEventTypes(t *testing.T, sc interface {
+	Recv() (*reflexpb.Event, error)
+}, want ...int32) {
+	for _, typ := range want {
+		e, err := sc.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Type != typ {
+			t.Errorf("got event type %d, want %d", e.Type, typ)
+		}
+	}
+}
+
+// cheatServerFate stops the fate tempted by the server from failing calls,
+// returning a func which restores it.
+func cheatServerFate(t *testing.T) func() {
+	fateP := flag.Lookup("fate_p").Value.String()
+	if err := flag.Set("fate_p", "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		flag.Set("fate_p", fateP)
+	}
+}
+
+// setupCompat returns a test DB holding a joined round, and the round's id.
+func setupCompat(t *testing.T) (*sql.DB, int64) {
+	dbc := db.ConnectForTesting(t)
+
+	ctx := unsure.ContextWithFate(context.Background(), 0)
+	reason := player.LocalEventReason("test")
+
+	id, err := rounds.Create(ctx, dbc, 42, 0, reason)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rounds.ShiftToJoined(ctx, dbc, id, "alice", reason,
+		player.RoundStatusJoin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dbc, id
+}
+
+// serveCompat serves the Player's API over the test DB, either as a build
+// serving both versions of the API or as a legacy build serving the first.
+func serveCompat(t *testing.T, dbc *sql.DB, legacy bool) (string, func()) {
+	return serveBackends(t, &compatBackends{dbc: dbc}, legacy)
+}
+
+// serveBackends serves the Player's API over the backends.
+func serveBackends(t *testing.T, b *compatBackends, legacy bool) (string,
+	func()) {
+	srv, err := grpctls.NewServer("127.0.0.1:0", insecure, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := player.Config{PlayerName: "alice", TeamName: "team"}
+	s := server.New(b, conf)
+
+	pbv1.RegisterPlayerServer(srv.GRPCServer(), s)
+	if !legacy {
+		pb.RegisterPlayerServer(srv.GRPCServer(), server.NewV2(s))
+	}
+
+	go srv.ServeForever()
+
+	return srv.Listener().Addr().String(), func() {
+		s.Stop()
+		srv.Stop()
+	}
+}
+
+type compatBackends struct {
+	dbc        *sql.DB
+	departures ops.Departures
+	standby    ops.Standby
+}
+
+func (b *compatBackends) PlayerDB() *sql.DB           { return b.dbc }
+func (b *compatBackends) EngineClient() engine.Client { return nil }
+func (b *compatBackends) Peers() []player.Client      { return nil }
+func (b *compatBackends) Keyring() *signing.Keyring   { return nil }
+func (b *compatBackends) Departures() *ops.Departures { return &b.departures }
+func (b *compatBackends) Standby() *ops.Standby       { return &b.standby }
@@ -2,28 +2,25 @@ package grpc
 
 import (
 	"context"
-	"flag"
-	"github.com/corverroos/unsure"
+	"io"
+	"sync"
+	"time"
 
 	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
 	"github.com/luno/reflex"
 	"github.com/luno/reflex/reflexpb"
 	"google.golang.org/grpc"
 
 	"unsure/player"
-	pb "unsure/player/playerpb"
-	"unsure/player/playerpb/protocp"
+	"unsure/player/internal/grpctls"
+	pbv1 "unsure/player/playerpb"
+	pb "unsure/player/playerpb/v2"
+	"unsure/player/playerpb/v2/protocp"
 )
 
 var _ player.Client = (*client)(nil)
-
-var grpcAddr = flag.String("player_address", "",
-	"host:port of the player gRPC service")
-
-// IsEnabled returns whether a gRPC address has been provided to the Player.
-func IsEnabled() bool {
-	return *grpcAddr != ""
-}
+var _ Breaker = (*client)(nil)
 
 type clientOpt func(c *client)
 
@@ -35,35 +32,148 @@ func WithAddress(address string) clientOpt {
 	}
 }
 
+// WithTLS provides an option to specify the certificates used to
+// authenticate with the Player.
+func WithTLS(conf player.TLSConfig) clientOpt {
+	return func(c *client) {
+		c.tls = conf
+	}
+}
+
+// WithCallTimeout provides an option to specify the deadline applied to
+// unary calls made with a context without one. Zero disables the deadline.
+func WithCallTimeout(d time.Duration) clientOpt {
+	return func(c *client) {
+		c.callTimeout = d
+	}
+}
+
+// WithRetries provides an option to specify how many times idempotent calls
+// are retried when the Player is unreachable, and the initial backoff
+// between attempts which doubles after each retry.
+func WithRetries(retries int, backoff time.Duration) clientOpt {
+	return func(c *client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// WithBreaker provides an option to specify the number of consecutive
+// unreachable calls after which the client's circuit breaker opens, and how
+// long it stays open before allowing a trial call.
+func WithBreaker(threshold int, cooldown time.Duration) clientOpt {
+	return func(c *client) {
+		c.breaker = &breaker{threshold: threshold, cooldown: cooldown}
+	}
+}
+
 // New returns a gRPC client for a Player.
 func New(opts ...clientOpt) (player.Client, error) {
 	c := client{
-		address: *grpcAddr,
+		callTimeout: defaultCallTimeout,
+		retries:     defaultRetries,
+		backoff:     defaultBackoff,
+		breaker: &breaker{
+			threshold: defaultBreakerThreshold,
+			cooldown:  defaultBreakerCooldown,
+		},
 	}
 
 	for _, o := range opts {
 		o(&c)
 	}
 
+	if c.address == "" {
+		return nil, errors.New("no address provided")
+	}
+
 	var err error
-	c.rpcConn, err = unsure.NewClient(c.address)
+	c.rpcConn, err = grpctls.NewClient(c.address, c.tls,
+		grpc.WithChainUnaryInterceptor(c.intercept))
 	if err != nil {
 		return nil, err
 	}
 
 	c.rpcClient = pb.NewPlayerClient(c.rpcConn)
+	c.legacyClient = legacyClient{rpc: pbv1.NewPlayerClient(c.rpcConn)}
 
 	return &c, nil
 }
 
 type client struct {
 	address   string
+	tls       player.TLSConfig
 	rpcConn   *grpc.ClientConn
 	rpcClient pb.PlayerClient
+
+	// legacyClient calls Players on older builds which don't serve the
+	// second version of the API. The version is probed on first use and
+	// cached until the Player is unreachable, since it may return on
+	// another build.
+	legacyClient pb.PlayerClient
+	mu           sync.Mutex
+	version      int
+
+	callTimeout time.Duration
+	retries     int
+	backoff     time.Duration
+	breaker     *breaker
 }
 
-func (c *client) Ping(ctx context.Context) error {
+// rpc returns the client for the newest version of the API the Player
+// serves. Until a Ping over the second version succeeds or is rejected as
+// unimplemented, the second version is assumed.
+func (c *client) rpc(ctx context.Context) pb.PlayerClient {
+	if c.probeVersion(ctx) == 1 {
+		return c.legacyClient
+	}
+
+	return c.rpcClient
+}
+
+// probeVersion returns the cached version of the API the Player serves,
+// probing it if unknown. The lock isn't held while probing, so calls aren't
+// held up behind a slow probe. It returns zero if the probe failed.
+func (c *client) probeVersion(ctx context.Context) int {
+	c.mu.Lock()
+	version := c.version
+	c.mu.Unlock()
+
+	if version != 0 {
+		return version
+	}
+
 	_, err := c.rpcClient.Ping(ctx, &pb.Empty{})
+	if err == nil {
+		version = 2
+	} else if errors.Is(err, errUnimplemented) {
+		version = 1
+	} else {
+		return 0
+	}
+
+	c.mu.Lock()
+	c.version = version
+	c.mu.Unlock()
+
+	return version
+}
+
+// resetVersion forgets the cached version of the API, so that it is probed
+// again once the Player is reachable.
+func (c *client) resetVersion() {
+	c.mu.Lock()
+	c.version = 0
+	c.mu.Unlock()
+}
+
+// BreakerState returns the current state of the client's circuit breaker.
+func (c *client) BreakerState() BreakerState {
+	return c.breaker.current()
+}
+
+func (c *client) Ping(ctx context.Context) error {
+	_, err := c.rpc(ctx).Ping(ctx, &pb.Empty{})
 	return err
 }
 
@@ -74,15 +184,37 @@ func (c *client) StreamEvents(ctx context.Context, after string,
 
 	streamFn := reflex.WrapStreamPB(func(ctx context.Context,
 		req *reflexpb.StreamRequest) (reflex.StreamClientPB, error) {
-		return c.rpcClient.StreamRoundEvents(ctx, req)
+		sc, err := c.rpc(ctx).StreamRoundEvents(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		return enumEventsClient{sc}, nil
 	})
 
 	return streamFn(ctx, after, opts...)
 }
 
+// enumEventsClient converts the type of each event it receives from a
+// pb.RoundStatus back to its player.RoundStatus.
+type enumEventsClient struct {
+	pb.Player_StreamRoundEventsClient
+}
+
+func (sc enumEventsClient) Recv() (*reflexpb.Event, error) {
+	e, err := sc.Player_StreamRoundEventsClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	e.Type = int32(protocp.RoundStatusFromProto(pb.RoundStatus(e.Type)))
+
+	return e, nil
+}
+
 // GetName returns a Player's name.
 func (c *client) GetName(ctx context.Context) (string, error) {
-	res, err := c.rpcClient.GetName(ctx, &pb.Empty{})
+	res, err := c.rpc(ctx).GetName(ctx, &pb.Empty{})
 	if err != nil {
 		return "", err
 	}
@@ -90,10 +222,30 @@ func (c *client) GetName(ctx context.Context) (string, error) {
 	return res.Name, nil
 }
 
+// Hello returns a Player's identity, protocol version and capabilities.
+func (c *client) Hello(ctx context.Context) (*player.Hello, error) {
+	res, err := c.rpc(ctx).Hello(ctx, &pb.Empty{})
+	if errors.Is(err, errUnimplemented) {
+		// Builds which predate Hello can't be checked, so aren't trusted.
+		return nil, errors.Wrap(player.ErrIncompatiblePeer,
+			"peer doesn't support hello", j.KV("address", c.address))
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to say hello")
+	}
+
+	return protocp.HelloFromProto(res), nil
+}
+
+// Leave notifies a Player that the named peer is shutting down.
+func (c *client) Leave(ctx context.Context, name string) error {
+	_, err := c.rpc(ctx).Leave(ctx, &pb.LeaveReq{Name: name})
+	return err
+}
+
 // GetParts returns a Player's parts received for a given round.
 func (c *client) GetParts(ctx context.Context, externalID int64) (
 	[]player.Part, error) {
-	res, err := c.rpcClient.GetParts(ctx, &pb.GetPartsReq{
+	res, err := c.rpc(ctx).GetParts(ctx, &pb.GetPartsReq{
 		ExternalId: externalID,
 	})
 	if err != nil {
@@ -113,10 +265,33 @@ func (c *client) GetParts(ctx context.Context, externalID int64) (
 	return parts, nil
 }
 
+// GetRoundParts returns every part a Player holds for a given round.
+func (c *client) GetRoundParts(ctx context.Context, externalID int64) (
+	[]player.Part, error) {
+	res, err := c.rpc(ctx).GetRoundParts(ctx, &pb.GetPartsReq{
+		ExternalId: externalID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get round parts")
+	}
+
+	// Convert proto parts to internal types.
+	var parts []player.Part
+	for _, protoPart := range res.Parts {
+		p, err := protocp.PartFromProto(protoPart)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert part from proto")
+		}
+		parts = append(parts, *p)
+	}
+
+	return parts, nil
+}
+
 // GetRound returns a local rounds from a Player's DB.
 func (c *client) GetRound(ctx context.Context, roundID int64) (
 	*player.Round, error) {
-	res, err := c.rpcClient.GetRound(ctx, &pb.GetRoundReq{
+	res, err := c.rpc(ctx).GetRound(ctx, &pb.GetRoundReq{
 		RoundId: roundID,
 	})
 	if err != nil {
@@ -125,3 +300,74 @@ func (c *client) GetRound(ctx context.Context, roundID int64) (
 
 	return protocp.RoundFromProto(res.Round)
 }
+
+// GetRoundAudit returns the audit trail of a local round in a Player's DB.
+func (c *client) GetRoundAudit(ctx context.Context, roundID int64) (
+	[]player.RoundAudit, error) {
+	res, err := c.rpc(ctx).GetRoundAudit(ctx, &pb.GetRoundAuditReq{
+		RoundId: roundID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get round audit")
+	}
+
+	// Convert proto audit to internal types.
+	var audit []player.RoundAudit
+	for _, protoAudit := range res.Audit {
+		a, err := protocp.RoundAuditFromProto(protoAudit)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert audit from proto")
+		}
+		audit = append(audit, *a)
+	}
+
+	return audit, nil
+}
+
+// GetStats returns the aggregate results of the Player's completed rounds.
+func (c *client) GetStats(ctx context.Context) (*player.Stats, error) {
+	res, err := c.rpc(ctx).GetStats(ctx, &pb.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stats")
+	}
+
+	return protocp.StatsFromProto(res.Stats), nil
+}
+
+// CheckTotal asks a Player to compare the claimed total against the parts it
+// collected from the claimant.
+func (c *client) CheckTotal(ctx context.Context, claim player.TotalClaim) (
+	*player.TotalCheck, error) {
+	res, err := c.rpc(ctx).CheckTotal(ctx, protocp.TotalClaimToProto(claim))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check total")
+	}
+
+	return protocp.TotalCheckFromProto(res), nil
+}
+
+// Export writes the Player's round results or summary to w as it is
+// streamed from the Player.
+func (c *client) Export(ctx context.Context, format, kind string,
+	w io.Writer) error {
+	sc, err := c.rpc(ctx).Export(ctx, &pb.ExportReq{
+		Format: format,
+		Kind:   kind,
+	})
+	if err != nil {
+		return toTyped(err, c.address)
+	}
+
+	for {
+		chunk, err := sc.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return toTyped(err, c.address)
+		}
+
+		if _, err := w.Write(chunk.Data); err != nil {
+			return errors.Wrap(err, "failed to write export")
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/luno/reflex/reflexpb"
+	"google.golang.org/grpc"
+
+	"unsure/player"
+	pb "unsure/player/playerpb"
+	"unsure/player/playerpb/protocp"
+	pbv2 "unsure/player/playerpb/v2"
+	protocpv2 "unsure/player/playerpb/v2/protocp"
+)
+
+var _ pbv2.PlayerClient = legacyClient{}
+
+// legacyClient calls Players on older builds, which only serve the first
+// version of the API, translating to and from the second version's messages.
+type legacyClient struct {
+	rpc pb.PlayerClient
+}
+
+func (lc legacyClient) Ping(ctx context.Context, in *pbv2.Empty,
+	opts ...grpc.CallOption) (*pbv2.Empty, error) {
+	_, err := lc.rpc.Ping(ctx, &pb.Empty{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.Empty{}, nil
+}
+
+// StreamRoundEvents returns the first version's stream, with the raw
+// player.RoundStatus type of each event converted to its pb.RoundStatus.
+func (lc legacyClient) StreamRoundEvents(ctx context.Context,
+	in *reflexpb.StreamRequest, opts ...grpc.CallOption) (
+	pbv2.Player_StreamRoundEventsClient, error) {
+	sc, err := lc.rpc.StreamRoundEvents(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return legacyEventsClient{sc}, nil
+}
+
+type legacyEventsClient struct {
+	pb.Player_StreamRoundEventsClient
+}
+
+func (sc legacyEventsClient) Recv() (*reflexpb.Event, error) {
+	e, err := sc.Player_StreamRoundEventsClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	e.Type = int32(protocpv2.RoundStatusToProto(player.RoundStatus(e.Type)))
+
+	return e, nil
+}
+
+func (lc legacyClient) GetParts(ctx context.Context, in *pbv2.GetPartsReq,
+	opts ...grpc.CallOption) (*pbv2.GetPartsResp, error) {
+	res, err := lc.rpc.GetParts(ctx, &pb.GetPartsReq{
+		ExternalId: in.ExternalId,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return partsToV2(res)
+}
+
+func (lc legacyClient) GetRoundParts(ctx context.Context,
+	in *pbv2.GetPartsReq, opts ...grpc.CallOption) (*pbv2.GetPartsResp,
+	error) {
+	res, err := lc.rpc.GetRoundParts(ctx, &pb.GetPartsReq{
+		ExternalId: in.ExternalId,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return partsToV2(res)
+}
+
+func (lc legacyClient) GetRound(ctx context.Context, in *pbv2.GetRoundReq,
+	opts ...grpc.CallOption) (*pbv2.GetRoundResp, error) {
+	res, err := lc.rpc.GetRound(ctx, &pb.GetRoundReq{
+		RoundId: in.RoundId,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := protocp.RoundFromProto(res.Round)
+	if err != nil {
+		return nil, err
+	}
+
+	round, err := protocpv2.RoundToProto(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.GetRoundResp{Round: round}, nil
+}
+
+func (lc legacyClient) GetName(ctx context.Context, in *pbv2.Empty,
+	opts ...grpc.CallOption) (*pbv2.GetNameResp, error) {
+	res, err := lc.rpc.GetName(ctx, &pb.Empty{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.GetNameResp{Name: res.Name}, nil
+}
+
+func (lc legacyClient) Hello(ctx context.Context, in *pbv2.Empty,
+	opts ...grpc.CallOption) (*pbv2.HelloResp, error) {
+	res, err := lc.rpc.Hello(ctx, &pb.Empty{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return protocpv2.HelloToProto(protocp.HelloFromProto(res)), nil
+}
+
+func (lc legacyClient) Leave(ctx context.Context, in *pbv2.LeaveReq,
+	opts ...grpc.CallOption) (*pbv2.Empty, error) {
+	_, err := lc.rpc.Leave(ctx, &pb.LeaveReq{Name: in.Name}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.Empty{}, nil
+}
+
+func (lc legacyClient) GetRoundAudit(ctx context.Context,
+	in *pbv2.GetRoundAuditReq, opts ...grpc.CallOption) (
+	*pbv2.GetRoundAuditResp, error) {
+	res, err := lc.rpc.GetRoundAudit(ctx, &pb.GetRoundAuditReq{
+		RoundId: in.RoundId,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp pbv2.GetRoundAuditResp
+	for _, a := range res.Audit {
+		audit, err := protocp.RoundAuditFromProto(a)
+		if err != nil {
+			return nil, err
+		}
+
+		auditProto, err := protocpv2.RoundAuditToProto(audit)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Audit = append(resp.Audit, auditProto)
+	}
+
+	return &resp, nil
+}
+
+func (lc legacyClient) Export(ctx context.Context, in *pbv2.ExportReq,
+	opts ...grpc.CallOption) (pbv2.Player_ExportClient, error) {
+	sc, err := lc.rpc.Export(ctx, &pb.ExportReq{
+		Format: in.Format,
+		Kind:   in.Kind,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return legacyExportClient{sc}, nil
+}
+
+func (lc legacyClient) GetStats(ctx context.Context, in *pbv2.Empty,
+	opts ...grpc.CallOption) (*pbv2.GetStatsResp, error) {
+	res, err := lc.rpc.GetStats(ctx, &pb.Empty{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.GetStatsResp{
+		Stats: protocpv2.StatsToProto(protocp.StatsFromProto(res.Stats)),
+	}, nil
+}
+
+func (lc legacyClient) CheckTotal(ctx context.Context, in *pbv2.TotalClaim,
+	opts ...grpc.CallOption) (*pbv2.TotalCheck, error) {
+	res, err := lc.rpc.CheckTotal(ctx, protocp.TotalClaimToProto(
+		protocpv2.TotalClaimFromProto(in)), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return protocpv2.TotalCheckToProto(protocp.TotalCheckFromProto(res)), nil
+}
+
+// legacyExportClient receives the first version's export chunks as the
+// second version's.
+type legacyExportClient struct {
+	pb.Player_ExportClient
+}
+
+func (ec legacyExportClient) Recv() (*pbv2.ExportChunk, error) {
+	chunk, err := ec.Player_ExportClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbv2.ExportChunk{Data: chunk.Data}, nil
+}
+
+func partsToV2(in *pb.GetPartsResp) (*pbv2.GetPartsResp, error) {
+	var res pbv2.GetPartsResp
+	for _, p := range in.Parts {
+		part, err := protocp.PartFromProto(p)
+		if err != nil {
+			return nil, err
+		}
+
+		partProto, err := protocpv2.PartToProto(part)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Parts = append(res.Parts, partProto)
+	}
+
+	return &res, nil
+}
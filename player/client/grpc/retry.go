@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"unsure/player"
+)
+
+const (
+	defaultCallTimeout      = 5 * time.Second
+	defaultRetries          = 3
+	defaultBackoff          = 100 * time.Millisecond
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 10 * time.Second
+)
+
+// idempotent defines the Player RPCs that are safe to retry, in every
+// version of the API.
+var idempotent = map[string]bool{
+	"Ping":          true,
+	"GetName":       true,
+	"Hello":         true,
+	"GetRound":      true,
+	"GetParts":      true,
+	"GetRoundParts": true,
+	"Leave":         true,
+	"GetRoundAudit": true,
+	"GetStats":      true,
+	"CheckTotal":    true,
+}
+
+// intercept applies the client's default deadline, retry policy and circuit
+// breaker to unary calls, and translates transport errors into typed player
+// errors. The cached API version is forgotten if the Player is unreachable.
+func (c *client) intercept(ctx context.Context, method string,
+	req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption) error {
+	if !c.breaker.allow() {
+		return errors.Wrap(player.ErrUnreachable, "circuit breaker open",
+			j.KV("address", c.address))
+	}
+
+	attempts := 1
+	if idempotent[path.Base(method)] {
+		attempts += c.retries
+	}
+
+	var err error
+	backoff := c.backoff
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				c.breaker.record(true)
+				return toTyped(err, c.address)
+			case <-time.After(backoff):
+				backoff *= 2
+			}
+		}
+
+		err = c.invoke(ctx, method, req, reply, cc, invoker, opts...)
+		if !isUnreachable(err) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	c.breaker.record(isUnreachable(err))
+	if isUnreachable(err) {
+		c.resetVersion()
+	}
+
+	return toTyped(err, c.address)
+}
+
+// invoke makes a single call, bounded by the default call timeout if the
+// context has no deadline of its own.
+func (c *client) invoke(ctx context.Context, method string,
+	req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && c.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.callTimeout)
+		defer cancel()
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// isUnreachable returns whether the error indicates a transport failure
+// rather than an error returned by the Player itself.
+func isUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// statusErrors maps gRPC status codes to the typed player errors returned
+// when the status carries no jettison error of its own.
+var statusErrors = map[codes.Code]error{
+	codes.NotFound:           player.ErrNotFound,
+	codes.InvalidArgument:    player.ErrInvalidArgument,
+	codes.FailedPrecondition: player.ErrNotReady,
+	codes.PermissionDenied:   player.ErrPermissionDenied,
+	codes.Unimplemented:      errUnimplemented,
+}
+
+// errUnimplemented indicates that the Player doesn't serve the called RPC,
+// usually because it runs an older build.
+var errUnimplemented = errors.New("rpc not implemented",
+	j.C("ERR_2e94c1b7a05d8f36"))
+
+// toTyped translates gRPC status errors into typed player errors. Jettison
+// errors returned by the Player itself are returned unchanged so that their
+// codes, like player.ErrRoundNotFound, can be matched with errors.Is.
+func toTyped(err error, address string) error {
+	if err == nil {
+		return nil
+	}
+
+	st := status.Convert(err)
+	if isUnreachable(err) {
+		return errors.Wrap(player.ErrUnreachable, st.Message(),
+			j.KV("address", address), j.KS("code", st.Code().String()))
+	}
+
+	if _, jerr := errors.FromStatus(st); jerr == nil {
+		return err
+	}
+
+	if typed, ok := statusErrors[st.Code()]; ok {
+		return errors.Wrap(typed, st.Message(), j.KV("address", address))
+	}
+
+	return err
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"unsure/player"
+)
+
+func TestIntercept(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	tests := []struct {
+		name    string
+		method  string
+		errs    []error
+		open    bool
+		calls   int
+		wantErr error
+	}{
+		{
+			name:   "idempotent retried until success",
+			method: "/playerpb.v2.Player/GetRound",
+			errs:   []error{unavailable, unavailable, nil},
+			calls:  3,
+		},
+		{
+			name:    "idempotent retried until exhausted",
+			method:  "/playerpb.v2.Player/GetRound",
+			errs:    []error{unavailable, unavailable, unavailable},
+			calls:   3,
+			wantErr: player.ErrUnreachable,
+		},
+		{
+			name:    "other methods not retried",
+			method:  "/playerpb.v2.Player/Submit",
+			errs:    []error{unavailable, nil},
+			calls:   1,
+			wantErr: player.ErrUnreachable,
+		},
+		{
+			name:    "stops on errors from the player",
+			method:  "/playerpb.v2.Player/GetRound",
+			errs:    []error{status.Error(codes.NotFound, "no round"), nil},
+			calls:   1,
+			wantErr: player.ErrNotFound,
+		},
+		{
+			name:    "breaker open fails fast",
+			method:  "/playerpb.v2.Player/GetRound",
+			errs:    []error{nil},
+			open:    true,
+			calls:   0,
+			wantErr: player.ErrUnreachable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &client{
+				address: "test",
+				retries: 2,
+				backoff: time.Millisecond,
+				breaker: &breaker{threshold: 1, cooldown: time.Hour},
+			}
+			if test.open {
+				c.breaker.record(true)
+			}
+
+			var calls int
+			invoker := func(ctx context.Context, method string,
+				req, reply interface{}, cc *grpc.ClientConn,
+				opts ...grpc.CallOption) error {
+				err := test.errs[calls]
+				calls++
+				return err
+			}
+
+			err := c.intercept(context.Background(), test.method, nil, nil,
+				nil, invoker)
+			if test.wantErr == nil && err != nil {
+				t.Errorf("got error %v, want nil", err)
+			} else if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+			if calls != test.calls {
+				t.Errorf("got %d calls, want %d", calls, test.calls)
+			}
+		})
+	}
+}
+
+// TestInterceptOpensBreaker checks that calls which exhaust their retries
+// count towards opening the breaker, after which calls fail fast.
+func TestInterceptOpensBreaker(t *testing.T) {
+	c := &client{
+		address: "test",
+		retries: 1,
+		backoff: time.Millisecond,
+		breaker: &breaker{threshold: 2, cooldown: time.Hour},
+	}
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	for i := 0; i < 3; i++ {
+		err := c.intercept(context.Background(),
+			"/playerpb.v2.Player/GetRound", nil, nil, nil, invoker)
+		if !errors.Is(err, player.ErrUnreachable) {
+			t.Errorf("got error %v, want unreachable", err)
+		}
+	}
+
+	if calls != 4 {
+		t.Errorf("got %d calls, want 4", calls)
+	}
+	if s := c.breaker.current(); s != BreakerStateOpen {
+		t.Errorf("got state %v, want %v", s, BreakerStateOpen)
+	}
+}
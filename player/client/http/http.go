@@ -0,0 +1,380 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/gateway"
+)
+
+var _ player.Client = (*client)(nil)
+
+const defaultCallTimeout = 5 * time.Second
+
+// errReadOnly is returned by requests which would change the Player, since
+// the gateway is read-only.
+var errReadOnly = errors.New("player gateway is read-only",
+	j.C("ERR_6b2f0d84e9c17a35"))
+
+type clientOpt func(c *client)
+
+// WithAddress provides an option to specify the base URL of a Player's HTTP
+// gateway, e.g. http://localhost:8080.
+func WithAddress(address string) clientOpt {
+	return func(c *client) {
+		c.address = strings.TrimSuffix(address, "/")
+	}
+}
+
+// WithCallTimeout provides an option to specify the deadline applied to
+// requests made with a context without one. Event streams are not subject
+// to the deadline. Zero disables the deadline.
+func WithCallTimeout(d time.Duration) clientOpt {
+	return func(c *client) {
+		c.callTimeout = d
+	}
+}
+
+// WithHTTPClient provides an option to specify the underlying HTTP client.
+func WithHTTPClient(hc *http.Client) clientOpt {
+	return func(c *client) {
+		c.hc = hc
+	}
+}
+
+// New returns an HTTP client for a Player's gateway.
+func New(opts ...clientOpt) (player.Client, error) {
+	c := client{
+		hc:          http.DefaultClient,
+		callTimeout: defaultCallTimeout,
+	}
+
+	for _, o := range opts {
+		o(&c)
+	}
+
+	if c.address == "" {
+		return nil, errors.New("no address provided")
+	}
+
+	return &c, nil
+}
+
+type client struct {
+	address     string
+	hc          *http.Client
+	callTimeout time.Duration
+}
+
+func (c *client) Ping(ctx context.Context) error {
+	return c.call(ctx, http.MethodGet, "/ping", nil, nil)
+}
+
+// StreamEvents returns a reflex.StreamClient that can be used to
+// stream reflex events from a Player.
+func (c *client) StreamEvents(ctx context.Context, after string,
+	opts ...reflex.StreamOption) (reflex.StreamClient, error) {
+	var so reflex.StreamOptions
+	for _, o := range opts {
+		o(&so)
+	}
+
+	q := url.Values{}
+	q.Set("after", after)
+	if so.StreamFromHead {
+		q.Set("from_head", "true")
+	}
+	if so.Lag > 0 {
+		q.Set("lag", so.Lag.String())
+	}
+
+	req, err := http.NewRequest(http.MethodGet,
+		c.address+"/events?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(player.ErrUnreachable, err.Error(),
+			j.KV("address", c.address))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, readError(resp)
+	}
+
+	return &streamClient{
+		body:    resp.Body,
+		scanner: bufio.NewScanner(resp.Body),
+	}, nil
+}
+
+// GetName returns a Player's name.
+func (c *client) GetName(ctx context.Context) (string, error) {
+	var resp gateway.NameResp
+	err := c.call(ctx, http.MethodGet, "/name", nil, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Name, nil
+}
+
+// Hello returns a Player's identity, protocol version and capabilities.
+func (c *client) Hello(ctx context.Context) (*player.Hello, error) {
+	var resp gateway.Hello
+	err := c.call(ctx, http.MethodGet, "/hello", nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to say hello")
+	}
+
+	return gateway.HelloFromJSON(resp), nil
+}
+
+// Leave returns errReadOnly, since peers may only announce that they are
+// leaving over the authenticated gRPC API.
+func (c *client) Leave(ctx context.Context, name string) error {
+	return errors.Wrap(errReadOnly, "leave not supported",
+		j.KV("address", c.address))
+}
+
+// GetParts returns a Player's parts received for a given round.
+func (c *client) GetParts(ctx context.Context, externalID int64) (
+	[]player.Part, error) {
+	var resp gateway.PartsResp
+	err := c.call(ctx, http.MethodGet,
+		"/parts/"+strconv.FormatInt(externalID, 10), nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get parts")
+	}
+
+	var parts []player.Part
+	for _, p := range resp.Parts {
+		parts = append(parts, gateway.PartFromJSON(p))
+	}
+
+	return parts, nil
+}
+
+// GetRoundParts returns every part a Player holds for a given round.
+func (c *client) GetRoundParts(ctx context.Context, externalID int64) (
+	[]player.Part, error) {
+	var resp gateway.PartsResp
+	err := c.call(ctx, http.MethodGet,
+		"/parts/"+strconv.FormatInt(externalID, 10)+"/all", nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get round parts")
+	}
+
+	var parts []player.Part
+	for _, p := range resp.Parts {
+		parts = append(parts, gateway.PartFromJSON(p))
+	}
+
+	return parts, nil
+}
+
+// GetRound returns a local rounds from a Player's DB.
+func (c *client) GetRound(ctx context.Context, roundID int64) (
+	*player.Round, error) {
+	var resp gateway.Round
+	err := c.call(ctx, http.MethodGet,
+		"/rounds/"+strconv.FormatInt(roundID, 10), nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get round")
+	}
+
+	return gateway.RoundFromJSON(resp), nil
+}
+
+// GetRoundAudit returns the audit trail of a local round in a Player's DB.
+func (c *client) GetRoundAudit(ctx context.Context, roundID int64) (
+	[]player.RoundAudit, error) {
+	var resp gateway.AuditResp
+	err := c.call(ctx, http.MethodGet,
+		"/rounds/"+strconv.FormatInt(roundID, 10)+"/audit", nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get round audit")
+	}
+
+	var audit []player.RoundAudit
+	for _, a := range resp.Audit {
+		audit = append(audit, gateway.RoundAuditFromJSON(a))
+	}
+
+	return audit, nil
+}
+
+// GetStats returns the aggregate results of the Player's completed rounds.
+func (c *client) GetStats(ctx context.Context) (*player.Stats, error) {
+	var resp gateway.Stats
+	err := c.call(ctx, http.MethodGet, "/stats", nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stats")
+	}
+
+	return gateway.StatsFromJSON(resp), nil
+}
+
+// CheckTotal asks a Player to compare the claimed total against the parts it
+// collected from the claimant.
+func (c *client) CheckTotal(ctx context.Context, claim player.TotalClaim) (
+	*player.TotalCheck, error) {
+	var resp gateway.TotalCheck
+	err := c.call(ctx, http.MethodPost, "/totals/check",
+		gateway.TotalClaimToJSON(claim), &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check total")
+	}
+
+	return gateway.TotalCheckFromJSON(resp), nil
+}
+
+// Export writes the Player's round results or summary to w as it is
+// streamed from the gateway.
+func (c *client) Export(ctx context.Context, format, kind string,
+	w io.Writer) error {
+	q := url.Values{}
+	q.Set("format", format)
+	q.Set("kind", kind)
+
+	req, err := http.NewRequest(http.MethodGet,
+		c.address+"/export?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(player.ErrUnreachable, err.Error(),
+			j.KV("address", c.address))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "failed to write export")
+	}
+
+	return nil
+}
+
+// call makes a JSON request to the gateway and decodes the response into
+// res, if provided.
+func (c *client) call(ctx context.Context, method, path string,
+	req, res interface{}) error {
+	if _, ok := ctx.Deadline(); !ok && c.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.callTimeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request")
+		}
+		body = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequest(method, c.address+path, body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.hc.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(player.ErrUnreachable, err.Error(),
+			j.KV("address", c.address))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+
+	if res == nil {
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(res)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode response")
+	}
+
+	return nil
+}
+
+// readError returns the error described by a non-2xx response. Errors
+// carrying a jettison code can be matched with errors.Is, e.g. against
+// player.ErrRoundNotFound.
+func readError(resp *http.Response) error {
+	var er gateway.ErrorResp
+	err := json.NewDecoder(resp.Body).Decode(&er)
+	if err != nil || er.Error == "" {
+		return errors.New("unexpected response",
+			j.KV("status", resp.StatusCode))
+	}
+
+	if er.Code != "" {
+		return errors.New(er.Error, j.C(er.Code))
+	}
+
+	return errors.New(er.Error, j.KV("status", resp.StatusCode))
+}
+
+// streamClient reads round events from a Server-Sent Events response.
+type streamClient struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// Recv returns the next event in the stream. It returns io.EOF once the
+// stream has ended.
+func (sc *streamClient) Recv() (*reflex.Event, error) {
+	for sc.scanner.Scan() {
+		line := sc.scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			// Ignore the id, event and blank lines since the data
+			// contains the whole event.
+			continue
+		}
+
+		var e gateway.Event
+		err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode event")
+		}
+
+		return gateway.EventFromJSON(e), nil
+	}
+
+	sc.body.Close()
+
+	if err := sc.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
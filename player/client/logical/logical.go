@@ -0,0 +1,103 @@
+// Package logical provides an in-process Player client which calls the
+// Player's business logic directly rather than over gRPC. It is used to
+// connect Players running together in a single process.
+package logical
+
+import (
+	"context"
+	"io"
+
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/export"
+	"unsure/player/internal/db/rounds"
+	"unsure/player/ops"
+)
+
+var _ player.Client = (*client)(nil)
+
+// New returns an in-process client for the Player with the provided
+// backends and config.
+func New(b ops.Backends, conf player.Config) player.Client {
+	return &client{
+		b:      b,
+		conf:   conf,
+		stream: rounds.EventStream(b.PlayerDB()),
+	}
+}
+
+type client struct {
+	b      ops.Backends
+	conf   player.Config
+	stream reflex.StreamFunc
+}
+
+func (c *client) Ping(ctx context.Context) error {
+	return c.b.PlayerDB().PingContext(ctx)
+}
+
+// StreamEvents returns a reflex.StreamClient that can be used to
+// stream reflex events from a Player.
+func (c *client) StreamEvents(ctx context.Context, after string,
+	opts ...reflex.StreamOption) (reflex.StreamClient, error) {
+	return c.stream(ctx, after, opts...)
+}
+
+// GetName returns a Player's name.
+func (c *client) GetName(ctx context.Context) (string, error) {
+	return c.conf.PlayerName, nil
+}
+
+// Hello returns a Player's identity, protocol version and capabilities.
+func (c *client) Hello(ctx context.Context) (*player.Hello, error) {
+	return player.NewHello(c.conf), nil
+}
+
+// Leave notifies a Player that the named peer is shutting down.
+func (c *client) Leave(ctx context.Context, name string) error {
+	return ops.PeerLeaving(ctx, c.b, name)
+}
+
+// GetParts returns a Player's parts received for a given round.
+func (c *client) GetParts(ctx context.Context, externalID int64) (
+	[]player.Part, error) {
+	return ops.GetParts(ctx, c.b, c.conf, externalID)
+}
+
+// GetRoundParts returns every part a Player holds for a given round.
+func (c *client) GetRoundParts(ctx context.Context, externalID int64) (
+	[]player.Part, error) {
+	return ops.GetRoundParts(ctx, c.b, externalID)
+}
+
+// GetRound returns a local rounds from a Player's DB.
+func (c *client) GetRound(ctx context.Context, roundID int64) (
+	*player.Round, error) {
+	return ops.GetRound(ctx, c.b, roundID)
+}
+
+// GetRoundAudit returns the audit trail of a local round in a Player's DB.
+func (c *client) GetRoundAudit(ctx context.Context, roundID int64) (
+	[]player.RoundAudit, error) {
+	return ops.GetRoundAudit(ctx, c.b, roundID)
+}
+
+// CheckTotal compares the claimed total against the parts the Player
+// collected from the claimant.
+func (c *client) CheckTotal(ctx context.Context, claim player.TotalClaim) (
+	*player.TotalCheck, error) {
+	return ops.CheckTotal(ctx, c.b, c.conf, claim)
+}
+
+// GetStats returns the aggregate results of the Player's completed rounds.
+func (c *client) GetStats(ctx context.Context) (*player.Stats, error) {
+	return ops.GetStats(ctx, c.b)
+}
+
+// Export writes the Player's round results or summary to w.
+func (c *client) Export(ctx context.Context, format, kind string,
+	w io.Writer) error {
+	return export.Write(ctx, w, c.b.PlayerDB(), c.conf, export.Format(format),
+		export.Kind(kind))
+}
@@ -0,0 +1,801 @@
+package player
+
+import (
+	"flag"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"gopkg.in/yaml.v2"
+)
+
+// envPrefix is prepended to the upper case flag name to obtain the
+// environment variable of a config field, e.g. UNSURE_TEAM_NAME.
+const envPrefix = "UNSURE_"
+
+const (
+	defaultShutdownTimeout = 10 * time.Second
+	defaultSubmitDeadline  = 5 * time.Second
+	defaultLeaseTTL        = 10 * time.Second
+)
+
+// Submission strategies, which decide when a Player submits its parts. See
+// ops.Strategy.
+const (
+	// StrategyAllPeers submits once every peer has submitted.
+	StrategyAllPeers = "all_peers"
+
+	// StrategyRankOrdered submits once every lower-ranked peer has
+	// submitted.
+	StrategyRankOrdered = "rank_ordered"
+
+	// StrategyDeadline submits in rank order, but without waiting longer
+	// than the submit deadline for lower-ranked peers.
+	StrategyDeadline = "deadline"
+)
+
+var strategies = map[string]bool{
+	StrategyAllPeers:    true,
+	StrategyRankOrdered: true,
+	StrategyDeadline:    true,
+}
+
+// Total verification modes, which decide what happens when a peer disagrees
+// with the total the Player is about to submit.
+const (
+	// VerifyTotalsOff skips the cross-check with peers.
+	VerifyTotalsOff = "off"
+
+	// VerifyTotalsAlert records and logs disagreements, but still submits.
+	VerifyTotalsAlert = "alert"
+
+	// VerifyTotalsBlock records disagreements and fails the round instead of
+	// submitting.
+	VerifyTotalsBlock = "block"
+)
+
+var verifyModes = map[string]bool{
+	VerifyTotalsOff:   true,
+	VerifyTotalsAlert: true,
+	VerifyTotalsBlock: true,
+}
+
+// Config defines the configuration of a Player. It is loaded with LoadConfig
+// and passed to the Player's state, loops and server so that multiple Players
+// may be constructed in a single process.
+type Config struct {
+	// TeamName is the name of the team the Player is a member of.
+	TeamName string `yaml:"team_name"`
+
+	// PlayerName is the Player's name, unique within the team.
+	PlayerName string `yaml:"player_name"`
+
+	// Debug enables verbose logging.
+	Debug bool `yaml:"debug"`
+
+	// PlayerDB is the name of the Player's MySQL database.
+	PlayerDB string `yaml:"player_db"`
+
+	// GRPCAddress is the host:port the Player's gRPC server listens on.
+	GRPCAddress string `yaml:"grpc_address"`
+
+	// HTTPAddress is the host:port the Player's HTTP/JSON gateway and status
+	// page listen on. Both are disabled if empty. Neither is authenticated,
+	// so the host must be a loopback address.
+	HTTPAddress string `yaml:"http_address"`
+
+	// GRPCReflection enables gRPC server reflection for debugging with
+	// generic tools.
+	GRPCReflection bool `yaml:"grpc_reflection"`
+
+	// Peers are the other Players in the team, each as name=host:port. The
+	// names identify the peers allowed to call the Player and may only be
+	// omitted in insecure mode. Peers running replicas list the address of
+	// each, as name=host1:port|host2:port, so that calls fail over to the
+	// replica leading. See PeerAddresses and PeerNames.
+	Peers []string `yaml:"peers"`
+
+	// TLS configures mutual TLS between the Player and its peers.
+	TLS TLSConfig `yaml:"tls"`
+
+	// SigningKey is the path to the Player's PEM encoded EC private key used
+	// to sign parts.
+	SigningKey string `yaml:"signing_key"`
+
+	// PeerKeys maps peer names to the paths of their public keys or
+	// certificates. Every peer requires a key unless AllowUnsigned is set.
+	PeerKeys map[string]string `yaml:"peer_keys"`
+
+	// AllowUnsigned accepts the parts of peers without a configured key
+	// unverified, and doesn't require a signing key (local dev only).
+	AllowUnsigned bool `yaml:"allow_unsigned"`
+
+	// ShutdownTimeout bounds how long in-flight round work may take to
+	// finish when the Player is stopped.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	// SubmitStrategy decides when the Player submits its parts, one of
+	// StrategyAllPeers, StrategyRankOrdered or StrategyDeadline.
+	SubmitStrategy string `yaml:"submit_strategy"`
+
+	// SubmitDeadline is how long StrategyDeadline waits for lower-ranked
+	// peers after collecting before submitting anyway.
+	SubmitDeadline time.Duration `yaml:"submit_deadline"`
+
+	// VerifyTotals decides how disagreements are handled when peers
+	// cross-check the total before it is submitted, one of VerifyTotalsOff,
+	// VerifyTotalsAlert or VerifyTotalsBlock.
+	VerifyTotals string `yaml:"verify_totals"`
+
+	// ConsistencyCheckPeriod is how often the Player compares its recent
+	// rounds' parts with its peers' copies. Zero disables the check.
+	ConsistencyCheckPeriod time.Duration `yaml:"consistency_check_period"`
+
+	// ReplicaID identifies this process among the replicas of the Player
+	// sharing its database. If set, only the replica holding the Player's
+	// lease runs the consumers, while the others stand by to take over.
+	ReplicaID string `yaml:"replica_id"`
+
+	// LeaseTTL is how long a replica holds the Player's lease without
+	// renewing it, and so bounds how long a failed replica's rounds go
+	// unplayed.
+	LeaseTTL time.Duration `yaml:"lease_ttl"`
+}
+
+// TLSConfig defines the certificates used to mutually authenticate a Player
+// and its peers.
+type TLSConfig struct {
+	// CA is the path to the team CA certificate.
+	CA string `yaml:"ca"`
+
+	// Cert is the path to the Player's certificate.
+	Cert string `yaml:"cert"`
+
+	// Key is the path to the Player's private key.
+	Key string `yaml:"key"`
+
+	// Insecure disables TLS and peer authentication (local dev only).
+	Insecure bool `yaml:"insecure"`
+}
+
+// field defines a config field that can be set by a flag or environment
+// variable.
+type field struct {
+	name   string
+	usage  string
+	isBool bool
+	set    func(c *Config, v string) error
+}
+
+var fields = []field{
+	{name: "team_name", usage: "Name of the team",
+		set: func(c *Config, v string) error {
+			c.TeamName = v
+			return nil
+		}},
+	{name: "player_name", usage: "Name of the player",
+		set: func(c *Config, v string) error {
+			c.PlayerName = v
+			return nil
+		}},
+	{name: "debug", usage: "Enable debug mode", isBool: true,
+		set: func(c *Config, v string) error {
+			return setBool(&c.Debug, v)
+		}},
+	{name: "player_db", usage: "Database name for player",
+		set: func(c *Config, v string) error {
+			c.PlayerDB = v
+			return nil
+		}},
+	{name: "grpc_address", usage: "player grpc address",
+		set: func(c *Config, v string) error {
+			c.GRPCAddress = v
+			return nil
+		}},
+	{name: "http_address",
+		usage: "player http/json gateway and status page address " +
+			"(disabled if empty)",
+		set: func(c *Config, v string) error {
+			c.HTTPAddress = v
+			return nil
+		}},
+	{name: "grpc_reflection", isBool: true,
+		usage: "Enable gRPC server reflection",
+		set: func(c *Config, v string) error {
+			return setBool(&c.GRPCReflection, v)
+		}},
+	{name: "peers", usage: "List of peers as name=host:port (comma separated)",
+		set: func(c *Config, v string) error {
+			c.Peers = splitList(v)
+			return nil
+		}},
+	{name: "tls_ca", usage: "Path to the team CA certificate",
+		set: func(c *Config, v string) error {
+			c.TLS.CA = v
+			return nil
+		}},
+	{name: "tls_cert", usage: "Path to the player certificate",
+		set: func(c *Config, v string) error {
+			c.TLS.Cert = v
+			return nil
+		}},
+	{name: "tls_key", usage: "Path to the player private key",
+		set: func(c *Config, v string) error {
+			c.TLS.Key = v
+			return nil
+		}},
+	{name: "grpc_insecure", isBool: true,
+		usage: "Disable TLS and peer authentication (local dev only)",
+		set: func(c *Config, v string) error {
+			return setBool(&c.TLS.Insecure, v)
+		}},
+	{name: "signing_key",
+		usage: "Path to the player's PEM encoded EC private key used to sign parts",
+		set: func(c *Config, v string) error {
+			c.SigningKey = v
+			return nil
+		}},
+	{name: "peer_keys",
+		usage: "List of peer public keys or certificates as name=path (comma separated)",
+		set: func(c *Config, v string) error {
+			keys, err := parsePeerKeys(v)
+			if err != nil {
+				return err
+			}
+			c.PeerKeys = keys
+			return nil
+		}},
+	{name: "allow_unsigned", isBool: true,
+		usage: "Accept unsigned parts from peers without keys (local dev only)",
+		set: func(c *Config, v string) error {
+			return setBool(&c.AllowUnsigned, v)
+		}},
+	{name: "shutdown_timeout",
+		usage: "Max duration to wait for in-flight round work on shutdown",
+		set: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse duration")
+			}
+			c.ShutdownTimeout = d
+			return nil
+		}},
+	{name: "submit_strategy",
+		usage: "When to submit parts: all_peers, rank_ordered or deadline",
+		set: func(c *Config, v string) error {
+			c.SubmitStrategy = v
+			return nil
+		}},
+	{name: "submit_deadline",
+		usage: "Max duration the deadline strategy waits for lower-ranked peers",
+		set: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse duration")
+			}
+			c.SubmitDeadline = d
+			return nil
+		}},
+	{name: "verify_totals",
+		usage: "Handling of peers disagreeing with our total: off, alert or block",
+		set: func(c *Config, v string) error {
+			c.VerifyTotals = v
+			return nil
+		}},
+	{name: "consistency_check_period",
+		usage: "How often to compare parts with peers (zero disables)",
+		set: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse duration")
+			}
+			c.ConsistencyCheckPeriod = d
+			return nil
+		}},
+	{name: "replica_id",
+		usage: "Unique id of this replica of the Player (empty disables replicas)",
+		set: func(c *Config, v string) error {
+			c.ReplicaID = v
+			return nil
+		}},
+	{name: "lease_ttl",
+		usage: "Max duration a replica holds the Player's lease without renewing it",
+		set: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse duration")
+			}
+			c.LeaseTTL = d
+			return nil
+		}},
+}
+
+// flagValue records the value of a field's flag and whether it was set.
+type flagValue struct {
+	field
+	value string
+	set   bool
+}
+
+func (v *flagValue) String() string {
+	return v.value
+}
+
+func (v *flagValue) Set(s string) error {
+	v.value = s
+	v.set = true
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// LoadConfig registers the Player's flags with the flag set, parses the
+// arguments and returns the validated config. Flags take precedence over
+// environment variables, which take precedence over the optional YAML or
+// JSON file provided by the "config" flag or UNSURE_CONFIG variable.
+func LoadConfig(fs *flag.FlagSet, args []string) (*Config, error) {
+	path := fs.String("config", "", "Path to a YAML or JSON config file")
+
+	values := make([]*flagValue, 0, len(fields))
+	for _, f := range fields {
+		v := &flagValue{field: f}
+		fs.Var(v, f.name, f.usage)
+		values = append(values, v)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *path == "" {
+		*path = os.Getenv(envPrefix + "CONFIG")
+	}
+
+	c := Config{
+		ShutdownTimeout: defaultShutdownTimeout,
+		SubmitStrategy:  StrategyAllPeers,
+		SubmitDeadline:  defaultSubmitDeadline,
+		VerifyTotals:    VerifyTotalsAlert,
+		LeaseTTL:        defaultLeaseTTL,
+	}
+	if *path != "" {
+		if err := loadFile(*path, &c); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields {
+		v, ok := os.LookupEnv(envPrefix + strings.ToUpper(f.name))
+		if !ok {
+			continue
+		}
+		if err := f.set(&c, v); err != nil {
+			return nil, errors.Wrap(err, "invalid environment variable",
+				j.KV("name", envPrefix+strings.ToUpper(f.name)))
+		}
+	}
+
+	for _, v := range values {
+		if !v.set {
+			continue
+		}
+		if err := v.field.set(&c, v.value); err != nil {
+			return nil, errors.Wrap(err, "invalid flag",
+				j.KV("name", v.name))
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// loadFile populates v from a YAML config file. Since JSON is a subset of
+// YAML, JSON files are supported too.
+func loadFile(path string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read config file",
+			j.KV("path", path))
+	}
+
+	err = yaml.UnmarshalStrict(b, v)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse config file",
+			j.KV("path", path))
+	}
+
+	return nil
+}
+
+// TeamConfig defines the configuration of a team of Players run in a single
+// process.
+type TeamConfig struct {
+	// TeamName is the name of the team, inherited by each Player.
+	TeamName string `yaml:"team_name"`
+
+	// Debug enables verbose logging for all Players.
+	Debug bool `yaml:"debug"`
+
+	// Players are the configs of the team's Players. A Player's database
+	// defaults to "<team_name>_<player_name>" and its peers to the gRPC
+	// addresses of the other Players.
+	Players []Config `yaml:"players"`
+}
+
+// LoadTeamConfig parses the arguments and returns the validated team config
+// from the YAML or JSON file provided by the "config" flag or UNSURE_CONFIG
+// variable.
+func LoadTeamConfig(fs *flag.FlagSet, args []string) (*TeamConfig, error) {
+	path := fs.String("config", "", "Path to a YAML or JSON team config file")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *path == "" {
+		*path = os.Getenv(envPrefix + "CONFIG")
+	}
+
+	if *path == "" {
+		return nil, errors.Wrap(ErrInvalidConfig, "config file required")
+	}
+
+	var tc TeamConfig
+	if err := loadFile(*path, &tc); err != nil {
+		return nil, err
+	}
+
+	tc.applyDefaults()
+
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &tc, nil
+}
+
+// applyDefaults populates each Player's config with the team's settings
+// where not provided.
+func (tc *TeamConfig) applyDefaults() {
+	for i := range tc.Players {
+		c := &tc.Players[i]
+		if c.TeamName == "" {
+			c.TeamName = tc.TeamName
+		}
+
+		c.Debug = c.Debug || tc.Debug
+
+		if c.ShutdownTimeout == 0 {
+			c.ShutdownTimeout = defaultShutdownTimeout
+		}
+
+		if c.SubmitStrategy == "" {
+			c.SubmitStrategy = StrategyAllPeers
+		}
+
+		if c.SubmitDeadline == 0 {
+			c.SubmitDeadline = defaultSubmitDeadline
+		}
+
+		if c.VerifyTotals == "" {
+			c.VerifyTotals = VerifyTotalsAlert
+		}
+
+		if c.LeaseTTL == 0 {
+			c.LeaseTTL = defaultLeaseTTL
+		}
+
+		if c.PlayerDB == "" && c.PlayerName != "" {
+			c.PlayerDB = strings.ToLower(tc.TeamName + "_" + c.PlayerName)
+		}
+
+		if len(c.Peers) == 0 {
+			for k, peer := range tc.Players {
+				if k != i {
+					c.Peers = append(c.Peers,
+						joinPeer(peer.PlayerName, peer.GRPCAddress))
+				}
+			}
+		}
+	}
+}
+
+// Validate returns an error if the team config or any of its Players'
+// configs are incomplete or inconsistent.
+func (tc *TeamConfig) Validate() error {
+	if tc.TeamName == "" {
+		return errors.Wrap(ErrInvalidConfig, "team_name required")
+	}
+
+	if len(tc.Players) < 2 {
+		return errors.Wrap(ErrInvalidConfig, "at least two players required")
+	}
+
+	names := make(map[string]bool)
+	dbs := make(map[string]bool)
+	addresses := make(map[string]bool)
+	for _, c := range tc.Players {
+		if err := c.Validate(); err != nil {
+			return errors.Wrap(err, "invalid player config",
+				j.KV("player", c.PlayerName))
+		}
+
+		if c.TeamName != tc.TeamName {
+			return errors.Wrap(ErrInvalidConfig, "team_name mismatch",
+				j.KV("player", c.PlayerName))
+		}
+
+		if names[strings.ToLower(c.PlayerName)] {
+			return errors.Wrap(ErrInvalidConfig, "duplicate player name",
+				j.KV("player", c.PlayerName))
+		}
+		names[strings.ToLower(c.PlayerName)] = true
+
+		if dbs[c.PlayerDB] {
+			return errors.Wrap(ErrInvalidConfig, "duplicate player_db",
+				j.KV("player", c.PlayerName))
+		}
+		dbs[c.PlayerDB] = true
+
+		if addresses[c.GRPCAddress] {
+			return errors.Wrap(ErrInvalidConfig, "duplicate grpc_address",
+				j.KV("player", c.PlayerName))
+		}
+		addresses[c.GRPCAddress] = true
+
+		if c.HTTPAddress == "" {
+			continue
+		}
+
+		if addresses[c.HTTPAddress] {
+			return errors.Wrap(ErrInvalidConfig, "duplicate http_address",
+				j.KV("player", c.PlayerName))
+		}
+		addresses[c.HTTPAddress] = true
+	}
+
+	return nil
+}
+
+// Validate returns an error if the config is incomplete or inconsistent.
+func (c *Config) Validate() error {
+	if c.TeamName == "" {
+		return errors.Wrap(ErrInvalidConfig, "team_name required")
+	}
+
+	if c.PlayerName == "" {
+		return errors.Wrap(ErrInvalidConfig, "player_name required")
+	}
+
+	if c.PlayerDB == "" {
+		return errors.Wrap(ErrInvalidConfig, "player_db required")
+	}
+
+	if _, _, err := net.SplitHostPort(c.GRPCAddress); err != nil {
+		return errors.Wrap(ErrInvalidConfig, "invalid grpc_address",
+			j.KV("address", c.GRPCAddress))
+	}
+
+	if c.HTTPAddress != "" {
+		host, _, err := net.SplitHostPort(c.HTTPAddress)
+		if err != nil {
+			return errors.Wrap(ErrInvalidConfig, "invalid http_address",
+				j.KV("address", c.HTTPAddress))
+		}
+
+		if !isLoopback(host) {
+			return errors.Wrap(ErrInvalidConfig, "http_address must be a "+
+				"loopback address", j.KV("address", c.HTTPAddress))
+		}
+	}
+
+	if len(c.Peers) == 0 {
+		return errors.Wrap(ErrInvalidConfig, "at least one peer required")
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range c.Peers {
+		name, address := splitPeer(p)
+		for _, a := range SplitReplicas(address) {
+			if _, _, err := net.SplitHostPort(a); err != nil {
+				return errors.Wrap(ErrInvalidConfig, "invalid peer address",
+					j.KV("address", a))
+			}
+
+			if seen[a] {
+				return errors.Wrap(ErrInvalidConfig,
+					"duplicate peer address", j.KV("address", a))
+			}
+			seen[a] = true
+		}
+
+		if name == "" && !c.TLS.Insecure {
+			return errors.Wrap(ErrInvalidConfig, "peer name required "+
+				"unless grpc_insecure is set", j.KV("address", address))
+		}
+	}
+
+	if !c.TLS.Insecure &&
+		(c.TLS.CA == "" || c.TLS.Cert == "" || c.TLS.Key == "") {
+		return errors.Wrap(ErrInvalidConfig, "tls_ca, tls_cert and "+
+			"tls_key are required unless grpc_insecure is set")
+	}
+
+	names := make(map[string]bool)
+	for _, n := range c.PeerNames() {
+		if names[strings.ToLower(n)] {
+			return errors.Wrap(ErrInvalidConfig, "duplicate peer name",
+				j.KV("name", n))
+		}
+		names[strings.ToLower(n)] = true
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		return errors.Wrap(ErrInvalidConfig, "invalid shutdown_timeout")
+	}
+
+	if !strategies[c.SubmitStrategy] {
+		return errors.Wrap(ErrInvalidConfig, "unknown submit_strategy",
+			j.KV("strategy", c.SubmitStrategy))
+	}
+
+	if c.SubmitDeadline <= 0 {
+		return errors.Wrap(ErrInvalidConfig, "invalid submit_deadline")
+	}
+
+	if c.ConsistencyCheckPeriod < 0 {
+		return errors.Wrap(ErrInvalidConfig,
+			"invalid consistency_check_period")
+	}
+
+	if c.LeaseTTL <= 0 {
+		return errors.Wrap(ErrInvalidConfig, "invalid lease_ttl")
+	}
+
+	if !verifyModes[c.VerifyTotals] {
+		return errors.Wrap(ErrInvalidConfig, "unknown verify_totals",
+			j.KV("mode", c.VerifyTotals))
+	}
+
+	keys := make(map[string]bool)
+	for name, path := range c.PeerKeys {
+		if name == "" || path == "" {
+			return errors.Wrap(ErrInvalidConfig, "invalid peer key",
+				j.KV("name", name))
+		}
+		keys[strings.ToLower(name)] = true
+	}
+
+	if !c.AllowUnsigned {
+		if c.SigningKey == "" || len(c.PeerKeys) == 0 {
+			return errors.Wrap(ErrInvalidConfig, "signing_key and "+
+				"peer_keys are required unless allow_unsigned is set")
+		}
+
+		for _, n := range c.PeerNames() {
+			if !keys[strings.ToLower(n)] {
+				return errors.Wrap(ErrInvalidConfig, "peer key required "+
+					"unless allow_unsigned is set", j.KV("name", n))
+			}
+		}
+	}
+
+	return nil
+}
+
+func setBool(b *bool, v string) error {
+	parsed, err := strconv.ParseBool(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse bool")
+	}
+
+	*b = parsed
+	return nil
+}
+
+func splitList(s string) []string {
+	var l []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			l = append(l, v)
+		}
+	}
+
+	return l
+}
+
+// isLoopback returns whether the host only accepts local connections.
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// PeerAddresses returns the addresses of the Player's peers, each either a
+// host:port or the addresses of the peer's replicas. See SplitReplicas.
+func (c Config) PeerAddresses() []string {
+	var res []string
+	for _, p := range c.Peers {
+		_, address := splitPeer(p)
+		res = append(res, address)
+	}
+
+	return res
+}
+
+// PeerNames returns the names of the Player's peers, which are the only
+// callers allowed unless running in insecure mode.
+func (c Config) PeerNames() []string {
+	var res []string
+	for _, p := range c.Peers {
+		if name, _ := splitPeer(p); name != "" {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+// splitPeer splits a name=host:port peer into its name, which is empty if
+// omitted, and its address.
+func splitPeer(s string) (name, address string) {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 {
+		return "", strings.TrimSpace(s)
+	}
+
+	return strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+}
+
+// replicaSep separates the addresses of a peer's replicas.
+const replicaSep = "|"
+
+// SplitReplicas splits a peer address into the host:port addresses of the
+// peer's replicas. A peer without replicas has a single address.
+func SplitReplicas(address string) []string {
+	var res []string
+	for _, a := range strings.Split(address, replicaSep) {
+		res = append(res, strings.TrimSpace(a))
+	}
+
+	return res
+}
+
+// joinPeer returns the name=host:port peer, or just the address if the name
+// is empty.
+func joinPeer(name, address string) string {
+	if name == "" {
+		return address
+	}
+
+	return name + "=" + address
+}
+
+// parsePeerKeys parses a comma separated list of name=path peer keys.
+func parsePeerKeys(s string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, entry := range splitList(s) {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" ||
+			strings.TrimSpace(kv[1]) == "" {
+			return nil, errors.New("invalid peer key entry",
+				j.KV("entry", entry))
+		}
+		keys[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,256 @@
+// Package consistency compares the copies of a round's parts held by each
+// Player in a team. Every Player stores its teammates' parts as collected
+// from them, and the copies can silently diverge through duplicate inserts,
+// missed collects or wrong ranks.
+package consistency
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/rounds"
+)
+
+// Field defines what differs between two copies of a player's parts.
+type Field string
+
+const (
+	// FieldMissing copies hold none of the player's parts.
+	FieldMissing Field = "missing"
+
+	// FieldValues copies hold different part values, or a different number
+	// of parts.
+	FieldValues Field = "values"
+
+	// FieldRank copies record a different rank for the player.
+	FieldRank Field = "rank"
+
+	// FieldSubmitted copies disagree on whether the player submitted.
+	FieldSubmitted Field = "submitted"
+)
+
+// Diff defines a difference between a player's parts and a teammate's copy
+// of them. The player's own view is the reference, unless it couldn't be
+// read, in which case the local Player's copy is.
+type Diff struct {
+	ExternalID int64
+	// Owner is the player the parts belong to.
+	Owner string
+	// Viewer is the player holding the copy.
+	Viewer string
+	Field  Field
+	Want   string
+	Got    string
+}
+
+// Report defines the result of a consistency check.
+type Report struct {
+	// Rounds is the number of rounds checked.
+	Rounds int
+	Diffs  []Diff
+	// Errors describe the peers whose copies couldn't be read.
+	Errors []string
+}
+
+// Peer is a teammate whose copies of the parts are checked.
+type Peer struct {
+	Name   string
+	Client player.Client
+}
+
+// ResolvePeers returns the peers with their names. Peers which can't be
+// reached are left out and described in the returned errors.
+func ResolvePeers(ctx context.Context, cl []player.Client) ([]Peer,
+	[]string) {
+	var (
+		res  []Peer
+		errs []string
+	)
+	for _, c := range cl {
+		name, err := c.GetName(ctx)
+		if err != nil {
+			errs = append(errs, "failed to get peer name: "+err.Error())
+			continue
+		}
+
+		res = append(res, Peer{Name: name, Client: c})
+	}
+
+	return res, errs
+}
+
+// Check compares the local Player's parts for up to limit of its most recent
+// completed rounds with its peers' copies. Active rounds are skipped since
+// their copies are still expected to change.
+func Check(ctx context.Context, dbc *sql.DB, playerName string,
+	peers []Peer, limit int) (*Report, error) {
+	rl, err := rounds.ListRecent(ctx, dbc, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list recent rounds")
+	}
+
+	var res Report
+	for i := len(rl) - 1; i >= 0; i-- {
+		r := rl[i]
+		if r.Status != player.RoundStatusSuccess &&
+			r.Status != player.RoundStatusFailed {
+			continue
+		}
+
+		local, err := parts.ListByRound(ctx, dbc, r.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list parts",
+				j.KV("round", r.ID))
+		}
+
+		views := map[string][]player.Part{strings.ToLower(playerName): local}
+		for _, p := range peers {
+			pl, err := p.Client.GetRoundParts(ctx, r.ExternalID)
+			if errors.Is(err, player.ErrRoundNotFound) {
+				// The peer never joined the round, so it holds no copies
+				// to compare.
+				continue
+			} else if err != nil {
+				res.Errors = append(res.Errors, "round "+
+					strconv.FormatInt(r.ExternalID, 10)+": "+p.Name+": "+
+					err.Error())
+				continue
+			}
+
+			views[strings.ToLower(p.Name)] = pl
+		}
+
+		res.Rounds++
+		res.Diffs = append(res.Diffs,
+			compare(r.ExternalID, playerName, views)...)
+	}
+
+	return &res, nil
+}
+
+// copyOf summarises a viewer's copy of a player's parts.
+type copyOf struct {
+	values    string
+	rank      string
+	submitted string
+}
+
+// compare returns the differences between each player's parts and the
+// viewers' copies of them. Only ranked parts are compared, since those are
+// the ones collected from the player itself rather than dealt to the viewer
+// by the engine. Viewers holding no parts at all were excluded from the
+// round before collecting, so they aren't expected to hold copies.
+func compare(externalID int64, playerName string,
+	views map[string][]player.Part) []Diff {
+	owners := make(map[string]bool)
+	viewers := make(map[string]bool)
+	for viewer, pl := range views {
+		if len(pl) > 0 {
+			viewers[viewer] = true
+		}
+		for _, p := range pl {
+			if p.Rank != 0 {
+				owners[strings.ToLower(p.Player)] = true
+			}
+		}
+	}
+
+	var res []Diff
+	for _, owner := range sortedKeys(owners) {
+		ref := owner
+		if _, ok := views[ref]; !ok {
+			ref = strings.ToLower(playerName)
+		}
+		want := summarise(views[ref], owner)
+
+		for _, viewer := range sortedKeys(viewers) {
+			if viewer == ref {
+				continue
+			}
+
+			got := summarise(views[viewer], owner)
+			diff := Diff{ExternalID: externalID, Owner: owner,
+				Viewer: viewer}
+
+			if got.values == "" && want.values != "" {
+				diff.Field, diff.Want, diff.Got = FieldMissing,
+					want.values, ""
+				res = append(res, diff)
+				continue
+			}
+
+			if got.values != want.values {
+				diff.Field, diff.Want, diff.Got = FieldValues,
+					want.values, got.values
+				res = append(res, diff)
+			}
+
+			if got.rank != want.rank {
+				diff.Field, diff.Want, diff.Got = FieldRank,
+					want.rank, got.rank
+				res = append(res, diff)
+			}
+
+			if got.submitted != want.submitted {
+				diff.Field, diff.Want, diff.Got = FieldSubmitted,
+					want.submitted, got.submitted
+				res = append(res, diff)
+			}
+		}
+	}
+
+	return res
+}
+
+// summarise returns the viewer's copy of the owner's ranked parts.
+func summarise(pl []player.Part, owner string) copyOf {
+	var (
+		values    []int64
+		ranks     = make(map[string]bool)
+		submitted = true
+	)
+	for _, p := range pl {
+		if p.Rank == 0 || !strings.EqualFold(p.Player, owner) {
+			continue
+		}
+
+		values = append(values, p.Value)
+		ranks[strconv.FormatInt(p.Rank, 10)] = true
+		submitted = submitted && p.Submitted
+	}
+
+	if len(values) == 0 {
+		return copyOf{}
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	var vl []string
+	for _, v := range values {
+		vl = append(vl, strconv.FormatInt(v, 10))
+	}
+
+	return copyOf{
+		values:    strings.Join(vl, ","),
+		rank:      strings.Join(sortedKeys(ranks), ","),
+		submitted: strconv.FormatBool(submitted),
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var res []string
+	for k := range m {
+		res = append(res, k)
+	}
+
+	sort.Strings(res)
+	return res
+}
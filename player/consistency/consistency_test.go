@@ -0,0 +1,134 @@
+package consistency
+
+import (
+	"reflect"
+	"testing"
+
+	"unsure/player"
+)
+
+func TestCompare(t *testing.T) {
+	alice := []player.Part{
+		{Player: "alice", Rank: 1, Value: 10, Submitted: true},
+		{Player: "bob", Rank: 2, Value: 20, Submitted: true},
+		{Player: "alice", Value: 5},
+	}
+
+	tests := []struct {
+		name  string
+		views map[string][]player.Part
+		want  []Diff
+	}{
+		{
+			name: "consistent",
+			views: map[string][]player.Part{
+				"alice": alice,
+				"bob":   alice,
+			},
+		},
+		{
+			name: "missing",
+			views: map[string][]player.Part{
+				"alice": alice,
+				"bob":   alice,
+				"carol": alice[:1],
+			},
+			want: []Diff{{ExternalID: 42, Owner: "bob", Viewer: "carol",
+				Field: FieldMissing, Want: "20"}},
+		},
+		{
+			name: "values",
+			views: map[string][]player.Part{
+				"alice": alice,
+				"bob": {
+					{Player: "alice", Rank: 1, Value: 11, Submitted: true},
+					{Player: "bob", Rank: 2, Value: 20, Submitted: true},
+				},
+			},
+			want: []Diff{{ExternalID: 42, Owner: "alice", Viewer: "bob",
+				Field: FieldValues, Want: "10", Got: "11"}},
+		},
+		{
+			name: "rank and submitted",
+			views: map[string][]player.Part{
+				"alice": alice,
+				"bob": {
+					{Player: "alice", Rank: 3, Value: 10},
+					{Player: "bob", Rank: 2, Value: 20, Submitted: true},
+				},
+			},
+			want: []Diff{{ExternalID: 42, Owner: "alice", Viewer: "bob",
+				Field: FieldRank, Want: "1", Got: "3"}, {ExternalID: 42,
+				Owner: "alice", Viewer: "bob", Field: FieldSubmitted,
+				Want: "true", Got: "false"}},
+		},
+		{
+			name: "excluded viewer",
+			views: map[string][]player.Part{
+				"alice": alice,
+				"bob":   alice,
+				"carol": nil,
+			},
+		},
+		{
+			name: "owner unread uses local copy",
+			views: map[string][]player.Part{
+				"alice": alice,
+				"carol": {
+					{Player: "alice", Rank: 1, Value: 10, Submitted: true},
+					{Player: "bob", Rank: 2, Value: 21, Submitted: true},
+				},
+			},
+			want: []Diff{{ExternalID: 42, Owner: "bob", Viewer: "carol",
+				Field: FieldValues, Want: "20", Got: "21"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := compare(42, "alice", test.views)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	tests := []struct {
+		name string
+		pl   []player.Part
+		want copyOf
+	}{
+		{name: "none"},
+		{
+			name: "unranked parts ignored",
+			pl:   []player.Part{{Player: "bob", Value: 3}},
+		},
+		{
+			name: "sorted values",
+			pl: []player.Part{
+				{Player: "Bob", Rank: 2, Value: 30, Submitted: true},
+				{Player: "bob", Rank: 2, Value: 4, Submitted: true},
+				{Player: "alice", Rank: 1, Value: 7},
+			},
+			want: copyOf{values: "4,30", rank: "2", submitted: "true"},
+		},
+		{
+			name: "conflicting ranks and partial submission",
+			pl: []player.Part{
+				{Player: "bob", Rank: 2, Value: 5, Submitted: true},
+				{Player: "bob", Rank: 3, Value: 6},
+			},
+			want: copyOf{values: "5,6", rank: "2,3", submitted: "false"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := summarise(test.pl, "bob"); got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package consistency
+
+import (
+	"fmt"
+	"io"
+)
+
+// Write writes the report as text, one difference per line followed by a
+// summary.
+func Write(w io.Writer, r *Report) error {
+	for _, d := range r.Diffs {
+		_, err := fmt.Fprintf(w, "round %d: %s's copy of %s's parts: "+
+			"%s want %q got %q\n", d.ExternalID, d.Viewer, d.Owner, d.Field,
+			d.Want, d.Got)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, e := range r.Errors {
+		if _, err := fmt.Fprintf(w, "error: %s\n", e); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d rounds checked, %d differences, "+
+		"%d errors\n", r.Rounds, len(r.Diffs), len(r.Errors))
+	return err
+}
@@ -0,0 +1,61 @@
+package player
+
+import (
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+var (
+	// ErrUnreachable indicates that a Player could not be reached, either
+	// because it is down, timed out or its circuit breaker is open.
+	ErrUnreachable = errors.New("player unreachable",
+		j.C("ERR_3b9e0f5d7c21a846"))
+
+	// ErrNotFound indicates that a Player was reachable but the requested
+	// resource does not exist.
+	ErrNotFound = errors.New("not found", j.C("ERR_c40d8e2a6b1f9375"))
+
+	// ErrInvalidArgument indicates that a request to a Player was malformed.
+	ErrInvalidArgument = errors.New("invalid argument",
+		j.C("ERR_0e7a4d9c13b58f62"))
+
+	// ErrNotReady indicates that the requested resource exists but is not
+	// yet in a state to be served.
+	ErrNotReady = errors.New("not ready", j.C("ERR_9d2c6b0a81e4f735"))
+
+	// ErrRoundNotFound indicates that a Player has no record of a round,
+	// usually because it hasn't been notified of it yet.
+	ErrRoundNotFound = errors.New("round not found",
+		j.C("ERR_5a18e3f7c0d29b64"))
+
+	// ErrRoundNotReady indicates that a Player has not yet collected its
+	// parts for a round.
+	ErrRoundNotReady = errors.New("round not ready",
+		j.C("ERR_e6f1b7304c9a2d58"))
+
+	// ErrRoundConflict indicates that a round wasn't in the status a shift
+	// expected, usually because it was shifted concurrently.
+	ErrRoundConflict = errors.New("round status conflict",
+		j.C("ERR_a36d0f82c5e91b47"))
+
+	// ErrIncompatiblePeer indicates that a peer's Hello showed it can't
+	// play with this Player, either because it is on another team or speaks
+	// an unsupported protocol version.
+	ErrIncompatiblePeer = errors.New("incompatible peer",
+		j.C("ERR_5f0b8e27d9c4a361"))
+
+	// ErrPermissionDenied indicates that the caller isn't allowed to make a
+	// request, such as announcing that another peer is leaving.
+	ErrPermissionDenied = errors.New("permission denied",
+		j.C("ERR_0c5e93b7a1f84d62"))
+
+	// ErrStandby indicates that the Player is a replica standing by for its
+	// lease, so it refuses requests which change the Player's state.
+	ErrStandby = errors.New("replica standing by",
+		j.C("ERR_b82f06d4e9a13c57"))
+
+	// ErrInvalidConfig indicates that a Player's config is incomplete or
+	// inconsistent.
+	ErrInvalidConfig = errors.New("invalid config",
+		j.C("ERR_71c9a4e02f6b3d85"))
+)
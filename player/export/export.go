@@ -0,0 +1,224 @@
+// Package export dumps a Player's round results and a summary of them to CSV
+// or JSON so that strategies can be compared offline.
+//
+// The summary covers all rounds stored in the Player's DB, which may span
+// several matches. Each exported round carries the match it belonged to.
+package export
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/rounds"
+)
+
+// listBatch is the number of rounds read from the DB at a time.
+const listBatch = 1000
+
+// stages are the non-terminal statuses a round spends time in, in order.
+var stages = []player.RoundStatus{
+	player.RoundStatusJoin,
+	player.RoundStatusJoined,
+	player.RoundStatusCollect,
+	player.RoundStatusCollected,
+	player.RoundStatusSubmit,
+	player.RoundStatusSubmitted,
+}
+
+// Outcome defines how a round ended.
+type Outcome string
+
+const (
+	OutcomeActive  Outcome = "active"
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailed  Outcome = "failed"
+)
+
+// Round defines the exported results of a round.
+type Round struct {
+	ExternalID int64
+	RoundID    int64
+	MatchID    int64
+	Status     player.RoundStatus
+	Outcome    Outcome
+	// Rank of the Player within the round, zero if never collected.
+	Rank  int64
+	Parts []player.Part
+	// TotalSubmitted is the sum of the Player's own submitted parts.
+	TotalSubmitted int64
+	// Stages is the time spent in each status the round passed through.
+	Stages map[player.RoundStatus]time.Duration
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Summary defines the summary of a Player's stored rounds.
+type Summary struct {
+	TeamName   string
+	PlayerName string
+	Rounds     int
+	Succeeded  int
+	Failed     int
+	Active     int
+	// TotalSubmitted is the sum of the Player's submitted parts over all
+	// rounds.
+	TotalSubmitted int64
+	// MeanStages is the mean time spent in each status by rounds which
+	// passed through it.
+	MeanStages map[player.RoundStatus]time.Duration
+
+	FirstRoundAt time.Time
+	LastRoundAt  time.Time
+}
+
+// SuccessRate returns the fraction of completed rounds which succeeded.
+func (m *Summary) SuccessRate() float64 {
+	if m.Succeeded+m.Failed == 0 {
+		return 0
+	}
+
+	return float64(m.Succeeded) / float64(m.Succeeded+m.Failed)
+}
+
+// listRounds calls fn with the results of each of the Player's rounds in
+// order.
+func listRounds(ctx context.Context, dbc *sql.DB, playerName string,
+	fn func(*Round) error) error {
+	var afterID int64
+	for {
+		rl, err := rounds.List(ctx, dbc, afterID, listBatch)
+		if err != nil {
+			return errors.Wrap(err, "failed to list rounds")
+		}
+
+		for _, r := range rl {
+			res, err := loadRound(ctx, dbc, playerName, r)
+			if err != nil {
+				return err
+			}
+
+			if err := fn(res); err != nil {
+				return err
+			}
+		}
+
+		if len(rl) < listBatch {
+			return nil
+		}
+		afterID = rl[len(rl)-1].ID
+	}
+}
+
+func loadRound(ctx context.Context, dbc *sql.DB, playerName string,
+	r player.Round) (*Round, error) {
+	tl, err := rounds.ListTransitions(ctx, dbc, r.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list transitions",
+			j.KV("round", r.ID))
+	}
+
+	pl, err := parts.ListByRound(ctx, dbc, r.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list parts",
+			j.KV("round", r.ID))
+	}
+
+	res := Round{
+		ExternalID: r.ExternalID,
+		RoundID:    r.ID,
+		MatchID:    r.MatchID,
+		Status:     r.Status,
+		Outcome:    outcome(r.Status),
+		Parts:      pl,
+		Stages:     make(map[player.RoundStatus]time.Duration),
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+
+	for _, p := range pl {
+		if !strings.EqualFold(p.Player, playerName) {
+			continue
+		}
+		if p.Rank != 0 {
+			res.Rank = p.Rank
+		}
+		if p.Submitted {
+			res.TotalSubmitted += p.Value
+		}
+	}
+
+	// A round spends time in each status until its next transition.
+	for i := 0; i+1 < len(tl); i++ {
+		res.Stages[tl[i].Status] += tl[i+1].Timestamp.Sub(tl[i].Timestamp)
+	}
+
+	return &res, nil
+}
+
+func outcome(s player.RoundStatus) Outcome {
+	switch s {
+	case player.RoundStatusSuccess:
+		return OutcomeSuccess
+	case player.RoundStatusFailed:
+		return OutcomeFailed
+	default:
+		return OutcomeActive
+	}
+}
+
+// summarize returns the summary of the Player's stored rounds.
+func summarize(ctx context.Context, dbc *sql.DB,
+	conf player.Config) (*Summary, error) {
+	m := Summary{
+		TeamName:   conf.TeamName,
+		PlayerName: conf.PlayerName,
+		MeanStages: make(map[player.RoundStatus]time.Duration),
+	}
+
+	totals := make(map[player.RoundStatus]time.Duration)
+	counts := make(map[player.RoundStatus]int)
+
+	err := listRounds(ctx, dbc, conf.PlayerName, func(r *Round) error {
+		m.Rounds++
+		switch r.Outcome {
+		case OutcomeSuccess:
+			m.Succeeded++
+		case OutcomeFailed:
+			m.Failed++
+		default:
+			m.Active++
+		}
+		m.TotalSubmitted += r.TotalSubmitted
+
+		if m.FirstRoundAt.IsZero() || r.CreatedAt.Before(m.FirstRoundAt) {
+			m.FirstRoundAt = r.CreatedAt
+		}
+		if r.UpdatedAt.After(m.LastRoundAt) {
+			m.LastRoundAt = r.UpdatedAt
+		}
+
+		for st, d := range r.Stages {
+			totals[st] += d
+			counts[st]++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for st, d := range totals {
+		m.MeanStages[st] = d / time.Duration(counts[st])
+	}
+
+	return &m, nil
+}
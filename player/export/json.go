@@ -0,0 +1,101 @@
+package export
+
+import (
+	"strings"
+	"time"
+)
+
+// roundJSON is the JSON representation of a Round.
+type roundJSON struct {
+	ExternalID     int64            `json:"external_id"`
+	RoundID        int64            `json:"round_id"`
+	MatchID        int64            `json:"match_id"`
+	Status         string           `json:"status"`
+	Outcome        Outcome          `json:"outcome"`
+	Rank           int64            `json:"rank"`
+	Parts          []partJSON       `json:"parts"`
+	TotalSubmitted int64            `json:"total_submitted"`
+	StagesMS       map[string]int64 `json:"stages_ms"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+type partJSON struct {
+	Player    string `json:"player"`
+	Rank      int64  `json:"rank"`
+	Value     int64  `json:"value"`
+	Submitted bool   `json:"submitted"`
+}
+
+// summaryJSON is the JSON representation of a Summary.
+type summaryJSON struct {
+	TeamName       string           `json:"team"`
+	PlayerName     string           `json:"player"`
+	Rounds         int              `json:"rounds"`
+	Succeeded      int              `json:"succeeded"`
+	Failed         int              `json:"failed"`
+	Active         int              `json:"active"`
+	SuccessRate    float64          `json:"success_rate"`
+	TotalSubmitted int64            `json:"total_submitted"`
+	MeanStagesMS   map[string]int64 `json:"mean_stages_ms"`
+	FirstRoundAt   *time.Time       `json:"first_round_at,omitempty"`
+	LastRoundAt    *time.Time       `json:"last_round_at,omitempty"`
+}
+
+func roundToJSON(r *Round) roundJSON {
+	res := roundJSON{
+		ExternalID:     r.ExternalID,
+		RoundID:        r.RoundID,
+		MatchID:        r.MatchID,
+		Status:         r.Status.String(),
+		Outcome:        r.Outcome,
+		Rank:           r.Rank,
+		Parts:          []partJSON{},
+		TotalSubmitted: r.TotalSubmitted,
+		StagesMS:       make(map[string]int64),
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+
+	for _, p := range r.Parts {
+		res.Parts = append(res.Parts, partJSON{
+			Player:    p.Player,
+			Rank:      p.Rank,
+			Value:     p.Value,
+			Submitted: p.Submitted,
+		})
+	}
+
+	for st, d := range r.Stages {
+		res.StagesMS[strings.ToLower(st.String())] =
+			int64(d / time.Millisecond)
+	}
+
+	return res
+}
+
+func summaryToJSON(m *Summary) summaryJSON {
+	res := summaryJSON{
+		TeamName:       m.TeamName,
+		PlayerName:     m.PlayerName,
+		Rounds:         m.Rounds,
+		Succeeded:      m.Succeeded,
+		Failed:         m.Failed,
+		Active:         m.Active,
+		SuccessRate:    m.SuccessRate(),
+		TotalSubmitted: m.TotalSubmitted,
+		MeanStagesMS:   make(map[string]int64),
+	}
+
+	for st, d := range m.MeanStages {
+		res.MeanStagesMS[strings.ToLower(st.String())] =
+			int64(d / time.Millisecond)
+	}
+
+	if !m.FirstRoundAt.IsZero() {
+		res.FirstRoundAt = &m.FirstRoundAt
+		res.LastRoundAt = &m.LastRoundAt
+	}
+
+	return res
+}
@@ -0,0 +1,225 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+)
+
+// Format defines the encoding of an export.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// Kind defines what an export contains.
+type Kind string
+
+const (
+	// KindRounds exports the results of every round.
+	KindRounds Kind = "rounds"
+
+	// KindSummary exports a summary of all the rounds in the Player's DB.
+	KindSummary Kind = "summary"
+)
+
+// Validate returns player.ErrInvalidArgument if the format and kind aren't
+// supported.
+func Validate(format Format, kind Kind) error {
+	if format != FormatCSV && format != FormatJSON {
+		return errors.Wrap(player.ErrInvalidArgument, "unknown format",
+			j.KV("format", format))
+	}
+
+	if kind != KindRounds && kind != KindSummary {
+		return errors.Wrap(player.ErrInvalidArgument, "unknown kind",
+			j.KV("kind", kind))
+	}
+
+	return nil
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format Format) string {
+	if format == FormatCSV {
+		return "text/csv"
+	}
+
+	return "application/json"
+}
+
+// Write writes the export of the Player's rounds or their summary to w.
+// Rounds are written as they are read, so large exports can be streamed.
+func Write(ctx context.Context, w io.Writer, dbc *sql.DB, conf player.Config,
+	format Format, kind Kind) error {
+	if err := Validate(format, kind); err != nil {
+		return err
+	}
+
+	if kind == KindSummary {
+		m, err := summarize(ctx, dbc, conf)
+		if err != nil {
+			return err
+		}
+
+		if format == FormatCSV {
+			return writeSummaryCSV(w, m)
+		}
+		return writeJSON(w, summaryToJSON(m))
+	}
+
+	if format == FormatCSV {
+		return writeRoundsCSV(ctx, w, dbc, conf)
+	}
+	return writeRoundsJSON(ctx, w, dbc, conf)
+}
+
+func writeRoundsCSV(ctx context.Context, w io.Writer, dbc *sql.DB,
+	conf player.Config) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"external_id", "round_id", "match_id", "status",
+		"outcome", "rank", "parts", "total_submitted"}
+	for _, st := range stages {
+		header = append(header, stageColumn(st))
+	}
+	header = append(header, "created_at", "updated_at")
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	err := listRounds(ctx, dbc, conf.PlayerName, func(r *Round) error {
+		row := []string{
+			strconv.FormatInt(r.ExternalID, 10),
+			strconv.FormatInt(r.RoundID, 10),
+			strconv.FormatInt(r.MatchID, 10),
+			r.Status.String(),
+			string(r.Outcome),
+			strconv.FormatInt(r.Rank, 10),
+			formatParts(r.Parts),
+			strconv.FormatInt(r.TotalSubmitted, 10),
+		}
+		for _, st := range stages {
+			row = append(row, formatMillis(r.Stages[st]))
+		}
+		row = append(row, formatTime(r.CreatedAt), formatTime(r.UpdatedAt))
+
+		return cw.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeSummaryCSV(w io.Writer, m *Summary) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"team", "player", "rounds", "succeeded", "failed",
+		"active", "success_rate", "total_submitted"}
+	for _, st := range stages {
+		header = append(header, "mean_"+stageColumn(st))
+	}
+	header = append(header, "first_round_at", "last_round_at")
+
+	row := []string{
+		m.TeamName,
+		m.PlayerName,
+		strconv.Itoa(m.Rounds),
+		strconv.Itoa(m.Succeeded),
+		strconv.Itoa(m.Failed),
+		strconv.Itoa(m.Active),
+		strconv.FormatFloat(m.SuccessRate(), 'f', 4, 64),
+		strconv.FormatInt(m.TotalSubmitted, 10),
+	}
+	for _, st := range stages {
+		row = append(row, formatMillis(m.MeanStages[st]))
+	}
+	row = append(row, formatTime(m.FirstRoundAt), formatTime(m.LastRoundAt))
+
+	if err := cw.WriteAll([][]string{header, row}); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+// writeRoundsJSON writes the rounds as a JSON array, one round per line.
+func writeRoundsJSON(ctx context.Context, w io.Writer, dbc *sql.DB,
+	conf player.Config) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	sep := "\n"
+	err := listRounds(ctx, dbc, conf.PlayerName, func(r *Round) error {
+		b, err := json.Marshal(roundToJSON(r))
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+		sep = ",\n"
+
+		_, err = w.Write(b)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n]\n")
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// stageColumn returns the column name of the time spent in a status, e.g.
+// "collected_ms".
+func stageColumn(st player.RoundStatus) string {
+	return strings.ToLower(st.String()) + "_ms"
+}
+
+// formatParts formats the parts as "player:rank:value" separated by spaces.
+func formatParts(pl []player.Part) string {
+	var res []string
+	for _, p := range pl {
+		res = append(res, p.Player+":"+strconv.FormatInt(p.Rank, 10)+":"+
+			strconv.FormatInt(p.Value, 10))
+	}
+
+	return strings.Join(res, " ")
+}
+
+func formatMillis(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Millisecond), 10)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,22 @@
+package gateway
+
+import (
+	"database/sql"
+
+	"github.com/corverroos/unsure/engine"
+
+	"unsure/player"
+	"unsure/player/internal/signing"
+	"unsure/player/ops"
+)
+
+// Backends defines the interface for the client dependencies required for
+// the Player's HTTP gateway to operate.
+type Backends interface {
+	PlayerDB() *sql.DB
+	EngineClient() engine.Client
+	Peers() []player.Client
+	Keyring() *signing.Keyring
+	Departures() *ops.Departures
+	Standby() *ops.Standby
+}
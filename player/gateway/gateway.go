@@ -0,0 +1,425 @@
+// Package gateway provides an HTTP/JSON front end to the Player API for
+// dashboards and scripts which can't speak gRPC. It mirrors the gRPC
+// service and streams round events as Server-Sent Events.
+//
+// The gateway is not authenticated, so it is read-only and only served on a
+// loopback address.
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/export"
+	"unsure/player/internal/db/rounds"
+	"unsure/player/ops"
+)
+
+const defaultListLimit = 100
+
+// statusCodes maps domain errors to the HTTP status codes returned to
+// callers.
+var statusCodes = []struct {
+	err  error
+	code int
+}{
+	{player.ErrRoundNotFound, http.StatusNotFound},
+	{player.ErrNotFound, http.StatusNotFound},
+	{player.ErrInvalidArgument, http.StatusBadRequest},
+	{player.ErrRoundNotReady, http.StatusConflict},
+	{player.ErrNotReady, http.StatusConflict},
+	{player.ErrStandby, http.StatusServiceUnavailable},
+}
+
+// Server serves the Player API over HTTP.
+type Server struct {
+	b      Backends
+	conf   player.Config
+	stream reflex.StreamFunc
+	mux    *http.ServeMux
+}
+
+// New returns the Player's HTTP gateway. The endpoints are:
+//
+//	GET  /ping
+//	GET  /name
+//	GET  /hello
+//	GET  /rounds?after_id=0&limit=100
+//	GET  /rounds/{round_id}
+//	GET  /rounds/{round_id}/audit
+//	GET  /parts/{external_id}
+//	GET  /parts/{external_id}/all
+//	GET  /events?after=&from_head=false&lag=0s  (text/event-stream)
+//	POST /totals/check  {"external_id": 0, "player": "...", "total": 0, ...}
+//	GET  /stats
+//	GET  /export?format=csv&kind=rounds
+func New(b Backends, conf player.Config) *Server {
+	srv := &Server{
+		b:      b,
+		conf:   conf,
+		stream: rounds.EventStream(b.PlayerDB()),
+		mux:    http.NewServeMux(),
+	}
+
+	srv.mux.HandleFunc("/ping", srv.ping)
+	srv.mux.HandleFunc("/name", srv.name)
+	srv.mux.HandleFunc("/hello", srv.hello)
+	srv.mux.HandleFunc("/rounds", srv.listRounds)
+	srv.mux.HandleFunc("/rounds/", srv.getRound)
+	srv.mux.HandleFunc("/parts/", srv.getParts)
+	srv.mux.HandleFunc("/events", srv.streamEvents)
+	srv.mux.HandleFunc("/totals/check", srv.checkTotal)
+	srv.mux.HandleFunc("/stats", srv.getStats)
+	srv.mux.HandleFunc("/export", srv.export)
+
+	return srv
+}
+
+// ServeHTTP implements http.Handler.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mux.ServeHTTP(w, r)
+}
+
+func (srv *Server) ping(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	if err := srv.b.PlayerDB().PingContext(r.Context()); err != nil {
+		writeError(w, r, errors.Wrap(err, "failed to ping player db"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (srv *Server) name(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NameResp{Name: srv.conf.PlayerName})
+}
+
+func (srv *Server) hello(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, HelloToJSON(player.NewHello(srv.conf)))
+}
+
+func (srv *Server) listRounds(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	afterID, err := queryInt(r, "after_id", 0)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	limit, err := queryInt(r, "limit", defaultListLimit)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rl, err := ops.ListRounds(r.Context(), srv.b, afterID, int(limit))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	resp := RoundsResp{Rounds: []Round{}}
+	for _, round := range rl {
+		resp.Rounds = append(resp.Rounds, RoundToJSON(&round))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (srv *Server) getRound(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/audit") {
+		srv.getRoundAudit(w, r)
+		return
+	}
+
+	id, err := pathInt(r.URL.Path, "/rounds/", "round_id")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	round, err := ops.GetRound(r.Context(), srv.b, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RoundToJSON(round))
+}
+
+func (srv *Server) getRoundAudit(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt(strings.TrimSuffix(r.URL.Path, "/audit"), "/rounds/",
+		"round_id")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	al, err := ops.GetRoundAudit(r.Context(), srv.b, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	resp := AuditResp{Audit: []RoundAudit{}}
+	for _, a := range al {
+		resp.Audit = append(resp.Audit, RoundAuditToJSON(&a))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (srv *Server) getParts(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	all := strings.HasSuffix(r.URL.Path, "/all")
+
+	externalID, err := pathInt(strings.TrimSuffix(r.URL.Path, "/all"),
+		"/parts/", "external_id")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var pl []player.Part
+	if all {
+		pl, err = ops.GetRoundParts(r.Context(), srv.b, externalID)
+	} else {
+		pl, err = ops.GetParts(r.Context(), srv.b, srv.conf, externalID)
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	resp := PartsResp{Parts: []Part{}}
+	for _, p := range pl {
+		resp.Parts = append(resp.Parts, PartToJSON(&p))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (srv *Server) checkTotal(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req TotalClaim
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, errors.Wrap(player.ErrInvalidArgument,
+			"invalid request body"))
+		return
+	}
+
+	check, err := ops.CheckTotal(r.Context(), srv.b, srv.conf,
+		TotalClaimFromJSON(req))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, TotalCheckToJSON(check))
+}
+
+func (srv *Server) getStats(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	s, err := ops.GetStats(r.Context(), srv.b)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, StatsToJSON(s))
+}
+
+// export streams the Player's round results or summary as CSV or
+// JSON. Errors after the response has started are logged since the status
+// has already been written.
+func (srv *Server) export(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	format := export.Format(r.URL.Query().Get("format"))
+	kind := export.Kind(r.URL.Query().Get("kind"))
+	if err := export.Validate(format, kind); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=%q", fmt.Sprintf("%s-%s.%s",
+			srv.conf.PlayerName, kind, format)))
+	w.WriteHeader(http.StatusOK)
+
+	err := export.Write(r.Context(), w, srv.b.PlayerDB(), srv.conf, format,
+		kind)
+	if err != nil && r.Context().Err() == nil {
+		log.Error(r.Context(), errors.Wrap(err, "export failed"))
+	}
+}
+
+// streamEvents streams round events as Server-Sent Events until the client
+// disconnects. Each event's data is a JSON encoded Event.
+func (srv *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, r, errors.New("streaming not supported"))
+		return
+	}
+
+	var opts []reflex.StreamOption
+	if r.URL.Query().Get("from_head") == "true" {
+		opts = append(opts, reflex.WithStreamFromHead())
+	}
+
+	if lag := r.URL.Query().Get("lag"); lag != "" {
+		d, err := time.ParseDuration(lag)
+		if err != nil {
+			writeError(w, r, errors.Wrap(player.ErrInvalidArgument,
+				"invalid lag", j.KV("lag", lag)))
+			return
+		}
+		opts = append(opts, reflex.WithStreamLag(d))
+	}
+
+	ctx := r.Context()
+	sc, err := srv.stream(ctx, r.URL.Query().Get("after"), opts...)
+	if err != nil {
+		writeError(w, r, errors.Wrap(err, "failed to stream events"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		e, err := sc.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error(ctx, errors.Wrap(err, "event stream error"))
+			}
+			return
+		}
+
+		data, err := json.Marshal(EventToJSON(e))
+		if err != nil {
+			log.Error(ctx, errors.Wrap(err, "failed to marshal event"))
+			return
+		}
+
+		_, err = fmt.Fprintf(w, "id: %s\nevent: %d\ndata: %s\n\n", e.ID,
+			e.Type.ReflexType(), data)
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+
+	w.Header().Set("Allow", method)
+	writeJSON(w, http.StatusMethodNotAllowed,
+		ErrorResp{Error: "method not allowed"})
+
+	return false
+}
+
+func queryInt(r *http.Request, key string, def int64) (int64, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return def, nil
+	}
+
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(player.ErrInvalidArgument,
+			"invalid query parameter", j.KV("key", key))
+	}
+
+	return i, nil
+}
+
+func pathInt(path, prefix, name string) (int64, error) {
+	i, err := strconv.ParseInt(strings.TrimPrefix(path, prefix), 10, 64)
+	if err != nil || i <= 0 {
+		return 0, errors.Wrap(player.ErrInvalidArgument, name+" required")
+	}
+
+	return i, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error(nil, errors.Wrap(err, "failed to write response"))
+	}
+}
+
+// writeError writes the error with the status code and jettison code of the
+// matching domain error, or 500 without a code if none match.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	code := http.StatusInternalServerError
+	resp := ErrorResp{Error: err.Error()}
+	for _, sc := range statusCodes {
+		if errors.Is(err, sc.err) {
+			code = sc.code
+			resp.Code = errors.GetCodes(sc.err)[0]
+			break
+		}
+	}
+
+	if code == http.StatusInternalServerError {
+		log.Error(r.Context(), errors.Wrap(err, "gateway request failed",
+			j.KV("path", r.URL.Path)))
+	}
+
+	writeJSON(w, code, resp)
+}
@@ -0,0 +1,373 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/luno/reflex"
+
+	"unsure/player"
+)
+
+// Round is the JSON representation of a player.Round.
+type Round struct {
+	ID         int64     `json:"id"`
+	ExternalID int64     `json:"external_id"`
+	Player     string    `json:"player"`
+	Status     int       `json:"status"`
+	StatusName string    `json:"status_name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Part is the JSON representation of a player.Part.
+type Part struct {
+	ID                 int64     `json:"id"`
+	RoundID            int64     `json:"round_id"`
+	Player             string    `json:"player"`
+	Rank               int64     `json:"rank"`
+	Value              int64     `json:"value"`
+	Submitted          bool      `json:"submitted"`
+	Signature          []byte    `json:"signature,omitempty"`
+	SubmittedSignature []byte    `json:"submitted_signature,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// RoundAudit is the JSON representation of a player.RoundAudit.
+type RoundAudit struct {
+	ID         int64     `json:"id"`
+	RoundID    int64     `json:"round_id"`
+	From       int       `json:"from"`
+	FromName   string    `json:"from_name"`
+	To         int       `json:"to"`
+	ToName     string    `json:"to_name"`
+	Trigger    string    `json:"trigger"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Stats is the JSON representation of a player.Stats. The derived rates are
+// included for convenience and ignored when decoding.
+type Stats struct {
+	Succeeded      int64        `json:"succeeded"`
+	Failed         int64        `json:"failed"`
+	Excluded       int64        `json:"excluded"`
+	SuccessRate    float64      `json:"success_rate"`
+	Matches        []MatchStats `json:"matches"`
+	Phases         []PhaseStats `json:"phases"`
+	Parts          int64        `json:"parts"`
+	PartsTotal     int64        `json:"parts_total"`
+	MeanPartValue  float64      `json:"mean_part_value"`
+	SubmittedTotal int64        `json:"submitted_total"`
+	Peers          []PeerStats  `json:"peers"`
+}
+
+// MatchStats is the JSON representation of a player.MatchStats.
+type MatchStats struct {
+	MatchID     int64   `json:"match_id"`
+	Succeeded   int64   `json:"succeeded"`
+	Failed      int64   `json:"failed"`
+	Excluded    int64   `json:"excluded"`
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// PhaseStats is the JSON representation of a player.PhaseStats.
+type PhaseStats struct {
+	Status     int    `json:"status"`
+	StatusName string `json:"status_name"`
+	Count      int64  `json:"count"`
+	MeanMS     int64  `json:"mean_ms"`
+	P95MS      int64  `json:"p95_ms"`
+}
+
+// PeerStats is the JSON representation of a player.PeerStats.
+type PeerStats struct {
+	Player          string `json:"player"`
+	LateSubmissions int64  `json:"late_submissions"`
+}
+
+// Hello is the JSON representation of a player.Hello.
+type Hello struct {
+	PlayerName      string   `json:"player_name"`
+	TeamName        string   `json:"team_name"`
+	BuildVersion    string   `json:"build_version"`
+	ProtocolVersion int      `json:"protocol_version"`
+	Capabilities    []string `json:"capabilities"`
+}
+
+// TotalClaim is the JSON representation of a player.TotalClaim.
+type TotalClaim struct {
+	ExternalID int64   `json:"external_id"`
+	Player     string  `json:"player"`
+	Total      int64   `json:"total"`
+	Parts      []int64 `json:"parts"`
+}
+
+// TotalCheck is the JSON representation of a player.TotalCheck.
+type TotalCheck struct {
+	Peer   string  `json:"peer"`
+	Known  bool    `json:"known"`
+	Agreed bool    `json:"agreed"`
+	Total  int64   `json:"total"`
+	Parts  []int64 `json:"parts"`
+}
+
+// Event is the JSON representation of a round event, sent as the data of
+// a Server-Sent Event.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      int       `json:"type"`
+	ForeignID string    `json:"foreign_id"`
+	Timestamp time.Time `json:"timestamp"`
+	MetaData  []byte    `json:"metadata,omitempty"`
+}
+
+// NameResp is the response of the name endpoint.
+type NameResp struct {
+	Name string `json:"name"`
+}
+
+// RoundsResp is the response of the rounds listing endpoint.
+type RoundsResp struct {
+	Rounds []Round `json:"rounds"`
+}
+
+// PartsResp is the response of the parts endpoint.
+type PartsResp struct {
+	Parts []Part `json:"parts"`
+}
+
+// AuditResp is the response of the round audit endpoint.
+type AuditResp struct {
+	Audit []RoundAudit `json:"audit"`
+}
+
+// ErrorResp is returned with non-2xx responses. Code is the jettison code of
+// the domain error, if any, which allows clients to match errors like
+// player.ErrRoundNotFound with errors.Is.
+type ErrorResp struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// RoundToJSON converts a player.Round to a Round.
+func RoundToJSON(in *player.Round) Round {
+	return Round{
+		ID:         in.ID,
+		ExternalID: in.ExternalID,
+		Player:     in.Player,
+		Status:     int(in.Status),
+		StatusName: in.Status.String(),
+		CreatedAt:  in.CreatedAt,
+		UpdatedAt:  in.UpdatedAt,
+	}
+}
+
+// RoundFromJSON converts a Round to a player.Round.
+func RoundFromJSON(in Round) *player.Round {
+	return &player.Round{
+		ID:         in.ID,
+		ExternalID: in.ExternalID,
+		Player:     in.Player,
+		Status:     player.RoundStatus(in.Status),
+		CreatedAt:  in.CreatedAt,
+		UpdatedAt:  in.UpdatedAt,
+	}
+}
+
+// PartToJSON converts a player.Part to a Part.
+func PartToJSON(in *player.Part) Part {
+	return Part{
+		ID:                 in.ID,
+		RoundID:            in.RoundID,
+		Player:             in.Player,
+		Rank:               in.Rank,
+		Value:              in.Value,
+		Submitted:          in.Submitted,
+		Signature:          in.Signature,
+		SubmittedSignature: in.SubmittedSignature,
+		CreatedAt:          in.CreatedAt,
+		UpdatedAt:          in.UpdatedAt,
+	}
+}
+
+// PartFromJSON converts a Part to a player.Part.
+func PartFromJSON(in Part) player.Part {
+	return player.Part{
+		ID:                 in.ID,
+		RoundID:            in.RoundID,
+		Player:             in.Player,
+		Rank:               in.Rank,
+		Value:              in.Value,
+		Submitted:          in.Submitted,
+		Signature:          in.Signature,
+		SubmittedSignature: in.SubmittedSignature,
+		CreatedAt:          in.CreatedAt,
+		UpdatedAt:          in.UpdatedAt,
+	}
+}
+
+// RoundAuditToJSON converts a player.RoundAudit to a RoundAudit.
+func RoundAuditToJSON(in *player.RoundAudit) RoundAudit {
+	return RoundAudit{
+		ID:         in.ID,
+		RoundID:    in.RoundID,
+		From:       int(in.From),
+		FromName:   in.From.String(),
+		To:         int(in.To),
+		ToName:     in.To.String(),
+		Trigger:    in.Trigger,
+		Error:      in.Error,
+		DurationMS: int64(in.Duration / time.Millisecond),
+		CreatedAt:  in.CreatedAt,
+	}
+}
+
+// RoundAuditFromJSON converts a RoundAudit to a player.RoundAudit.
+func RoundAuditFromJSON(in RoundAudit) player.RoundAudit {
+	return player.RoundAudit{
+		ID:        in.ID,
+		RoundID:   in.RoundID,
+		From:      player.RoundStatus(in.From),
+		To:        player.RoundStatus(in.To),
+		Trigger:   in.Trigger,
+		Error:     in.Error,
+		Duration:  time.Duration(in.DurationMS) * time.Millisecond,
+		CreatedAt: in.CreatedAt,
+	}
+}
+
+// StatsToJSON converts a player.Stats to a Stats.
+func StatsToJSON(in *player.Stats) Stats {
+	res := Stats{
+		Succeeded:      in.Succeeded,
+		Failed:         in.Failed,
+		Excluded:       in.Excluded,
+		SuccessRate:    in.SuccessRate(),
+		Matches:        []MatchStats{},
+		Phases:         []PhaseStats{},
+		Parts:          in.Parts,
+		PartsTotal:     in.PartsTotal,
+		MeanPartValue:  in.MeanPartValue(),
+		SubmittedTotal: in.SubmittedTotal,
+		Peers:          []PeerStats{},
+	}
+
+	for _, ms := range in.Matches {
+		res.Matches = append(res.Matches, MatchStats{
+			MatchID:     ms.MatchID,
+			Succeeded:   ms.Succeeded,
+			Failed:      ms.Failed,
+			Excluded:    ms.Excluded,
+			SuccessRate: ms.SuccessRate(),
+		})
+	}
+
+	for _, ps := range in.Phases {
+		res.Phases = append(res.Phases, PhaseStats{
+			Status:     int(ps.Status),
+			StatusName: ps.Status.String(),
+			Count:      ps.Count,
+			MeanMS:     int64(ps.Mean / time.Millisecond),
+			P95MS:      int64(ps.P95 / time.Millisecond),
+		})
+	}
+
+	for _, ps := range in.Peers {
+		res.Peers = append(res.Peers, PeerStats(ps))
+	}
+
+	return res
+}
+
+// StatsFromJSON converts a Stats to a player.Stats.
+func StatsFromJSON(in Stats) *player.Stats {
+	res := player.Stats{
+		Succeeded:      in.Succeeded,
+		Failed:         in.Failed,
+		Excluded:       in.Excluded,
+		Parts:          in.Parts,
+		PartsTotal:     in.PartsTotal,
+		SubmittedTotal: in.SubmittedTotal,
+	}
+
+	for _, ms := range in.Matches {
+		res.Matches = append(res.Matches, player.MatchStats{
+			MatchID:   ms.MatchID,
+			Succeeded: ms.Succeeded,
+			Failed:    ms.Failed,
+			Excluded:  ms.Excluded,
+		})
+	}
+
+	for _, ps := range in.Phases {
+		res.Phases = append(res.Phases, player.PhaseStats{
+			Status: player.RoundStatus(ps.Status),
+			Count:  ps.Count,
+			Mean:   time.Duration(ps.MeanMS) * time.Millisecond,
+			P95:    time.Duration(ps.P95MS) * time.Millisecond,
+		})
+	}
+
+	for _, ps := range in.Peers {
+		res.Peers = append(res.Peers, player.PeerStats(ps))
+	}
+
+	return &res
+}
+
+// HelloToJSON converts a player.Hello to a Hello.
+func HelloToJSON(in *player.Hello) Hello {
+	return Hello(*in)
+}
+
+// HelloFromJSON converts a Hello to a player.Hello.
+func HelloFromJSON(in Hello) *player.Hello {
+	res := player.Hello(in)
+	return &res
+}
+
+// TotalClaimToJSON converts a player.TotalClaim to a TotalClaim.
+func TotalClaimToJSON(in player.TotalClaim) TotalClaim {
+	return TotalClaim(in)
+}
+
+// TotalClaimFromJSON converts a TotalClaim to a player.TotalClaim.
+func TotalClaimFromJSON(in TotalClaim) player.TotalClaim {
+	return player.TotalClaim(in)
+}
+
+// TotalCheckToJSON converts a player.TotalCheck to a TotalCheck.
+func TotalCheckToJSON(in *player.TotalCheck) TotalCheck {
+	return TotalCheck(*in)
+}
+
+// TotalCheckFromJSON converts a TotalCheck to a player.TotalCheck.
+func TotalCheckFromJSON(in TotalCheck) *player.TotalCheck {
+	res := player.TotalCheck(in)
+	return &res
+}
+
+// EventToJSON converts a reflex.Event to an Event.
+func EventToJSON(in *reflex.Event) Event {
+	return Event{
+		ID:        in.ID,
+		Type:      in.Type.ReflexType(),
+		ForeignID: in.ForeignID,
+		Timestamp: in.Timestamp,
+		MetaData:  in.MetaData,
+	}
+}
+
+// EventFromJSON converts an Event to a reflex.Event. Round events are typed
+// by their player.RoundStatus.
+func EventFromJSON(in Event) *reflex.Event {
+	return &reflex.Event{
+		ID:        in.ID,
+		Type:      player.RoundStatus(in.Type),
+		ForeignID: in.ForeignID,
+		Timestamp: in.Timestamp,
+		MetaData:  in.MetaData,
+	}
+}
@@ -0,0 +1,88 @@
+package player
+
+import (
+	"strings"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+const (
+	// ProtocolVersion is the version of the protocol spoken between peers.
+	// Version 2 introduced the playerpb/v2 API, while still serving the
+	// first version to older peers.
+	ProtocolVersion = 2
+
+	// MinProtocolVersion is the oldest protocol version of a peer this build
+	// interoperates with.
+	MinProtocolVersion = 1
+)
+
+// BuildVersion identifies the Player's build. It is set at link time with
+// -ldflags "-X unsure/player.BuildVersion=<version>".
+var BuildVersion = "dev"
+
+// Capabilities of optional features advertised by peers in their Hello.
+const (
+	// CapRichEvents peers embed a RoundMeta in their round events, so
+	// consumers don't need to call back for the round's details.
+	CapRichEvents = "rich_events"
+)
+
+// capabilities are the optional features supported by this build.
+var capabilities = []string{CapRichEvents}
+
+// Hello defines the identity a Player presents to its peers.
+type Hello struct {
+	PlayerName      string
+	TeamName        string
+	BuildVersion    string
+	ProtocolVersion int
+	Capabilities    []string
+}
+
+// Has returns whether the Player advertised the capability.
+func (h *Hello) Has(capability string) bool {
+	for _, c := range h.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewHello returns the Hello of the Player with the given config.
+func NewHello(conf Config) *Hello {
+	return &Hello{
+		PlayerName:      conf.PlayerName,
+		TeamName:        conf.TeamName,
+		BuildVersion:    BuildVersion,
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    append([]string(nil), capabilities...),
+	}
+}
+
+// CheckPeer returns ErrIncompatiblePeer if the peer's Hello shows it plays
+// for another team or speaks a protocol this build doesn't support, either
+// older than MinProtocolVersion or newer than ProtocolVersion.
+func CheckPeer(conf Config, h *Hello) error {
+	if !strings.EqualFold(h.TeamName, conf.TeamName) {
+		return errors.Wrap(ErrIncompatiblePeer, "peer on another team",
+			j.MKV{"peer": h.PlayerName, "team": h.TeamName})
+	}
+
+	if h.ProtocolVersion < MinProtocolVersion {
+		return errors.Wrap(ErrIncompatiblePeer, "peer protocol too old",
+			j.MKV{"peer": h.PlayerName, "version": h.ProtocolVersion,
+				"min_version": MinProtocolVersion})
+	}
+
+	if h.ProtocolVersion > ProtocolVersion {
+		return errors.Wrap(ErrIncompatiblePeer, "peer protocol too new",
+			j.MKV{"peer": h.PlayerName, "version": h.ProtocolVersion,
+				"max_version": ProtocolVersion})
+	}
+
+	return nil
+}
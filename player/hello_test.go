@@ -0,0 +1,42 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/luno/jettison/errors"
+)
+
+func TestCheckPeer(t *testing.T) {
+	conf := Config{TeamName: "Team", PlayerName: "alice"}
+
+	tests := []struct {
+		name string
+		team string
+		ver  int
+		want error
+	}{
+		{name: "compatible", team: "team", ver: ProtocolVersion},
+		{name: "oldest", team: "team", ver: MinProtocolVersion},
+		{name: "another team", team: "other", ver: ProtocolVersion,
+			want: ErrIncompatiblePeer},
+		{name: "too old", team: "team", ver: MinProtocolVersion - 1,
+			want: ErrIncompatiblePeer},
+		{name: "too new", team: "team", ver: ProtocolVersion + 1,
+			want: ErrIncompatiblePeer},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckPeer(conf, &Hello{
+				PlayerName:      "bob",
+				TeamName:        test.team,
+				ProtocolVersion: test.ver,
+			})
+			if test.want == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if test.want != nil && !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+		})
+	}
+}
@@ -2,22 +2,17 @@ package db
 
 import (
 	"database/sql"
-	"flag"
 	"runtime"
 	"strings"
+	"testing"
 
 	"github.com/corverroos/unsure"
 	"github.com/luno/jettison/log"
 )
 
-var (
-	playerDB = flag.String("player_db", "", "Database name for player")
-)
-
-// Connect attempts to create a connection to the MySQL database provided
-// by the "player_db" flag.
-func Connect() (*sql.DB, error) {
-	dbURI := "mysql://root@unix(" + unsure.SockFile() + ")/" + *playerDB + "?"
+// Connect attempts to create a connection to the named MySQL database.
+func Connect(name string) (*sql.DB, error) {
+	dbURI := "mysql://root@unix(" + unsure.SockFile() + ")/" + name + "?"
 	ok, err := unsure.MaybeRecreateSchema(dbURI, getSchemaPath())
 	if err != nil {
 		return nil, err
@@ -33,6 +28,12 @@ func Connect() (*sql.DB, error) {
 	return dbc, nil
 }
 
+// ConnectForTesting returns a connection to the test database with the
+// Player's schema created as temporary tables.
+func ConnectForTesting(t testing.TB) *sql.DB {
+	return unsure.ConnectForTesting(t, getSchemaPath())
+}
+
 func getSchemaPath() string {
 	_, filename, _, _ := runtime.Caller(0)
 	return strings.Replace(filename, "connect.go", "schema.sql", 1)
@@ -1,7 +1,9 @@
 package cursors
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/luno/reflex"
 	"github.com/luno/reflex/rsql"
@@ -13,3 +15,31 @@ var cursors = rsql.NewCursorsTable("cursors")
 func Store(dbc *sql.DB) reflex.CursorStore {
 	return cursors.ToStore(dbc)
 }
+
+// Cursor defines a reflex consumer's position in its stream.
+type Cursor struct {
+	Name        string
+	LastEventID int64
+	UpdatedAt   time.Time
+}
+
+// List returns all the cursors, ordered by name.
+func List(ctx context.Context, dbc *sql.DB) ([]Cursor, error) {
+	rows, err := dbc.QueryContext(ctx, "select id, last_event_id, "+
+		"updated_at from cursors order by id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cl []Cursor
+	for rows.Next() {
+		var c Cursor
+		if err := rows.Scan(&c.Name, &c.LastEventID, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		cl = append(cl, c)
+	}
+
+	return cl, rows.Err()
+}
@@ -0,0 +1,55 @@
+// Package divergences records disagreements between the total the Player was
+// about to submit for a round and its peers' view of its parts, for
+// post-mortem.
+package divergences
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+)
+
+// Divergence defines a peer's disagreement with the Player's total for a
+// round.
+type Divergence struct {
+	ID         int64
+	RoundID    int64
+	ExternalID int64
+	Peer       string
+	// Total and parts the Player computed.
+	Total int64
+	Parts []int64
+	// Total and parts the peer collected from the Player.
+	PeerTotal int64
+	PeerParts []int64
+
+	CreatedAt time.Time
+}
+
+// Create records a divergence.
+func Create(ctx context.Context, dbc *sql.DB, d Divergence) error {
+	_, err := dbc.ExecContext(ctx, "insert into divergences set "+
+		"round_id=?, external_id=?, peer=?, total=?, parts=?, "+
+		"peer_total=?, peer_parts=?, created_at=now()", d.RoundID,
+		d.ExternalID, d.Peer, d.Total, formatParts(d.Parts), d.PeerTotal,
+		formatParts(d.PeerParts))
+	if err != nil {
+		return errors.Wrap(err, "failed to insert divergence")
+	}
+
+	return nil
+}
+
+// formatParts formats part values as a comma separated list.
+func formatParts(vl []int64) string {
+	var res []string
+	for _, v := range vl {
+		res = append(res, strconv.FormatInt(v, 10))
+	}
+
+	return strings.Join(res, ",")
+}
@@ -0,0 +1,81 @@
+// Package leases grants named leases to one holder at a time, so that
+// replicas sharing a database can agree on which of them is active. Expiry
+// is judged by the database's clock, so the replicas' clocks needn't agree.
+package leases
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+// Lease defines the current holder of a named lease.
+type Lease struct {
+	Name      string
+	Holder    string
+	ExpiresAt time.Time
+	UpdatedAt time.Time
+}
+
+// Acquire takes the named lease for the holder for the ttl if it is free or
+// expired, or renews it if the holder already has it. It returns whether the
+// holder has the lease.
+func Acquire(ctx context.Context, dbc *sql.DB, name, holder string,
+	ttl time.Duration) (bool, error) {
+	// Create the lease already expired, so that it is taken below even
+	// within the same millisecond.
+	_, err := dbc.ExecContext(ctx, "insert ignore into leases set name=?, "+
+		"holder='', expires_at=date_sub(now(3), interval 1 second), "+
+		"updated_at=now()", name)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to insert lease",
+			j.KV("name", name))
+	}
+
+	_, err = dbc.ExecContext(ctx, "update leases set holder=?, "+
+		"expires_at=date_add(now(3), interval ? microsecond), "+
+		"updated_at=now() where name=? and (holder=? or expires_at<now(3))",
+		holder, int64(ttl/time.Microsecond), name, holder)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to update lease",
+			j.KV("name", name))
+	}
+
+	l, err := Lookup(ctx, dbc, name)
+	if err != nil {
+		return false, err
+	}
+
+	return l.Holder == holder, nil
+}
+
+// Release expires the named lease if the holder has it, so that another
+// replica can take it without waiting for it to expire.
+func Release(ctx context.Context, dbc *sql.DB, name, holder string) error {
+	_, err := dbc.ExecContext(ctx, "update leases set "+
+		"expires_at=date_sub(now(3), interval 1 second), updated_at=now() "+
+		"where name=? and holder=?", name, holder)
+	if err != nil {
+		return errors.Wrap(err, "failed to release lease",
+			j.KV("name", name))
+	}
+
+	return nil
+}
+
+// Lookup returns the named lease.
+func Lookup(ctx context.Context, dbc *sql.DB, name string) (*Lease, error) {
+	var l Lease
+	err := dbc.QueryRowContext(ctx, "select name, holder, expires_at, "+
+		"updated_at from leases where name=?", name).Scan(&l.Name,
+		&l.Holder, &l.ExpiresAt, &l.UpdatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup lease",
+			j.KV("name", name))
+	}
+
+	return &l, nil
+}
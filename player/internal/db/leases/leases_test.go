@@ -0,0 +1,101 @@
+package leases
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/corverroos/unsure"
+
+	"unsure/player/internal/db"
+)
+
+const ttl = time.Minute
+
+func TestAcquire(t *testing.T) {
+	dbc := db.ConnectForTesting(t)
+	defer dbc.Close()
+
+	ctx := unsure.ContextWithFate(context.Background(), 0)
+	alice, bob := leaseName("alice"), leaseName("bob")
+
+	assertAcquire(t, dbc, alice, "r1", ttl, true)
+	first := lookup(t, dbc, alice)
+
+	// Another holder can't take the lease while it is held.
+	assertAcquire(t, dbc, alice, "r2", ttl, false)
+	if l := lookup(t, dbc, alice); l.Holder != "r1" {
+		t.Errorf("got holder %q, want r1", l.Holder)
+	}
+
+	// Other leases are independent.
+	assertAcquire(t, dbc, bob, "r2", ttl, true)
+
+	// The holder renews the lease, extending it.
+	time.Sleep(10 * time.Millisecond)
+	assertAcquire(t, dbc, alice, "r1", ttl, true)
+	if l := lookup(t, dbc, alice); !l.ExpiresAt.After(first.ExpiresAt) {
+		t.Errorf("renewal didn't extend lease: %v not after %v",
+			l.ExpiresAt, first.ExpiresAt)
+	}
+
+	// Only the holder releases the lease.
+	if err := Release(ctx, dbc, alice, "r2"); err != nil {
+		t.Fatal(err)
+	}
+	assertAcquire(t, dbc, alice, "r2", ttl, false)
+
+	if err := Release(ctx, dbc, alice, "r1"); err != nil {
+		t.Fatal(err)
+	}
+	assertAcquire(t, dbc, alice, "r2", ttl, true)
+}
+
+func TestAcquireExpired(t *testing.T) {
+	dbc := db.ConnectForTesting(t)
+	defer dbc.Close()
+
+	alice := leaseName("alice")
+
+	assertAcquire(t, dbc, alice, "r1", 50*time.Millisecond, true)
+	assertAcquire(t, dbc, alice, "r2", ttl, false)
+
+	time.Sleep(100 * time.Millisecond)
+
+	assertAcquire(t, dbc, alice, "r2", ttl, true)
+	assertAcquire(t, dbc, alice, "r1", ttl, false)
+}
+
+// leaseName returns a name unique to the test run, since the test database
+// may keep leases from earlier runs.
+func leaseName(name string) string {
+	return name + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+func assertAcquire(t *testing.T, dbc *sql.DB, name, holder string,
+	ttl time.Duration, want bool) {
+	t.Helper()
+
+	ctx := unsure.ContextWithFate(context.Background(), 0)
+	held, err := Acquire(ctx, dbc, name, holder, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if held != want {
+		t.Errorf("%s acquiring %s: got %v, want %v", holder, name, held, want)
+	}
+}
+
+func lookup(t *testing.T, dbc *sql.DB, name string) *Lease {
+	t.Helper()
+
+	ctx := unsure.ContextWithFate(context.Background(), 0)
+	l, err := Lookup(ctx, dbc, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return l
+}
@@ -0,0 +1,37 @@
+// Package matches records the Unsure Engine matches the Player has seen
+// start, so that its rounds can be attributed to a match.
+package matches
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+// Create records that the match started. Matches which have already been
+// recorded are ignored, so it is safe to call more than once per match.
+func Create(ctx context.Context, dbc *sql.DB, externalID int64) error {
+	_, err := dbc.ExecContext(ctx, "insert ignore into matches set "+
+		"external_id=?, created_at=now()", externalID)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert match",
+			j.KV("external_id", externalID))
+	}
+
+	return nil
+}
+
+// LookupLatest returns the engine id of the match which started last. It
+// returns sql.ErrNoRows if no match has started.
+func LookupLatest(ctx context.Context, dbc *sql.DB) (int64, error) {
+	var externalID int64
+	err := dbc.QueryRowContext(ctx, "select external_id from matches "+
+		"order by id desc limit 1").Scan(&externalID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to lookup latest match")
+	}
+
+	return externalID, nil
+}
@@ -9,7 +9,7 @@ import (
 )
 
 const cols = "id, round_id, player, coalesce(rank, 0), value, submitted," +
-	" created_at, updated_at"
+	" signature, submitted_signature, created_at, updated_at"
 
 // Create inserts a new part record into the parts table.
 func Create(ctx context.Context, dbc *sql.DB, roundID int64, player string,
@@ -47,8 +47,9 @@ func CreateBatch(ctx context.Context, dbc *sql.DB, pl []player.Part) error {
 
 	for _, p := range pl {
 		_, err := tx.ExecContext(ctx, "insert into parts set "+
-			"round_id=?, player=?, value=?, submitted=0,"+
-			"created_at=now(), updated_at=now()", p.RoundID, p.Player, p.Value)
+			"round_id=?, player=?, value=?, submitted=0, signature=?,"+
+			"created_at=now(), updated_at=now()", p.RoundID, p.Player, p.Value,
+			p.Signature)
 		if err != nil {
 			return errors.Wrap(err, "failed to insert part")
 		}
@@ -68,7 +69,7 @@ func CreateBatch(ctx context.Context, dbc *sql.DB, pl []player.Part) error {
 func SetRankTx(ctx context.Context, tx *sql.Tx, roundID int64, player string,
 	rank int64) error {
 	_, err := tx.ExecContext(ctx, "update parts set "+
-		"rank=?, updated_at=now() where id=? and player=?", rank, roundID,
+		"rank=?, updated_at=now() where round_id=? and player=?", rank, roundID,
 		player)
 	if err != nil {
 		return errors.Wrap(err, "failed to set rank")
@@ -91,6 +92,14 @@ func ListByRoundAndPlayer(ctx context.Context, dbc *sql.DB, roundID int64,
 		"player=?", roundID, player)
 }
 
+// ListByRoundAndPlayerTx queries parts associated with a given round and
+// player, within a transaction.
+func ListByRoundAndPlayerTx(ctx context.Context, tx *sql.Tx, roundID int64,
+	player string) ([]player.Part, error) {
+	return list(ctx, tx, "select "+cols+" from parts where round_id=? and "+
+		"player=?", roundID, player)
+}
+
 // ListByRound returns a list of parts associated with a given round.
 func ListByRound(ctx context.Context, dbc *sql.DB, roundID int64) (
 	[]player.Part, error) {
@@ -101,7 +110,7 @@ func ListByRound(ctx context.Context, dbc *sql.DB, roundID int64) (
 
 func LookupRankByPlayer(ctx context.Context, dbc *sql.DB, roundID int64,
 	player string) (int64, error) {
-	r, err := scan(dbc.QueryRowContext(ctx, "select "+cols+" where "+
+	r, err := scan(dbc.QueryRowContext(ctx, "select "+cols+" from parts where "+
 		"round_id=? and player=? and rank is not null", roundID, player))
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup part")
@@ -110,10 +119,13 @@ func LookupRankByPlayer(ctx context.Context, dbc *sql.DB, roundID int64,
 	return r.Rank, nil
 }
 
+// MarkAsSubmittedTx marks a player's parts for a round as submitted, along
+// with the player's signed acknowledgement, within a transaction.
 func MarkAsSubmittedTx(ctx context.Context, tx *sql.Tx, roundID int64,
-	player string) error {
+	player string, sig []byte) error {
 	_, err := tx.ExecContext(ctx, "update parts set submitted=true, "+
-		"updated_at=now() where round_id=? and player=?", roundID, player)
+		"submitted_signature=?, updated_at=now() where round_id=? and "+
+		"player=?", sig, roundID, player)
 	if err != nil {
 		return errors.Wrap(err, "failed to mark parts as submitted")
 	}
@@ -121,10 +133,13 @@ func MarkAsSubmittedTx(ctx context.Context, tx *sql.Tx, roundID int64,
 	return nil
 }
 
+// MarkAsSubmitted marks a player's parts for a round as submitted, along
+// with the player's signed acknowledgement.
 func MarkAsSubmitted(ctx context.Context, dbc *sql.DB, roundID int64,
-	player string) error {
+	player string, sig []byte) error {
 	_, err := dbc.ExecContext(ctx, "update parts set submitted=true, "+
-		"updated_at=now() where round_id=? and player=?", roundID, player)
+		"submitted_signature=?, updated_at=now() where round_id=? and "+
+		"player=?", sig, roundID, player)
 	if err != nil {
 		return errors.Wrap(err, "failed to mark parts as submitted")
 	}
@@ -132,7 +147,7 @@ func MarkAsSubmitted(ctx context.Context, dbc *sql.DB, roundID int64,
 	return nil
 }
 
-func list(ctx context.Context, dbc *sql.DB, query string, args ...interface{}) (
+func list(ctx context.Context, dbc dbc, query string, args ...interface{}) (
 	[]player.Part, error) {
 
 	rows, err := dbc.QueryContext(ctx, query, args...)
@@ -155,7 +170,8 @@ func list(ctx context.Context, dbc *sql.DB, query string, args ...interface{}) (
 func scan(row row) (*player.Part, error) {
 	var p player.Part
 	err := row.Scan(&p.ID, &p.RoundID, &p.Player, &p.Rank, &p.Value,
-		&p.Submitted, &p.CreatedAt, &p.UpdatedAt)
+		&p.Submitted, &p.Signature, &p.SubmittedSignature, &p.CreatedAt,
+		&p.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -163,6 +179,12 @@ func scan(row row) (*player.Part, error) {
 	return &p, nil
 }
 
+// dbc is a common interface for *sql.DB and *sql.Tx.
+type dbc interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (
+		*sql.Rows, error)
+}
+
 // row is a common interface for *sql.Rows and *sql.Row.
 type row interface {
 	Scan(dest ...interface{}) error
@@ -0,0 +1,86 @@
+package rounds
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/luno/jettison/errors"
+
+	"unsure/player"
+)
+
+type reasonKey struct{}
+
+// withReason returns a context carrying the reason for a shift, which is
+// recorded in the audit trail when the shift's event is inserted.
+func withReason(ctx context.Context,
+	reason player.TransitionReason) context.Context {
+	return context.WithValue(ctx, reasonKey{}, reason)
+}
+
+func reasonFromContext(ctx context.Context) player.TransitionReason {
+	reason, _ := ctx.Value(reasonKey{}).(player.TransitionReason)
+	return reason
+}
+
+// insertAuditTx records a round's transition into the given status in its
+// audit trail. It must be called before the transition's event is inserted
+// since the round's previous event defines the status it is transitioning
+// from.
+func insertAuditTx(ctx context.Context, tx *sql.Tx, id int64,
+	to player.RoundStatus) error {
+	var (
+		from     player.RoundStatus
+		duration int64
+	)
+	err := tx.QueryRowContext(ctx, "select `type`, "+
+		"timestampdiff(microsecond, updated_at, now()) from round_events "+
+		"where foreign_id=? order by id desc limit 1", id).
+		Scan(&from, &duration)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, "failed to lookup previous event")
+	}
+
+	reason := reasonFromContext(ctx)
+
+	_, err = tx.ExecContext(ctx, "insert into round_audit set "+
+		"round_id=?, from_status=?, to_status=?, triggered_by=?, error=?, "+
+		"duration_ms=?, created_at=now()", id, from, to, reason.Trigger,
+		reason.Error, duration/1000)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert audit")
+	}
+
+	return nil
+}
+
+// ListAudit returns the audit trail of a round in the order the transitions
+// occurred.
+func ListAudit(ctx context.Context, dbc *sql.DB, id int64) (
+	[]player.RoundAudit, error) {
+	rows, err := dbc.QueryContext(ctx, "select id, round_id, from_status, "+
+		"to_status, triggered_by, coalesce(error, ''), duration_ms, "+
+		"created_at from round_audit where round_id=? order by id", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var al []player.RoundAudit
+	for rows.Next() {
+		var (
+			a          player.RoundAudit
+			durationMS int64
+		)
+		err := rows.Scan(&a.ID, &a.RoundID, &a.From, &a.To, &a.Trigger,
+			&a.Error, &durationMS, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		a.Duration = time.Duration(durationMS) * time.Millisecond
+		al = append(al, a)
+	}
+
+	return al, rows.Err()
+}
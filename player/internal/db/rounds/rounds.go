@@ -3,25 +3,106 @@ package rounds
 import (
 	"context"
 	"database/sql"
+	"strconv"
 	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/submissions"
 
 	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
 	"github.com/luno/reflex"
 	"github.com/luno/reflex/rsql"
+	"github.com/luno/shift"
 
 	"unsure/player"
+	"unsure/player/playerpb/protocp"
 )
 
-var events = rsql.NewEventsTableInt("round_events",
-	rsql.WithEventTimeField("updated_at"))
+var events = metaEvents{rsql.NewEventsTable("round_events",
+	rsql.WithEventTimeField("updated_at"),
+	rsql.WithEventMetadataField("metadata"))}
+
+// metaEvents wraps the round events table and embeds a player.RoundMeta in
+// every event inserted by the rounds FSM.
+type metaEvents struct {
+	rsql.EventsTable
+}
+
+// Insert inserts a round event along with the round's metadata, read within
+// the same transaction as the shift. The transition is also recorded in the
+// round's audit trail with the reason carried by the context.
+func (e metaEvents) Insert(ctx context.Context, tx *sql.Tx, foreignID int64,
+	typ reflex.EventType) (rsql.NotifyFunc, error) {
+	err := insertAuditTx(ctx, tx, foreignID,
+		player.RoundStatus(typ.ReflexType()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to audit transition")
+	}
+
+	meta, err := lookupMetaTx(ctx, tx, foreignID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup round meta")
+	}
+
+	b, err := protocp.MarshalRoundMeta(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.EventsTable.InsertWithMetadata(ctx, tx,
+		strconv.FormatInt(foreignID, 10), typ, b)
+}
+
+// Clone returns a copy of the events table with the given options applied.
+func (e metaEvents) Clone(opts ...rsql.EventsOption) rsql.EventsTableInt {
+	return metaEvents{e.EventsTable.Clone(opts...)}
+}
+
+// lookupMetaTx builds the metadata for a round from its current state within
+// a transaction.
+func lookupMetaTx(ctx context.Context, tx *sql.Tx, id int64) (
+	*player.RoundMeta, error) {
+	r, err := scan(tx.QueryRowContext(ctx, "select "+cols+" from rounds "+
+		"where id=?", id))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup round")
+	}
+
+	meta := player.RoundMeta{
+		ExternalID: r.ExternalID,
+		Player:     r.Player,
+	}
+
+	// Rounds are only assigned a player once joined.
+	if r.Player == "" {
+		return &meta, nil
+	}
+
+	pl, err := parts.ListByRoundAndPlayerTx(ctx, tx, id, r.Player)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list parts")
+	}
+
+	meta.Submitted = len(pl) > 0
+	for _, p := range pl {
+		if p.Rank != 0 {
+			meta.Rank = p.Rank
+		}
+		meta.Parts = append(meta.Parts, p.Value)
+		meta.PartSignatures = append(meta.PartSignatures, p.Signature)
+		meta.Submitted = meta.Submitted && p.Submitted
+		meta.SubmittedSignature = p.SubmittedSignature
+	}
+
+	return &meta, nil
+}
 
 // EventStream returns the reflex.StreamFunc for round events.
 func EventStream(dbc *sql.DB) reflex.StreamFunc {
 	return events.ToStream(dbc)
 }
 
-const cols = "id, external_id, coalesce(player, ''), status, created_at," +
-	" updated_at"
+const cols = "id, external_id, match_id, coalesce(player, ''), status, " +
+	"excluded, created_at, updated_at"
 
 // Lookup queries a round by id.
 func Lookup(ctx context.Context, dbc *sql.DB, id int64) (*player.Round, error) {
@@ -36,64 +117,111 @@ func LookupByExternalID(ctx context.Context, dbc *sql.DB, externalID int64) (
 		"where external_id=?", externalID))
 }
 
-// Create inserts a new Round into the database with state
-// player.RoundStatusJoin.
-func Create(ctx context.Context, dbc *sql.DB, externalID int64) (int64, error) {
-	return roundsFSM.Insert(ctx, dbc, join{ExternalID: externalID})
+// List returns up to limit rounds with ids greater than afterID, ordered by
+// id.
+func List(ctx context.Context, dbc *sql.DB, afterID int64, limit int) (
+	[]player.Round, error) {
+	return list(ctx, dbc, "select "+cols+" from rounds "+
+		"where id>? order by id limit ?", afterID, limit)
 }
 
-// ShiftToJoined attempts to shift a Round into player.RoundStatusJoined.
-func ShiftToJoined(ctx context.Context, dbc *sql.DB, id int64,
-	p string) error {
-	r, err := Lookup(ctx, dbc, id)
-	if err != nil {
-		return errors.Wrap(err, "failed to lookup round")
-	}
+// ListRecent returns up to limit of the latest rounds, newest first.
+func ListRecent(ctx context.Context, dbc *sql.DB, limit int) (
+	[]player.Round, error) {
+	return list(ctx, dbc, "select "+cols+" from rounds "+
+		"order by id desc limit ?", limit)
+}
 
-	return roundsFSM.Update(ctx, dbc, r.Status, player.RoundStatusJoined,
-		joined{ID: id, Player: p})
+// ListActive returns all rounds which haven't reached a terminal status.
+func ListActive(ctx context.Context, dbc *sql.DB) ([]player.Round, error) {
+	return list(ctx, dbc, "select "+cols+" from rounds "+
+		"where status not in (?, ?) order by id",
+		player.RoundStatusSuccess, player.RoundStatusFailed)
 }
 
-// ShiftToCollect attempts to shift a Round into player.RoundStatusCollect.
-func ShiftToCollect(ctx context.Context, dbc *sql.DB, id int64) error {
-	r, err := Lookup(ctx, dbc, id)
+// ListTransitions returns the status transitions of a round in the order
+// they occurred.
+func ListTransitions(ctx context.Context, dbc *sql.DB, id int64) (
+	[]player.RoundTransition, error) {
+	rows, err := dbc.QueryContext(ctx, "select `type`, updated_at "+
+		"from round_events where foreign_id=? order by id", id)
 	if err != nil {
-		return errors.Wrap(err, "failed to lookup round")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tl []player.RoundTransition
+	for rows.Next() {
+		var t player.RoundTransition
+		if err := rows.Scan(&t.Status, &t.Timestamp); err != nil {
+			return nil, err
+		}
+		tl = append(tl, t)
 	}
 
-	return roundsFSM.Update(ctx, dbc, r.Status, player.RoundStatusCollect,
-		empty{ID: id})
+	return tl, rows.Err()
 }
 
-// ShiftToCollected attempts to shift a Round into player.RoundStatusCollected.
-func ShiftToCollected(ctx context.Context, dbc *sql.DB, id int64) error {
-	r, err := Lookup(ctx, dbc, id)
-	if err != nil {
-		return errors.Wrap(err, "failed to lookup round")
-	}
+// EventsHead returns the id of the latest round event, or zero if there are
+// none.
+func EventsHead(ctx context.Context, dbc *sql.DB) (int64, error) {
+	var head int64
+	err := dbc.QueryRowContext(ctx, "select coalesce(max(id), 0) "+
+		"from round_events").Scan(&head)
+	return head, err
+}
 
-	return roundsFSM.Update(ctx, dbc, r.Status, player.RoundStatusCollected,
-		empty{ID: id})
+// Create inserts a new Round into the database with state
+// player.RoundStatusJoin. Like the ShiftTo functions, it records the reason
+// for the transition in the round's audit trail.
+func Create(ctx context.Context, dbc *sql.DB, externalID, matchID int64,
+	reason player.TransitionReason) (int64, error) {
+	return roundsFSM.Insert(withReason(ctx, reason), dbc,
+		join{ExternalID: externalID, MatchID: matchID})
 }
 
-// ShiftToSubmit attempts to shift a Round into player.RoundStatusSubmit.
-func ShiftToSubmit(ctx context.Context, dbc *sql.DB, id int64) error {
-	r, err := Lookup(ctx, dbc, id)
-	if err != nil {
-		return errors.Wrap(err, "failed to lookup round")
-	}
+// The ShiftTo functions shift a round into a status from any of the given
+// statuses the caller expects it to be in, trying each in order. Since the
+// status is checked by the update itself, a round in the first expected
+// status is shifted with a single query. They return player.ErrRoundConflict
+// if the round isn't in any of the expected statuses, usually because a
+// concurrent consumer shifted it first.
+
+// ShiftToJoined attempts to shift a Round into player.RoundStatusJoined.
+func ShiftToJoined(ctx context.Context, dbc *sql.DB, id int64, p string,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	return shiftRound(withReason(ctx, reason), dbc, id,
+		player.RoundStatusJoined, joined{ID: id, Player: p}, from)
+}
+
+// ShiftToCollect attempts to shift a Round into player.RoundStatusCollect.
+func ShiftToCollect(ctx context.Context, dbc *sql.DB, id int64,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	return shiftRound(withReason(ctx, reason), dbc, id,
+		player.RoundStatusCollect, empty{ID: id}, from)
+}
+
+// ShiftToCollected attempts to shift a Round into player.RoundStatusCollected.
+func ShiftToCollected(ctx context.Context, dbc *sql.DB, id int64,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	return shiftRound(withReason(ctx, reason), dbc, id,
+		player.RoundStatusCollected, empty{ID: id}, from)
+}
 
-	return roundsFSM.Update(ctx, dbc, r.Status, player.RoundStatusSubmit,
-		empty{ID: id})
+// ShiftToSubmit attempts to shift a Round into player.RoundStatusSubmit.
+func ShiftToSubmit(ctx context.Context, dbc *sql.DB, id int64,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	return shiftRound(withReason(ctx, reason), dbc, id,
+		player.RoundStatusSubmit, empty{ID: id}, from)
 }
 
-// ShiftToSubmitted attempts to shift a Round into player.RoundStatusSubmitted.
+// ShiftToSubmitted attempts to shift a Round into player.RoundStatusSubmitted,
+// marking the player's parts as submitted with its signed acknowledgement and
+// completing its journaled submission.
 func ShiftToSubmitted(ctx context.Context, dbc *sql.DB, id int64,
-	p string) error {
-	r, err := Lookup(ctx, dbc, id)
-	if err != nil {
-		return errors.Wrap(err, "failed to lookup round")
-	}
+	p string, sig []byte, submissionID int64,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	ctx = withReason(ctx, reason)
 
 	tx, err := dbc.Begin()
 	if err != nil {
@@ -101,15 +229,20 @@ func ShiftToSubmitted(ctx context.Context, dbc *sql.DB, id int64,
 	}
 	defer tx.Rollback()
 
-	err = parts.MarkAsSubmittedTx(ctx, tx, id, p)
+	err = parts.MarkAsSubmittedTx(ctx, tx, id, p, sig)
 	if err != nil {
 		return errors.Wrap(err, "failed to mark parts as submitted")
 	}
 
-	notify, err := roundsFSM.UpdateTx(ctx, tx, r.Status,
-		player.RoundStatusSubmitted, empty{ID: id})
+	err = submissions.CompleteTx(ctx, tx, submissionID)
 	if err != nil {
-		return errors.Wrap(err, "failed to shift round to failed")
+		return errors.Wrap(err, "failed to complete submission")
+	}
+
+	notify, err := shiftRoundTx(ctx, tx, id, player.RoundStatusSubmitted,
+		empty{ID: id}, from)
+	if err != nil {
+		return err
 	}
 	defer notify()
 
@@ -117,31 +250,100 @@ func ShiftToSubmitted(ctx context.Context, dbc *sql.DB, id int64,
 }
 
 // ShiftToSuccess attempts to shift a Round into player.RoundStatusSuccess.
-func ShiftToSuccess(ctx context.Context, dbc *sql.DB, id int64) error {
-	r, err := Lookup(ctx, dbc, id)
+func ShiftToSuccess(ctx context.Context, dbc *sql.DB, id int64,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	return shiftRound(withReason(ctx, reason), dbc, id,
+		player.RoundStatusSuccess, empty{ID: id}, from)
+}
+
+// ShiftToFailed attempts to shift a Round into player.RoundStatusFailed.
+func ShiftToFailed(ctx context.Context, dbc *sql.DB, id int64,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	return shiftRound(withReason(ctx, reason), dbc, id,
+		player.RoundStatusFailed, failed{ID: id}, from)
+}
+
+// ShiftToExcluded attempts to shift a Round into player.RoundStatusFailed,
+// marking it as excluded by the Unsure Engine.
+func ShiftToExcluded(ctx context.Context, dbc *sql.DB, id int64,
+	reason player.TransitionReason, from ...player.RoundStatus) error {
+	return shiftRound(withReason(ctx, reason), dbc, id,
+		player.RoundStatusFailed, failed{ID: id, Excluded: true}, from)
+}
+
+func shiftRound(ctx context.Context, dbc *sql.DB, id int64,
+	to player.RoundStatus, upd shift.Updater,
+	from []player.RoundStatus) error {
+	tx, err := dbc.Begin()
 	if err != nil {
-		return errors.Wrap(err, "failed to lookup round")
+		return errors.Wrap(err, "failed to start db transaction")
+	}
+	defer tx.Rollback()
+
+	notify, err := shiftRoundTx(ctx, tx, id, to, upd, from)
+	if err != nil {
+		return err
+	}
+	defer notify()
+
+	return tx.Commit()
+}
+
+// shiftRoundTx shifts the round from the first of the expected statuses it
+// is in. An update which doesn't match the round's status changes nothing,
+// so the next status can be tried within the same transaction. Each status
+// tried costs an update, so a round in the last of several statuses costs
+// more queries than looking its status up first; callers should list the
+// statuses most likely first.
+func shiftRoundTx(ctx context.Context, tx *sql.Tx, id int64,
+	to player.RoundStatus, upd shift.Updater,
+	from []player.RoundStatus) (rsql.NotifyFunc, error) {
+	if len(from) == 0 {
+		return nil, errors.New("no expected status", j.KV("round", id),
+			j.KV("to", to))
 	}
 
-	return roundsFSM.Update(ctx, dbc, r.Status, player.RoundStatusSuccess,
-		empty{ID: id})
+	for _, st := range from {
+		notify, err := roundsFSM.UpdateTx(ctx, tx, st, to, upd)
+		if errors.Is(err, shift.ErrRowCount) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to shift round",
+				j.KV("round", id), j.KV("from", st), j.KV("to", to))
+		}
+
+		return notify, nil
+	}
+
+	return nil, errors.Wrap(player.ErrRoundConflict,
+		"round not in expected status", j.KV("round", id),
+		j.KV("to", to), j.KV("from", from))
 }
 
-// ShiftToFailed attempts to shift a Round into player.RoundStatusFailed.
-func ShiftToFailed(ctx context.Context, dbc *sql.DB, id int64) error {
-	r, err := Lookup(ctx, dbc, id)
+func list(ctx context.Context, dbc *sql.DB, query string,
+	args ...interface{}) ([]player.Round, error) {
+	rows, err := dbc.QueryContext(ctx, query, args...)
 	if err != nil {
-		return errors.Wrap(err, "failed to lookup round")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rl []player.Round
+	for rows.Next() {
+		r, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		rl = append(rl, *r)
 	}
 
-	return roundsFSM.Update(ctx, dbc, r.Status, player.RoundStatusFailed,
-		empty{ID: id})
+	return rl, rows.Err()
 }
 
 func scan(row row) (*player.Round, error) {
 	var r player.Round
-	err := row.Scan(&r.ID, &r.ExternalID, &r.Player, &r.Status,
-		&r.CreatedAt, &r.UpdatedAt)
+	err := row.Scan(&r.ID, &r.ExternalID, &r.MatchID, &r.Player, &r.Status,
+		&r.Excluded, &r.CreatedAt, &r.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
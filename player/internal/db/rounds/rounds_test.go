@@ -0,0 +1,100 @@
+package rounds
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/corverroos/unsure"
+
+	"unsure/player"
+	"unsure/player/internal/db"
+)
+
+// The benchmarks shift newly created rounds to failed, comparing a shift from
+// the status the caller expects with looking the status up first, as the
+// ShiftTo functions used to.
+
+func BenchmarkShiftExpected(b *testing.B) {
+	benchmarkShift(b, func(ctx context.Context, dbc *sql.DB, id int64,
+		reason player.TransitionReason) error {
+		return ShiftToFailed(ctx, dbc, id, reason,
+			player.RoundStatusJoin)
+	})
+}
+
+func BenchmarkShiftLookupFirst(b *testing.B) {
+	benchmarkShift(b, func(ctx context.Context, dbc *sql.DB, id int64,
+		reason player.TransitionReason) error {
+		r, err := Lookup(ctx, dbc, id)
+		if err != nil {
+			return err
+		}
+
+		return ShiftToFailed(ctx, dbc, id, reason, r.Status)
+	})
+}
+
+// BenchmarkShiftAnyOf shifts rounds expected in any of the active statuses,
+// as failing a round from the command line does, with each round in the last
+// of them. This is the worst case, costing an update per status tried.
+func BenchmarkShiftAnyOf(b *testing.B) {
+	from := []player.RoundStatus{
+		player.RoundStatusSubmitted, player.RoundStatusSubmit,
+		player.RoundStatusCollected, player.RoundStatusCollect,
+		player.RoundStatusJoined, player.RoundStatusJoin,
+	}
+
+	benchmarkShift(b, func(ctx context.Context, dbc *sql.DB, id int64,
+		reason player.TransitionReason) error {
+		return ShiftToFailed(ctx, dbc, id, reason, from...)
+	})
+}
+
+func benchmarkShift(b *testing.B, shiftFn func(context.Context, *sql.DB,
+	int64, player.TransitionReason) error) {
+	dbc := db.ConnectForTesting(b)
+	defer dbc.Close()
+
+	ctx := unsure.ContextWithFate(context.Background(), 0)
+	reason := player.LocalEventReason("benchmark")
+
+	var queries int64
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		id, err := Create(ctx, dbc, int64(i+1), 0, reason)
+		if err != nil {
+			b.Fatal(err)
+		}
+		before := countQueries(ctx, b, dbc)
+		b.StartTimer()
+
+		if err := shiftFn(ctx, dbc, id, reason); err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		// Don't count the query counting the queries.
+		queries += countQueries(ctx, b, dbc) - before - 1
+		b.StartTimer()
+	}
+
+	b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+}
+
+// countQueries returns the number of statements the connection has run,
+// including the transaction statements. The test DB has a single connection,
+// so the session's count covers every query.
+func countQueries(ctx context.Context, b *testing.B, dbc *sql.DB) int64 {
+	var name string
+	var n int64
+	err := dbc.QueryRowContext(ctx,
+		"show session status like 'Questions'").Scan(&name, &n)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return n
+}
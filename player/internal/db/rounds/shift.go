@@ -6,22 +6,40 @@ import (
 	"unsure/player"
 )
 
-//go:generate shiftgen -inserter=join -updaters=joined,empty -table=rounds
+//go:generate shiftgen -inserter=join -updaters=joined,empty,failed -table=rounds
 
 var roundsFSM = shift.NewFSM(events).
-	Insert(player.RoundStatusReady, ready{}, player.RoundStatusJoined,
-		player.RoundStatusExcluded).
-	Update(player.RoundStatusJoined, joined{}, player.RoundStatusCollected,
-		player.RoundStatusFailed).
-	Update(player.RoundStatusCollected, collected{}, player.RoundStatusSubmitted,
+	Insert(player.RoundStatusJoin, join{}, player.RoundStatusJoined,
 		player.RoundStatusFailed).
+	Update(player.RoundStatusJoined, joined{}, player.RoundStatusCollect,
+		player.RoundStatusSuccess, player.RoundStatusFailed).
+	Update(player.RoundStatusCollect, empty{}, player.RoundStatusCollected,
+		player.RoundStatusSuccess, player.RoundStatusFailed).
+	Update(player.RoundStatusCollected, empty{}, player.RoundStatusSubmit,
+		player.RoundStatusSuccess, player.RoundStatusFailed).
+	Update(player.RoundStatusSubmit, empty{}, player.RoundStatusSubmitted,
+		player.RoundStatusSuccess, player.RoundStatusFailed).
 	Update(player.RoundStatusSubmitted, empty{}, player.RoundStatusSuccess,
 		player.RoundStatusFailed).
-	Update(player.RoundStatusExcluded, empty{}).
 	Update(player.RoundStatusSuccess, empty{}).
-	Update(player.RoundStatusFailed, empty{}).
+	Update(player.RoundStatusFailed, failed{}).
 	Build()
 
-type ready struct {
+type join struct {
+	ExternalID int64
+	MatchID    int64
+}
+
+type joined struct {
+	ID     int64
+	Player string
+}
+
+type empty struct {
+	ID int64
+}
 
-}
\ No newline at end of file
+type failed struct {
+	ID       int64
+	Excluded bool
+}
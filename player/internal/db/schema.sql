@@ -1,8 +1,10 @@
 create table rounds (
     id bigint not null auto_increment,
     external_id bigint not null,
+    match_id bigint not null default 0,
     player varchar (255),
     `status` int not null,
+    excluded bool not null default false,
     created_at datetime not null,
     updated_at datetime not null,
     
@@ -14,6 +16,7 @@ create table round_events (
     foreign_id bigint not null,
     `type` int not null,
     updated_at datetime not null,
+    metadata blob,
 
     primary key(id)
 );
@@ -25,6 +28,8 @@ create table parts (
     value int not null,
     rank int,
     submitted bool not null,
+    signature blob,
+    submitted_signature blob,
     created_at datetime not null,
     updated_at datetime not null,
 
@@ -37,4 +42,106 @@ create table cursors (
     updated_at datetime not null,
 
     primary key(id)
-);
\ No newline at end of file
+);
+
+create table round_audit (
+    id bigint not null auto_increment,
+    round_id bigint not null,
+    from_status int not null,
+    to_status int not null,
+    triggered_by varchar(255) not null,
+    error text,
+    duration_ms bigint not null,
+    created_at datetime not null,
+
+    primary key(id)
+);
+
+create table stats_rounds (
+    round_id bigint not null,
+    `status` int not null,
+    created_at datetime not null,
+
+    primary key(round_id)
+);
+
+create table stats_counters (
+    name varchar(255) not null,
+    value bigint not null,
+    updated_at datetime not null,
+
+    primary key(name)
+);
+
+create table stats_phases (
+    `status` int not null,
+    bucket_ms bigint not null,
+    count bigint not null,
+    total_ms bigint not null,
+
+    primary key(`status`, bucket_ms)
+);
+
+create table stats_peers (
+    player varchar(255) not null,
+    late_submissions bigint not null,
+
+    primary key(player)
+);
+
+create table stats_matches (
+    match_id bigint not null,
+    succeeded bigint not null,
+    failed bigint not null,
+    excluded bigint not null,
+
+    primary key(match_id)
+);
+
+create table submissions (
+    id bigint not null auto_increment,
+    round_id bigint not null,
+    external_id bigint not null,
+    total bigint not null,
+    status int not null,
+    attempts int not null,
+    error text,
+    created_at datetime not null,
+    updated_at datetime not null,
+
+    primary key(id),
+    unique key(round_id)
+);
+
+create table divergences (
+    id bigint not null auto_increment,
+    round_id bigint not null,
+    external_id bigint not null,
+    peer varchar(255) not null,
+    total bigint not null,
+    parts text not null,
+    peer_total bigint not null,
+    peer_parts text not null,
+    created_at datetime not null,
+
+    primary key(id),
+    index by_round(round_id)
+);
+
+create table leases (
+    name varchar(255) not null,
+    holder varchar(255) not null,
+    expires_at datetime(3) not null,
+    updated_at datetime not null,
+
+    primary key(name)
+);
+
+create table matches (
+    id bigint not null auto_increment,
+    external_id bigint not null,
+    created_at datetime not null,
+
+    primary key(id),
+    unique key(external_id)
+);
@@ -0,0 +1,345 @@
+// Package stats stores the aggregate results of a Player's completed rounds.
+// Each round is folded into the aggregates once, so reading the stats
+// doesn't require scanning the rounds and parts tables.
+package stats
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+)
+
+const (
+	counterSucceeded      = "rounds_succeeded"
+	counterFailed         = "rounds_failed"
+	counterExcluded       = "rounds_excluded"
+	counterParts          = "parts"
+	counterPartsTotal     = "parts_total"
+	counterSubmittedTotal = "submitted_total"
+)
+
+// buckets are the upper bounds in milliseconds of the phase duration
+// histogram. Longer durations fall into a final unbounded bucket.
+var buckets = []int64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000,
+	25000, 60000, 120000, 300000}
+
+// unbounded is the upper bound of the final histogram bucket.
+const unbounded = math.MaxInt64
+
+// Round defines a completed round's contribution to the stats.
+type Round struct {
+	ID      int64
+	MatchID int64
+	Status  player.RoundStatus
+	// Excluded is true if the Unsure Engine excluded the Player.
+	Excluded bool
+	// Phases is the time the round spent in each status.
+	Phases map[player.RoundStatus]time.Duration
+
+	Parts          int64
+	PartsTotal     int64
+	SubmittedTotal int64
+
+	// Peers maps the peers whose parts were collected to whether their
+	// submission was acknowledged only after the round completed.
+	Peers map[string]bool
+}
+
+// Record adds a completed round to the stats. Rounds which have already been
+// recorded are ignored, so it is safe to call more than once per round.
+func Record(ctx context.Context, dbc *sql.DB, r Round) error {
+	tx, err := dbc.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to start db transaction")
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, "insert ignore into stats_rounds set "+
+		"round_id=?, `status`=?, created_at=now()", r.ID, r.Status)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert stats round")
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		// Already recorded.
+		return nil
+	}
+
+	var succeeded, failed, excluded int64
+	if r.Status == player.RoundStatusSuccess {
+		succeeded = 1
+	} else {
+		failed = 1
+	}
+	if r.Excluded {
+		excluded = 1
+	}
+
+	_, err = tx.ExecContext(ctx, "insert into stats_matches set "+
+		"match_id=?, succeeded=?, failed=?, excluded=? on duplicate key "+
+		"update succeeded=succeeded+values(succeeded), "+
+		"failed=failed+values(failed), excluded=excluded+values(excluded)",
+		r.MatchID, succeeded, failed, excluded)
+	if err != nil {
+		return errors.Wrap(err, "failed to update match",
+			j.KV("match", r.MatchID))
+	}
+
+	counters := map[string]int64{
+		counterSucceeded:      succeeded,
+		counterFailed:         failed,
+		counterExcluded:       excluded,
+		counterParts:          r.Parts,
+		counterPartsTotal:     r.PartsTotal,
+		counterSubmittedTotal: r.SubmittedTotal,
+	}
+
+	for name, delta := range counters {
+		_, err := tx.ExecContext(ctx, "insert into stats_counters set "+
+			"name=?, value=?, updated_at=now() on duplicate key update "+
+			"value=value+values(value), updated_at=now()", name, delta)
+		if err != nil {
+			return errors.Wrap(err, "failed to update counter",
+				j.KV("name", name))
+		}
+	}
+
+	for st, d := range r.Phases {
+		ms := int64(d / time.Millisecond)
+		_, err := tx.ExecContext(ctx, "insert into stats_phases set "+
+			"`status`=?, bucket_ms=?, count=1, total_ms=? "+
+			"on duplicate key update count=count+1, "+
+			"total_ms=total_ms+values(total_ms)", st, bucketFor(ms), ms)
+		if err != nil {
+			return errors.Wrap(err, "failed to update phase",
+				j.KV("status", st))
+		}
+	}
+
+	for peer, late := range r.Peers {
+		var n int64
+		if late {
+			n = 1
+		}
+
+		_, err := tx.ExecContext(ctx, "insert into stats_peers set "+
+			"player=?, late_submissions=? on duplicate key update "+
+			"late_submissions=late_submissions+values(late_submissions)",
+			peer, n)
+		if err != nil {
+			return errors.Wrap(err, "failed to update peer",
+				j.KV("peer", peer))
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Load returns the stats of all recorded rounds.
+func Load(ctx context.Context, dbc *sql.DB) (*player.Stats, error) {
+	var s player.Stats
+
+	err := loadCounters(ctx, dbc, &s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load counters")
+	}
+
+	s.Matches, err = loadMatches(ctx, dbc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load matches")
+	}
+
+	s.Phases, err = loadPhases(ctx, dbc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load phases")
+	}
+
+	s.Peers, err = loadPeers(ctx, dbc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load peers")
+	}
+
+	return &s, nil
+}
+
+func loadCounters(ctx context.Context, dbc *sql.DB, s *player.Stats) error {
+	rows, err := dbc.QueryContext(ctx, "select name, value "+
+		"from stats_counters")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	fields := map[string]*int64{
+		counterSucceeded:      &s.Succeeded,
+		counterFailed:         &s.Failed,
+		counterExcluded:       &s.Excluded,
+		counterParts:          &s.Parts,
+		counterPartsTotal:     &s.PartsTotal,
+		counterSubmittedTotal: &s.SubmittedTotal,
+	}
+
+	for rows.Next() {
+		var (
+			name  string
+			value int64
+		)
+		if err := rows.Scan(&name, &value); err != nil {
+			return err
+		}
+
+		if f, ok := fields[name]; ok {
+			*f = value
+		}
+	}
+
+	return rows.Err()
+}
+
+func loadMatches(ctx context.Context, dbc *sql.DB) ([]player.MatchStats,
+	error) {
+	rows, err := dbc.QueryContext(ctx, "select match_id, succeeded, failed, "+
+		"excluded from stats_matches order by match_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []player.MatchStats
+	for rows.Next() {
+		var ms player.MatchStats
+		err := rows.Scan(&ms.MatchID, &ms.Succeeded, &ms.Failed,
+			&ms.Excluded)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ms)
+	}
+
+	return res, rows.Err()
+}
+
+type bucket struct {
+	upperMS int64
+	count   int64
+	totalMS int64
+}
+
+func loadPhases(ctx context.Context, dbc *sql.DB) ([]player.PhaseStats,
+	error) {
+	rows, err := dbc.QueryContext(ctx, "select `status`, bucket_ms, count, "+
+		"total_ms from stats_phases order by `status`, bucket_ms")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		statuses []player.RoundStatus
+		hists    = make(map[player.RoundStatus][]bucket)
+	)
+	for rows.Next() {
+		var (
+			st player.RoundStatus
+			b  bucket
+		)
+		if err := rows.Scan(&st, &b.upperMS, &b.count, &b.totalMS); err != nil {
+			return nil, err
+		}
+
+		if _, ok := hists[st]; !ok {
+			statuses = append(statuses, st)
+		}
+		hists[st] = append(hists[st], b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var res []player.PhaseStats
+	for _, st := range statuses {
+		res = append(res, phaseStats(st, hists[st]))
+	}
+
+	return res, nil
+}
+
+// phaseStats returns the stats of a status from its histogram, ordered by
+// bucket.
+func phaseStats(st player.RoundStatus, hist []bucket) player.PhaseStats {
+	res := player.PhaseStats{Status: st}
+
+	var totalMS int64
+	for _, b := range hist {
+		res.Count += b.count
+		totalMS += b.totalMS
+	}
+	if res.Count == 0 {
+		return res
+	}
+	res.Mean = time.Duration(totalMS/res.Count) * time.Millisecond
+
+	// Find the bucket containing the 95th percentile.
+	target := int64(math.Ceil(float64(res.Count) * 0.95))
+	var cum int64
+	for _, b := range hist {
+		cum += b.count
+		if cum < target {
+			continue
+		}
+
+		upper := b.upperMS
+		if upper == unbounded {
+			// The final bucket has no bound, so use its mean instead.
+			upper = b.totalMS / b.count
+		}
+		res.P95 = time.Duration(upper) * time.Millisecond
+		break
+	}
+
+	return res
+}
+
+func loadPeers(ctx context.Context, dbc *sql.DB) ([]player.PeerStats,
+	error) {
+	rows, err := dbc.QueryContext(ctx, "select player, late_submissions "+
+		"from stats_peers order by player")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []player.PeerStats
+	for rows.Next() {
+		var ps player.PeerStats
+		if err := rows.Scan(&ps.Player, &ps.LateSubmissions); err != nil {
+			return nil, err
+		}
+		res = append(res, ps)
+	}
+
+	return res, rows.Err()
+}
+
+// bucketFor returns the upper bound of the histogram bucket containing the
+// duration.
+func bucketFor(ms int64) int64 {
+	i := sort.Search(len(buckets), func(i int) bool {
+		return buckets[i] >= ms
+	})
+	if i == len(buckets) {
+		return unbounded
+	}
+
+	return buckets[i]
+}
@@ -0,0 +1,155 @@
+// Package submissions journals the Player's round submissions to the Unsure
+// Engine. An intent is written before the engine is called and completed in
+// the same transaction as the round's shift to submitted, so a crash at any
+// point leaves a pending intent which can be resubmitted as is.
+package submissions
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/luno/jettison/errors"
+)
+
+// Status defines the state of a journaled submission.
+type Status int
+
+const (
+	// StatusPending submissions may or may not have reached the engine.
+	StatusPending Status = 1
+
+	// StatusSubmitted submissions were accepted by the engine and the round
+	// shifted to submitted.
+	StatusSubmitted Status = 2
+
+	// StatusAbandoned submissions were never completed because the round
+	// ended first.
+	StatusAbandoned Status = 3
+)
+
+// Submission defines a journaled submission of a round's total.
+type Submission struct {
+	ID         int64
+	RoundID    int64
+	ExternalID int64
+	// Total submitted, fixed when the intent is written.
+	Total  int64
+	Status Status
+	// Attempts is the number of failed engine calls.
+	Attempts int64
+	// Error of the last failed engine call, if any.
+	Error string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const cols = "id, round_id, external_id, total, status, attempts, " +
+	"coalesce(error, ''), created_at, updated_at"
+
+// Create writes a pending intent to submit the round's total. Each round has
+// at most one submission.
+func Create(ctx context.Context, dbc *sql.DB, roundID, externalID,
+	total int64) (*Submission, error) {
+	_, err := dbc.ExecContext(ctx, "insert into submissions set "+
+		"round_id=?, external_id=?, total=?, status=?, attempts=0, "+
+		"created_at=now(), updated_at=now()", roundID, externalID, total,
+		StatusPending)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to insert submission")
+	}
+
+	return LookupByRound(ctx, dbc, roundID)
+}
+
+// LookupByRound returns the round's submission.
+func LookupByRound(ctx context.Context, dbc *sql.DB, roundID int64) (
+	*Submission, error) {
+	return scan(dbc.QueryRowContext(ctx, "select "+cols+" from submissions "+
+		"where round_id=?", roundID))
+}
+
+// ListPending returns the submissions which haven't been completed, ordered
+// by id.
+func ListPending(ctx context.Context, dbc *sql.DB) ([]Submission, error) {
+	rows, err := dbc.QueryContext(ctx, "select "+cols+" from submissions "+
+		"where status=? order by id", StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sl []Submission
+	for rows.Next() {
+		s, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		sl = append(sl, *s)
+	}
+
+	return sl, rows.Err()
+}
+
+// RecordAttempt records a failed engine call, leaving the submission pending.
+func RecordAttempt(ctx context.Context, dbc *sql.DB, id int64,
+	cause error) error {
+	_, err := dbc.ExecContext(ctx, "update submissions set "+
+		"attempts=attempts+1, error=?, updated_at=now() where id=?",
+		cause.Error(), id)
+	if err != nil {
+		return errors.Wrap(err, "failed to record attempt")
+	}
+
+	return nil
+}
+
+// CompleteTx marks a pending submission as submitted within a transaction.
+func CompleteTx(ctx context.Context, tx *sql.Tx, id int64) error {
+	return setStatus(ctx, tx, id, StatusSubmitted)
+}
+
+// Complete marks a pending submission as submitted.
+func Complete(ctx context.Context, dbc *sql.DB, id int64) error {
+	return setStatus(ctx, dbc, id, StatusSubmitted)
+}
+
+// Abandon marks a pending submission as abandoned.
+func Abandon(ctx context.Context, dbc *sql.DB, id int64) error {
+	return setStatus(ctx, dbc, id, StatusAbandoned)
+}
+
+func setStatus(ctx context.Context, dbc execer, id int64,
+	status Status) error {
+	_, err := dbc.ExecContext(ctx, "update submissions set status=?, "+
+		"updated_at=now() where id=? and status=?", status, id,
+		StatusPending)
+	if err != nil {
+		return errors.Wrap(err, "failed to update submission status")
+	}
+
+	return nil
+}
+
+func scan(row row) (*Submission, error) {
+	var s Submission
+	err := row.Scan(&s.ID, &s.RoundID, &s.ExternalID, &s.Total, &s.Status,
+		&s.Attempts, &s.Error, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// execer is a common interface for *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (
+		sql.Result, error)
+}
+
+// row is a common interface for *sql.Rows and *sql.Row.
+type row interface {
+	Scan(dest ...interface{}) error
+}
@@ -0,0 +1,94 @@
+package grpctls
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type callerKey struct{}
+
+// CallerFromContext returns the authenticated name of the Player that made
+// the gRPC call, if any.
+func CallerFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(callerKey{}).(string)
+	return name, ok
+}
+
+// authenticator verifies that gRPC callers present a certificate identifying
+// them as one of the configured peers.
+type authenticator struct {
+	allowed map[string]bool
+}
+
+// newAuthenticator returns an authenticator allowing the provided peer names.
+// Callers are only allowed if their name is provided, so no caller is allowed
+// if none are.
+func newAuthenticator(names []string) *authenticator {
+	allowed := make(map[string]bool)
+	for _, n := range names {
+		allowed[strings.ToLower(n)] = true
+	}
+
+	return &authenticator{allowed: allowed}
+}
+
+func (a *authenticator) unary(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+	interface{}, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate returns a context containing the caller's name if the
+// caller's verified certificate belongs to an allowed peer.
+func (a *authenticator) authenticate(ctx context.Context) (context.Context,
+	error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "no peer info")
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 ||
+		len(info.State.VerifiedChains[0]) == 0 {
+		return nil, status.Error(codes.Unauthenticated,
+			"no verified client certificate")
+	}
+
+	name := info.State.VerifiedChains[0][0].Subject.CommonName
+	if !a.allowed[strings.ToLower(name)] {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"caller %q is not a configured peer", name)
+	}
+
+	return context.WithValue(ctx, callerKey{}, name), nil
+}
+
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *authedStream) Context() context.Context {
+	return ss.ctx
+}
@@ -0,0 +1,69 @@
+package grpctls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		caller  string
+		code    codes.Code
+	}{
+		{name: "allowed", allowed: []string{"Alice", "Bob"}, caller: "bob",
+			code: codes.OK},
+		{name: "not a peer", allowed: []string{"Alice"}, caller: "Mallory",
+			code: codes.PermissionDenied},
+		{name: "no peers", caller: "Alice", code: codes.PermissionDenied},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := newAuthenticator(test.allowed)
+
+			ctx, err := a.authenticate(contextWithCaller(test.caller))
+			if status.Code(err) != test.code {
+				t.Fatalf("got %v, want %v", err, test.code)
+			} else if err != nil {
+				return
+			}
+
+			name, ok := CallerFromContext(ctx)
+			if !ok || name != test.caller {
+				t.Errorf("got caller %q, want %q", name, test.caller)
+			}
+		})
+	}
+}
+
+func TestAuthenticateUnverified(t *testing.T) {
+	a := newAuthenticator([]string{"Alice"})
+
+	ctx := peer.NewContext(context.Background(),
+		&peer.Peer{AuthInfo: credentials.TLSInfo{}})
+
+	_, err := a.authenticate(ctx)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("got %v, want %v", err, codes.Unauthenticated)
+	}
+}
+
+func contextWithCaller(name string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: name}}
+
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}},
+	})
+}
@@ -0,0 +1,270 @@
+// Package grpctls provides gRPC servers and clients for Players that are
+// mutually authenticated using certificates signed by the team's CA.
+package grpctls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"path"
+	"strings"
+
+	"github.com/corverroos/unsure"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/interceptors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"unsure/player"
+)
+
+// healthPrefix is the method prefix of the standard gRPC health service.
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// unfated defines the Player RPCs, in every version of the API, which peers
+// use to tell whether the Player is up, such as when electing a coordinator.
+var unfated = map[string]bool{
+	"Ping":    true,
+	"GetName": true,
+}
+
+// isUnfated returns whether the method shouldn't fail by chance. Health
+// checks and liveness RPCs report on the Player, so failing them would make
+// healthy Players appear down.
+func isUnfated(method string) bool {
+	return strings.HasPrefix(method, healthPrefix) || unfated[path.Base(method)]
+}
+
+// Server defines a gRPC server listening on a TCP address.
+type Server struct {
+	listener   net.Listener
+	grpcServer *grpc.Server
+}
+
+// NewServer returns a gRPC server listening on the provided address. Unless
+// running in insecure mode, callers must present a certificate signed by the
+// team CA whose common name is one of the provided peer names, which are
+// required.
+func NewServer(address string, conf player.TLSConfig,
+	peerNames []string) (*Server, error) {
+	if address == "" {
+		return nil, errors.New("no address provided")
+	}
+
+	if !conf.Insecure && len(peerNames) == 0 {
+		return nil, errors.New("peer names required unless grpc_insecure " +
+			"is set")
+	}
+
+	unary := []grpc.UnaryServerInterceptor{
+		interceptors.UnaryServerInterceptor, unaryFateInterceptor}
+	stream := []grpc.StreamServerInterceptor{
+		interceptors.StreamServerInterceptor, streamFateInterceptor}
+
+	var opts []grpc.ServerOption
+	if conf.Insecure {
+		log.Info(nil, "grpctls: Running without TLS or peer authentication")
+	} else {
+		tlsConf, err := serverConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+
+		a := newAuthenticator(peerNames)
+		unary = append([]grpc.UnaryServerInterceptor{a.unary}, unary...)
+		stream = append([]grpc.StreamServerInterceptor{a.stream}, stream...)
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConf)))
+	}
+
+	opts = append(opts,
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unary...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(stream...)))
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		listener:   listener,
+		grpcServer: grpc.NewServer(opts...),
+	}, nil
+}
+
+// Listener returns the server's network listener.
+func (srv *Server) Listener() net.Listener {
+	return srv.listener
+}
+
+// GRPCServer returns the underlying gRPC server for service registration.
+func (srv *Server) GRPCServer() *grpc.Server {
+	return srv.grpcServer
+}
+
+// Stop gracefully stops the server.
+func (srv *Server) Stop() {
+	srv.grpcServer.GracefulStop()
+}
+
+// ServeForever serves gRPC requests until the server is stopped.
+func (srv *Server) ServeForever() error {
+	log.Info(nil, "grpctls: ServeForever listening",
+		j.KV("addr", srv.listener.Addr()))
+	return srv.grpcServer.Serve(srv.listener)
+}
+
+// NewClient returns a gRPC client connection to the provided address, or to
+// the replicas it lists (see player.SplitReplicas). Unless running in
+// insecure mode, the connection presents the player's certificate and
+// verifies the server's certificate against the team CA. Interceptors in the
+// provided options are chained after the default ones.
+func NewClient(address string, conf player.TLSConfig,
+	extra ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(
+			interceptors.UnaryClientInterceptor, unaryTemptInterceptor),
+		grpc.WithChainStreamInterceptor(
+			interceptors.StreamClientInterceptor),
+	}
+
+	target, replicaOpts, replicas := replicasTarget(address)
+	opts = append(opts, replicaOpts...)
+
+	if conf.Insecure {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		tlsConf, err := clientConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+
+		creds := credentials.NewTLS(tlsConf)
+		if replicas {
+			creds = replicaCreds{creds}
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	}
+
+	return grpc.Dial(target, append(opts, extra...)...)
+}
+
+func serverConfig(conf player.TLSConfig) (*tls.Config, error) {
+	cert, pool, err := loadKeyPair(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func clientConfig(conf player.TLSConfig) (*tls.Config, error) {
+	cert, pool, err := loadKeyPair(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// loadKeyPair loads the player's certificate and the team CA pool.
+func loadKeyPair(conf player.TLSConfig) (tls.Certificate, *x509.CertPool,
+	error) {
+	if conf.CA == "" || conf.Cert == "" || conf.Key == "" {
+		return tls.Certificate{}, nil, errors.New("tls_ca, tls_cert and " +
+			"tls_key are required unless grpc_insecure is set")
+	}
+
+	cert, err := tls.LoadX509KeyPair(conf.Cert, conf.Key)
+	if err != nil {
+		return tls.Certificate{}, nil, errors.Wrap(err,
+			"failed to load player key pair")
+	}
+
+	ca, err := ioutil.ReadFile(conf.CA)
+	if err != nil {
+		return tls.Certificate{}, nil, errors.Wrap(err,
+			"failed to read team CA")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, errors.New("invalid team CA",
+			j.KV("path", conf.CA))
+	}
+
+	return cert, pool, nil
+}
+
+// unaryFateInterceptor injects the default fate into server contexts, as
+// done by unsure.NewServer.
+func unaryFateInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+	interface{}, error) {
+	ctx = unsure.ContextWithFate(ctx, unsure.DefaultFateP())
+
+	if isUnfated(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	if err := tempt(ctx); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// streamFateInterceptor injects the default fate into server stream
+// contexts, as done by unsure.NewServer.
+func streamFateInterceptor(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &fatedStream{ServerStream: ss})
+}
+
+type fatedStream struct {
+	grpc.ServerStream
+}
+
+func (ss *fatedStream) Context() context.Context {
+	return unsure.ContextWithFate(ss.ServerStream.Context(),
+		unsure.DefaultFateP())
+}
+
+// unaryTemptInterceptor tempts the context's fate before each call, as done
+// by unsure.NewClient.
+func unaryTemptInterceptor(ctx context.Context, method string,
+	req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption) error {
+	if isUnfated(method) {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	if err := tempt(ctx); err != nil {
+		return err
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func tempt(ctx context.Context) error {
+	f, err := unsure.FateFromContext(ctx)
+	if err != nil {
+		log.Error(ctx, err)
+		return err
+	}
+
+	return f.Tempt()
+}
@@ -0,0 +1,23 @@
+package grpctls
+
+import "testing"
+
+func TestIsUnfated(t *testing.T) {
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{method: "/grpc.health.v1.Health/Check", want: true},
+		{method: "/playerpb.Player/Ping", want: true},
+		{method: "/playerpb.v2.Player/Ping", want: true},
+		{method: "/playerpb.v2.Player/GetName", want: true},
+		{method: "/playerpb.v2.Player/GetParts"},
+		{method: "/playerpb.Player/Leave"},
+	}
+
+	for _, test := range tests {
+		if got := isUnfated(test.method); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.method, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package grpctls
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/resolver"
+
+	"unsure/player"
+)
+
+// replicasScheme is the scheme of dial targets listing the addresses of a
+// Player's replicas.
+const replicasScheme = "replicas"
+
+func init() {
+	resolver.Register(replicasBuilder{})
+}
+
+// replicasTarget returns the target and options for dialing the address. If
+// it lists the addresses of a Player's replicas, calls are balanced across
+// them, so that a call refused as unavailable by a replica standing by, or
+// failing because a replica is down, is retried against another.
+func replicasTarget(address string) (string, []grpc.DialOption, bool) {
+	if len(player.SplitReplicas(address)) < 2 {
+		return address, nil, false
+	}
+
+	return replicasScheme + ":///" + address, []grpc.DialOption{
+		grpc.WithBalancerName(roundrobin.Name),
+		grpc.WithContextDialer(dialReplica),
+	}, true
+}
+
+// replicasBuilder resolves replicas targets to the addresses they list.
+type replicasBuilder struct{}
+
+func (replicasBuilder) Build(target resolver.Target, cc resolver.ClientConn,
+	_ resolver.BuildOption) (resolver.Resolver, error) {
+	var addrs []resolver.Address
+	for _, a := range player.SplitReplicas(target.Endpoint) {
+		addrs = append(addrs, resolver.Address{Addr: a})
+	}
+
+	cc.UpdateState(resolver.State{Addresses: addrs})
+
+	return replicasResolver{}, nil
+}
+
+func (replicasBuilder) Scheme() string {
+	return replicasScheme
+}
+
+// replicasResolver has nothing to watch since the addresses are static.
+type replicasResolver struct{}
+
+func (replicasResolver) ResolveNow(resolver.ResolveNowOption) {}
+
+func (replicasResolver) Close() {}
+
+// replicaConn is a connection to one of a Player's replicas, remembering the
+// address dialed.
+type replicaConn struct {
+	net.Conn
+	address string
+}
+
+func dialReplica(ctx context.Context, address string) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return replicaConn{Conn: conn, address: address}, nil
+}
+
+// replicaCreds verifies each replica's certificate against the host dialed,
+// rather than against the target listing every replica, which gRPC uses as
+// the authority of all its connections.
+type replicaCreds struct {
+	credentials.TransportCredentials
+}
+
+func (c replicaCreds) ClientHandshake(ctx context.Context, authority string,
+	conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if rc, ok := conn.(replicaConn); ok {
+		authority = rc.address
+	}
+
+	return c.TransportCredentials.ClientHandshake(ctx, authority, conn)
+}
+
+func (c replicaCreds) Clone() credentials.TransportCredentials {
+	return replicaCreds{c.TransportCredentials.Clone()}
+}
@@ -0,0 +1,17 @@
+package signing
+
+import (
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+var (
+	// ErrInvalidSignature indicates that a peer's signature does not match
+	// the signed data, either due to tampering or a spoofed peer.
+	ErrInvalidSignature = errors.New("invalid signature",
+		j.C("ERR_6f0e3c1b2a9d4e57"))
+
+	// ErrUnknownPeer indicates that no public key is configured for a peer.
+	ErrUnknownPeer = errors.New("no key configured for peer",
+		j.C("ERR_a83d51c70b6e2f94"))
+)
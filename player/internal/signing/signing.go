@@ -0,0 +1,210 @@
+// Package signing signs a Player's parts and submission acknowledgements and
+// verifies those received from peers, so that tampered or spoofed parts are
+// detected before they are stored.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+// Keyring holds the Player's signing key and the public keys of its peers.
+// A zero Keyring doesn't sign, and rejects every signature.
+type Keyring struct {
+	key           *ecdsa.PrivateKey
+	peers         map[string]*ecdsa.PublicKey
+	allowUnsigned bool
+}
+
+// Load returns a Keyring holding the private key at keyPath, if any, and
+// the public keys or certificates of the peers at the provided paths. Peers
+// without a key are rejected, unless allowUnsigned is set, in which case
+// they are accepted unverified.
+func Load(keyPath string, peerKeys map[string]string,
+	allowUnsigned bool) (*Keyring, error) {
+	k := Keyring{allowUnsigned: allowUnsigned}
+	if keyPath != "" {
+		key, err := loadPrivateKey(keyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load signing key")
+		}
+		k.key = key
+	}
+
+	k.peers = make(map[string]*ecdsa.PublicKey)
+	for name, path := range peerKeys {
+		pub, err := loadPublicKey(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load peer key",
+				j.KV("peer", name))
+		}
+		k.peers[strings.ToLower(name)] = pub
+	}
+
+	return &k, nil
+}
+
+// SignPart returns the Player's signature of a part it received for the
+// round with the given external ID.
+func (k *Keyring) SignPart(externalID int64, player string, rank,
+	value int64) ([]byte, error) {
+	return k.sign(partMessage(externalID, player, rank, value))
+}
+
+// VerifyPart verifies a peer's signature of one of its parts.
+func (k *Keyring) VerifyPart(externalID int64, player string, rank,
+	value int64, sig []byte) error {
+	return k.verify(player, partMessage(externalID, player, rank, value),
+		sig)
+}
+
+// SignSubmitted returns the Player's signature acknowledging that it has
+// submitted its parts for the round with the given external ID.
+func (k *Keyring) SignSubmitted(externalID int64, player string) ([]byte,
+	error) {
+	return k.sign(submittedMessage(externalID, player))
+}
+
+// VerifySubmitted verifies a peer's submission acknowledgement.
+func (k *Keyring) VerifySubmitted(externalID int64, player string,
+	sig []byte) error {
+	return k.verify(player, submittedMessage(externalID, player), sig)
+}
+
+func (k *Keyring) sign(msg string) ([]byte, error) {
+	if k == nil || k.key == nil {
+		return nil, nil
+	}
+
+	digest := sha256.Sum256([]byte(msg))
+	sig, err := k.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign")
+	}
+
+	return sig, nil
+}
+
+// verify returns ErrInvalidSignature if the signature was not made by the
+// named peer, or ErrUnknownPeer if there is no key for the peer and unsigned
+// parts aren't allowed.
+func (k *Keyring) verify(player string, msg string, sig []byte) error {
+	if k == nil {
+		return errors.Wrap(ErrUnknownPeer, "no keyring",
+			j.KV("player", player))
+	}
+
+	pub, ok := k.peers[strings.ToLower(player)]
+	if !ok && k.allowUnsigned {
+		return nil
+	} else if !ok {
+		return errors.Wrap(ErrUnknownPeer, "verify signature",
+			j.KV("player", player))
+	}
+
+	var esig struct {
+		R, S *big.Int
+	}
+	_, err := asn1.Unmarshal(sig, &esig)
+	if err != nil || esig.R == nil || esig.S == nil {
+		return errors.Wrap(ErrInvalidSignature, "malformed signature",
+			j.KV("player", player))
+	}
+
+	digest := sha256.Sum256([]byte(msg))
+	if !ecdsa.Verify(pub, digest[:], esig.R, esig.S) {
+		return errors.Wrap(ErrInvalidSignature, "signature mismatch",
+			j.KV("player", player))
+	}
+
+	return nil
+}
+
+func partMessage(externalID int64, player string, rank, value int64) string {
+	return fmt.Sprintf("part:%d:%s:%d:%d", externalID,
+		strings.ToLower(player), rank, value)
+}
+
+func submittedMessage(externalID int64, player string) string {
+	return fmt.Sprintf("submitted:%d:%s", externalID, strings.ToLower(player))
+}
+
+func loadPrivateKey(path string) (*ecdsa.PrivateKey, error) {
+	b, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParseECPrivateKey(b.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an EC key")
+	}
+
+	return ecKey, nil
+}
+
+// loadPublicKey loads a public key from either a PEM encoded public key or
+// certificate.
+func loadPublicKey(path string) (*ecdsa.PublicKey, error) {
+	b, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pub interface{}
+	if b.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(b.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse certificate")
+		}
+		pub = cert.PublicKey
+	} else {
+		pub, err = x509.ParsePKIXPublicKey(b.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse public key")
+		}
+	}
+
+	ecPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an EC key")
+	}
+
+	return ecPub, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read key file",
+			j.KV("path", path))
+	}
+
+	b, _ := pem.Decode(raw)
+	if b == nil {
+		return nil, errors.New("no PEM data found", j.KV("path", path))
+	}
+
+	return b, nil
+}
@@ -0,0 +1,123 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luno/jettison/errors"
+)
+
+func TestVerifyPart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	aliceKey, alicePub := writeKey(t, dir, "alice")
+	_, malloryPub := writeKey(t, dir, "mallory")
+
+	alice, err := Load(aliceKey, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := alice.SignPart(42, "alice", 1, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		peerKeys      map[string]string
+		allowUnsigned bool
+		value         int64
+		sig           []byte
+		want          error
+	}{
+		{name: "valid", peerKeys: map[string]string{"Alice": alicePub},
+			value: 7, sig: sig},
+		{name: "tampered", peerKeys: map[string]string{"alice": alicePub},
+			value: 8, sig: sig, want: ErrInvalidSignature},
+		{name: "spoofed", peerKeys: map[string]string{"alice": malloryPub},
+			value: 7, sig: sig, want: ErrInvalidSignature},
+		{name: "unsigned", peerKeys: map[string]string{"alice": alicePub},
+			value: 7, want: ErrInvalidSignature},
+		{name: "no keys", value: 7, sig: sig, want: ErrUnknownPeer},
+		{name: "other peer's key",
+			peerKeys: map[string]string{"bob": alicePub}, value: 7, sig: sig,
+			want: ErrUnknownPeer},
+		{name: "no keys allow unsigned", allowUnsigned: true, value: 7},
+		{name: "keys still verified when allowing unsigned",
+			peerKeys:      map[string]string{"alice": alicePub},
+			allowUnsigned: true, value: 8, sig: sig,
+			want: ErrInvalidSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			k, err := Load("", test.peerKeys, test.allowUnsigned)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = k.VerifyPart(42, "alice", 1, test.value, test.sig)
+			if test.want == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if test.want != nil && !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestVerifyNilKeyring(t *testing.T) {
+	var k *Keyring
+	err := k.VerifySubmitted(42, "alice", nil)
+	if !errors.Is(err, ErrUnknownPeer) {
+		t.Errorf("got %v, want %v", err, ErrUnknownPeer)
+	}
+}
+
+// writeKey generates a key pair, writing the private and public keys to the
+// directory, and returns their paths.
+func writeKey(t *testing.T, dir, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPath := filepath.Join(dir, name+"-key.pem")
+	pubPath := filepath.Join(dir, name+"-pub.pem")
+
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(
+		&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(pubPath, pem.EncodeToMemory(
+		&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keyPath, pubPath
+}
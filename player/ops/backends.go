@@ -3,6 +3,7 @@ package ops
 import (
 	"database/sql"
 	"unsure/player"
+	"unsure/player/internal/signing"
 
 	"github.com/corverroos/unsure/engine"
 )
@@ -13,4 +14,7 @@ type Backends interface {
 	PlayerDB() *sql.DB
 	EngineClient() engine.Client
 	Peers() []player.Client
+	Keyring() *signing.Keyring
+	Departures() *Departures
+	Standby() *Standby
 }
@@ -0,0 +1,69 @@
+package ops
+
+import (
+	"context"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/consistency"
+)
+
+// consistencyCheckRounds is the number of recent rounds compared with peers
+// by each background consistency check.
+const consistencyCheckRounds = 20
+
+// checkConsistencyForever periodically compares the parts of recent rounds
+// with the peers' copies, logging any differences, until the consumers are
+// stopped.
+func checkConsistencyForever(l *Loops, b Backends, conf player.Config) {
+	ctx := l.fatedContext()
+
+	for {
+		select {
+		case <-l.stopped:
+			return
+		case <-time.After(conf.ConsistencyCheckPeriod):
+		}
+
+		err := checkConsistency(ctx, l, b, conf)
+		if err != nil && !l.isStopped() &&
+			!errors.Is(err, context.Canceled) {
+			l.logError(ctx, errors.Wrap(err, "consistency check error"))
+		}
+	}
+}
+
+func checkConsistency(ctx context.Context, l *Loops, b Backends,
+	conf player.Config) error {
+	peers, errs := consistency.ResolvePeers(ctx, b.Peers())
+
+	report, err := consistency.Check(ctx, b.PlayerDB(), conf.PlayerName,
+		peers, consistencyCheckRounds)
+	if err != nil {
+		return err
+	}
+	report.Errors = append(errs, report.Errors...)
+
+	for _, d := range report.Diffs {
+		log.Info(ctx, "Parts copy differs",
+			j.MKV{"external_id": d.ExternalID, "owner": d.Owner,
+				"viewer": d.Viewer, "field": string(d.Field),
+				"want": d.Want, "got": d.Got})
+	}
+
+	for _, e := range report.Errors {
+		log.Info(ctx, "Consistency check incomplete", j.KV("error", e))
+	}
+
+	if len(report.Diffs) > 0 {
+		l.logError(ctx, errors.New("parts copies differ from peers",
+			j.KV("rounds", report.Rounds),
+			j.KV("differences", len(report.Diffs))))
+	}
+
+	return nil
+}
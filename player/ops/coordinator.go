@@ -0,0 +1,140 @@
+package ops
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corverroos/unsure"
+	"github.com/corverroos/unsure/engine"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+)
+
+const (
+	// electionPeriod is how often the team's coordinator is re-elected, and
+	// so bounds how long the team goes without one after it fails.
+	electionPeriod = time.Second
+
+	// Bounds of the backoff between failed attempts to start a match.
+	startMatchMinBackoff = 100 * time.Millisecond
+	startMatchMaxBackoff = 10 * time.Second
+)
+
+// electCoordinatorForever periodically elects the team's coordinator, which
+// makes the team-wide decisions such as starting matches, until the
+// consumers are stopped.
+func electCoordinatorForever(l *Loops, b Backends, conf player.Config) {
+	for {
+		name := electCoordinator(l.fatedContext(), b, conf)
+
+		l.mu.Lock()
+		prev := l.coordinator
+		l.coordinator = name
+		l.mu.Unlock()
+
+		if !strings.EqualFold(prev, name) {
+			log.Info(unsure.FatedContext(), "Coordinator elected",
+				j.MKV{"coordinator": name, "previous": prev})
+		}
+
+		select {
+		case <-l.stopped:
+			return
+		case <-time.After(electionPeriod):
+		}
+	}
+}
+
+// electCoordinator returns the name of the coordinator, which is the Player
+// with the lowest name among this one and its peers that respond to pings
+// and haven't left. Every Player sees the same peers while they are healthy,
+// so they agree on the coordinator without exchanging votes, and fail over
+// to the next lowest name as soon as the coordinator stops responding.
+func electCoordinator(ctx context.Context, b Backends,
+	conf player.Config) string {
+	// Peers' gRPC clients require a fate, which shouldn't fail the pings.
+	ctx = unsure.ContextWithFate(ctx, 0)
+
+	peers := b.Peers()
+	names := make([]string, len(peers))
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(i int, p player.Client) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, peerPingTimeout)
+			defer cancel()
+
+			name, err := p.GetName(ctx)
+			if err == nil {
+				err = p.Ping(ctx)
+			}
+			if err != nil || b.Departures().Has(name) {
+				return
+			}
+
+			names[i] = name
+		}(i, p)
+	}
+	wg.Wait()
+
+	res := conf.PlayerName
+	for _, name := range names {
+		if name != "" && strings.ToLower(name) < strings.ToLower(res) {
+			res = name
+		}
+	}
+
+	return res
+}
+
+// isCoordinator returns whether the Player was last elected the team's
+// coordinator. It returns false until the first election completes.
+func (l *Loops) isCoordinator() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.coordinator != "" &&
+		strings.EqualFold(l.coordinator, l.conf.PlayerName)
+}
+
+// startMatchesForever starts the team's match once the Player is elected
+// coordinator, backing off between failed attempts, until the engine reports
+// the match is active. Players which aren't the coordinator wait in case the
+// coordinator fails before the match starts, until the engine's match started
+// event shows it has.
+func startMatchesForever(l *Loops, b Backends, conf player.Config) {
+	backoff := startMatchMinBackoff
+	for !l.isStopped() && !l.isMatchStarted() {
+		if !l.isCoordinator() {
+			l.sleep(electionPeriod)
+			continue
+		}
+
+		err := b.EngineClient().StartMatch(unsure.FatedContext(),
+			conf.TeamName, len(b.Peers())+1)
+		if errors.Is(err, engine.ErrActiveMatch) {
+			l.setMatchStarted(time.Now())
+			break
+		} else if err != nil {
+			l.logError(unsure.FatedContext(),
+				errors.Wrap(err, "failed to start match"))
+
+			l.sleep(backoff)
+			backoff *= 2
+			if backoff > startMatchMaxBackoff {
+				backoff = startMatchMaxBackoff
+			}
+			continue
+		}
+
+		backoff = startMatchMinBackoff
+	}
+}
@@ -0,0 +1,70 @@
+package ops
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+)
+
+// Departures records the peers that have announced they are leaving, so that
+// rounds aren't held up waiting for their submissions. A peer is forgotten
+// once it greets the Player again. The zero value is ready to use.
+type Departures struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+// Add records that the named peer is leaving.
+func (d *Departures) Add(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.names == nil {
+		d.names = make(map[string]bool)
+	}
+	d.names[strings.ToLower(name)] = true
+}
+
+// Remove forgets that the named peer was leaving.
+func (d *Departures) Remove(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.names, strings.ToLower(name))
+}
+
+// Has returns whether the named peer is leaving.
+func (d *Departures) Has(name string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.names[strings.ToLower(name)]
+}
+
+// PeerLeaving records that the named peer is shutting down. It returns
+// player.ErrStandby if the Player is a replica standing by, since the
+// replica leading wouldn't learn of the departure.
+func PeerLeaving(ctx context.Context, b Backends, name string) error {
+	if err := checkLeading(b); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Peer leaving", j.KV("peer", name))
+	b.Departures().Add(name)
+
+	return nil
+}
+
+// PeerJoined forgets that the named peer was leaving, since it has greeted
+// the Player again.
+func PeerJoined(ctx context.Context, b Backends, name string) {
+	if !b.Departures().Has(name) {
+		return
+	}
+
+	log.Info(ctx, "Peer rejoined", j.KV("peer", name))
+	b.Departures().Remove(name)
+}
@@ -10,12 +10,62 @@ import (
 	"github.com/luno/jettison/log"
 
 	"unsure/player"
+	"unsure/player/internal/db/matches"
 	"unsure/player/internal/db/rounds"
 )
 
-func notifyToJoin(ctx context.Context, b Backends, f fate.Fate,
+// errEngineRoundFailed is recorded as the reason for rounds the Unsure Engine
+// reports as failed.
+var errEngineRoundFailed = errors.New("engine reported round failed",
+	j.C("ERR_6f03b2d9e4a1c857"))
+
+// joinedStatuses are the statuses of rounds which the Player has joined and
+// which haven't ended, most advanced first since rounds usually end once
+// submitted.
+var joinedStatuses = []player.RoundStatus{
+	player.RoundStatusSubmitted,
+	player.RoundStatusSubmit,
+	player.RoundStatusCollected,
+	player.RoundStatusCollect,
+	player.RoundStatusJoined,
+}
+
+// statusFirst returns the expected statuses with the round's last known
+// status moved first if it is one of them, since each status tried before
+// the round's costs an update.
+func statusFirst(st player.RoundStatus,
+	from []player.RoundStatus) []player.RoundStatus {
+	res := make([]player.RoundStatus, 0, len(from))
+	for _, s := range from {
+		if s == st {
+			res = append([]player.RoundStatus{s}, res...)
+		} else {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
+
+func notifyMatchStarted(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, externalID int64) error {
+	if conf.Debug {
+		log.Info(ctx, "Match started notification from Engine",
+			j.KV("external_id", externalID))
+	}
+
+	err := matches.Create(ctx, b.PlayerDB(), externalID)
+	if err != nil {
+		return err
+	}
+
+	return f.Tempt()
+}
+
+func notifyToJoin(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	externalID int64) error {
-	if *debug {
+	if conf.Debug {
 		log.Info(ctx, "Round join request from Engine",
 			j.KV("external_id", externalID))
 	}
@@ -30,8 +80,14 @@ func notifyToJoin(ctx context.Context, b Backends, f fate.Fate,
 		return errors.Wrap(err, "failed to lookup round")
 	}
 
+	// Attribute the round to the match which started last.
+	matchID, err := matches.LookupLatest(ctx, b.PlayerDB())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
 	// Insert a new round to join.
-	_, err = rounds.Create(ctx, b.PlayerDB(), externalID)
+	_, err = rounds.Create(ctx, b.PlayerDB(), externalID, matchID, reason)
 	if err != nil {
 		return errors.Wrap(err, "failed to insert new round",
 			j.KV("external_id", externalID))
@@ -40,9 +96,10 @@ func notifyToJoin(ctx context.Context, b Backends, f fate.Fate,
 	return f.Tempt()
 }
 
-func notifyToCollect(ctx context.Context, b Backends, f fate.Fate,
+func notifyToCollect(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	externalID int64) error {
-	if *debug {
+	if conf.Debug {
 		log.Info(ctx, "Round collect request from Engine",
 			j.KV("external_id", externalID))
 	}
@@ -60,7 +117,8 @@ func notifyToCollect(ctx context.Context, b Backends, f fate.Fate,
 	}
 
 	// Shift the round to RoundStatusCollect.
-	err = rounds.ShiftToCollect(ctx, b.PlayerDB(), r.ID)
+	err = rounds.ShiftToCollect(ctx, b.PlayerDB(), r.ID, reason,
+		player.RoundStatusJoined)
 	if err != nil {
 		return errors.Wrap(err, "failed to shift to collect",
 			j.KV("round", r.ID))
@@ -69,9 +127,10 @@ func notifyToCollect(ctx context.Context, b Backends, f fate.Fate,
 	return f.Tempt()
 }
 
-func notifyToSubmit(ctx context.Context, b Backends, f fate.Fate,
+func notifyToSubmit(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	externalID int64) error {
-	if *debug {
+	if conf.Debug {
 		log.Info(ctx, "Round submit request from Engine",
 			j.KV("round", externalID))
 	}
@@ -89,7 +148,7 @@ func notifyToSubmit(ctx context.Context, b Backends, f fate.Fate,
 	}
 
 	// Shift the round to RoundStatusSubmit.
-	err = maybeReadyToSubmit(ctx, b, f, r.ID)
+	err = maybeReadyToSubmit(ctx, b, conf, f, reason, r.ID)
 	if err != nil {
 		return errors.Wrap(err, "failed to check if player should submit")
 	}
@@ -97,9 +156,10 @@ func notifyToSubmit(ctx context.Context, b Backends, f fate.Fate,
 	return fate.Tempt()
 }
 
-func notifyRoundSuccess(ctx context.Context, b Backends, f fate.Fate,
+func notifyRoundSuccess(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	externalID int64) error {
-	if *debug {
+	if conf.Debug {
 		log.Info(ctx, "Round completed notification from Engine",
 			j.KV("external_id", externalID))
 	}
@@ -121,7 +181,8 @@ func notifyRoundSuccess(ctx context.Context, b Backends, f fate.Fate,
 	}
 
 	// Shift the round to success.
-	err = rounds.ShiftToSuccess(ctx, b.PlayerDB(), r.ID)
+	err = rounds.ShiftToSuccess(ctx, b.PlayerDB(), r.ID, reason,
+		statusFirst(r.Status, joinedStatuses)...)
 	if err != nil {
 		return errors.Wrap(err, "failed to shift round to success",
 			j.KV("round", r.ID), j.KV("status", r.Status.String()))
@@ -130,9 +191,10 @@ func notifyRoundSuccess(ctx context.Context, b Backends, f fate.Fate,
 	return f.Tempt()
 }
 
-func notifyRoundFailed(ctx context.Context, b Backends, f fate.Fate,
+func notifyRoundFailed(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	externalID int64) error {
-	if *debug {
+	if conf.Debug {
 		log.Info(ctx, "Round completed notification from Engine",
 			j.KV("external_id", externalID))
 	}
@@ -154,7 +216,9 @@ func notifyRoundFailed(ctx context.Context, b Backends, f fate.Fate,
 	}
 
 	// Shift the round to failed.
-	err = rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID)
+	err = rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID,
+		reason.WithError(errEngineRoundFailed),
+		statusFirst(r.Status, joinedStatuses)...)
 	if err != nil {
 		return errors.Wrap(err, "failed to shift round to failed",
 			j.KV("round", r.ID), j.KV("status", r.Status.String()))
@@ -0,0 +1,260 @@
+package ops
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corverroos/unsure"
+	"github.com/luno/fate"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/internal/db/rounds"
+)
+
+// errStopping is returned to reflex for events received while stopping, so
+// that their cursors aren't updated and they are consumed again on restart.
+var errStopping = errors.New("player stopping", j.C("ERR_2c8f61d0b7e4a395"))
+
+// leaveTimeout bounds flushing cursors, notifying peers and reporting once
+// the consumers have stopped.
+const leaveTimeout = 5 * time.Second
+
+// Loops manages the lifecycle of the Player's consumers so that in-flight
+// round work can finish before the Player exits.
+type Loops struct {
+	b       Backends
+	conf    player.Config
+	cursors reflex.CursorStore
+
+	mu       sync.Mutex
+	stopping bool
+	inflight sync.WaitGroup
+	stopped  chan struct{}
+
+	// Progress reported on the status page, guarded by mu.
+	matchStartedAt time.Time
+	coordinator    string
+	consumers      map[string]ConsumerProgress
+	errs           []RecentError
+}
+
+func newLoops(b Backends, conf player.Config, cs reflex.CursorStore) *Loops {
+	return &Loops{
+		b:         b,
+		conf:      conf,
+		cursors:   cs,
+		stopped:   make(chan struct{}),
+		consumers: make(map[string]ConsumerProgress),
+	}
+}
+
+// Stop stops the consumers from handling new events and waits for in-flight
+// handlers to finish until the context expires. It then flushes cursors,
+// notifies peers that the Player is leaving and logs any rounds left in a
+// non-terminal status. It returns an error if the handlers didn't finish in
+// time.
+func (l *Loops) Stop(ctx context.Context) error {
+	return l.stop(ctx, true)
+}
+
+// handOver stops the loops like Stop, but without notifying peers, since
+// another replica of the Player takes over its rounds.
+func (l *Loops) handOver(ctx context.Context) error {
+	return l.stop(ctx, false)
+}
+
+func (l *Loops) stop(ctx context.Context, leave bool) error {
+	l.mu.Lock()
+	l.stopping = true
+	l.mu.Unlock()
+
+	log.Info(ctx, "Draining in-flight round work")
+
+	drained := make(chan struct{})
+	go func() {
+		l.inflight.Wait()
+		close(drained)
+	}()
+
+	var drainErr error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		drainErr = errors.Wrap(ctx.Err(), "in-flight handlers didn't finish")
+	}
+
+	close(l.stopped)
+
+	// The drain deadline may have passed, so leave with a fresh context
+	// that isn't subject to fate.
+	ctx, cancel := context.WithTimeout(
+		unsure.ContextWithFate(context.Background(), 0), leaveTimeout)
+	defer cancel()
+
+	if err := l.cursors.Flush(ctx); err != nil {
+		log.Error(ctx, errors.Wrap(err, "failed to flush cursors"))
+	}
+
+	if leave {
+		for _, p := range l.b.Peers() {
+			if err := p.Leave(ctx, l.conf.PlayerName); err != nil {
+				log.Error(ctx, errors.Wrap(err, "failed to notify peer"))
+			}
+		}
+	}
+
+	l.reportActive(ctx)
+
+	return drainErr
+}
+
+// reportActive logs the rounds left in a non-terminal status.
+func (l *Loops) reportActive(ctx context.Context) {
+	rl, err := rounds.ListActive(ctx, l.b.PlayerDB())
+	if err != nil {
+		log.Error(ctx, errors.Wrap(err, "failed to list active rounds"))
+		return
+	}
+
+	for _, r := range rl {
+		log.Info(ctx, "Round left active",
+			j.MKV{"round": r.ID, "external_id": r.ExternalID,
+				"status": r.Status.String()})
+	}
+
+	log.Info(ctx, "Player stopped", j.KV("active_rounds", len(rl)))
+}
+
+// isStopped returns whether the consumers have been stopped.
+func (l *Loops) isStopped() bool {
+	select {
+	case <-l.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+type consumerFunc func(context.Context, fate.Fate, *reflex.Event) error
+
+// track wraps a consumer function so that Stop waits for it to finish, and
+// so that it rejects events once the Player is stopping. It also records the
+// consumer's progress for the status page.
+func (l *Loops) track(name reflex.ConsumerName, fn consumerFunc) consumerFunc {
+	l.mu.Lock()
+	l.consumers[name.String()] = ConsumerProgress{}
+	l.mu.Unlock()
+
+	return func(ctx context.Context, f fate.Fate, e *reflex.Event) error {
+		l.mu.Lock()
+		if l.stopping {
+			l.mu.Unlock()
+			return errStopping
+		}
+		l.inflight.Add(1)
+		l.mu.Unlock()
+
+		defer l.inflight.Done()
+
+		err := fn(ctx, f, e)
+		if err != nil {
+			return err
+		}
+
+		l.mu.Lock()
+		l.consumers[name.String()] = ConsumerProgress{
+			EventID:   e.ID,
+			EventTime: e.Timestamp,
+			HandledAt: time.Now(),
+		}
+		l.mu.Unlock()
+
+		return nil
+	}
+}
+
+// logError logs the error and records it for the status page.
+func (l *Loops) logError(ctx context.Context, err error) {
+	log.Error(ctx, err)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.errs = append(l.errs, RecentError{
+		Message:   err.Error(),
+		Timestamp: time.Now(),
+	})
+	if len(l.errs) > maxRecentErrors {
+		l.errs = l.errs[len(l.errs)-maxRecentErrors:]
+	}
+}
+
+// setMatchStarted records that the team's match started at the given time.
+func (l *Loops) setMatchStarted(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.matchStartedAt = t
+}
+
+// isMatchStarted returns whether the team's match is known to be active.
+func (l *Loops) isMatchStarted() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return !l.matchStartedAt.IsZero()
+}
+
+// sleep waits for the duration, returning early if the consumers are
+// stopped.
+func (l *Loops) sleep(d time.Duration) {
+	select {
+	case <-l.stopped:
+	case <-time.After(d):
+	}
+}
+
+// fatedContext returns a fated context which is cancelled once the
+// consumers are stopped.
+func (l *Loops) fatedContext() context.Context {
+	ctx, cancel := context.WithCancel(unsure.FatedContext())
+	go func() {
+		select {
+		case <-l.stopped:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	return ctx
+}
+
+// consumeForever is equivalent to unsure.ConsumeForever but returns once the
+// consumers are stopped.
+func (l *Loops) consumeForever(consume reflex.ConsumeFunc,
+	consumer reflex.Consumer) {
+	for !l.isStopped() {
+		ctx := l.fatedContext()
+
+		err := consume(ctx, consumer)
+		if l.isStopped() {
+			return
+		}
+
+		if errors.IsAny(err, context.Canceled, context.DeadlineExceeded,
+			reflex.ErrStopped, fate.ErrTempt, errStopping) {
+			// Just retry on expected errors.
+			time.Sleep(time.Millisecond * 100)
+			continue
+		}
+
+		l.logError(ctx, errors.Wrap(err, "consume forever error",
+			j.KV("consumer", consumer.Name())))
+		time.Sleep(time.Second)
+	}
+}
@@ -2,6 +2,7 @@ package ops
 
 import (
 	"context"
+	"database/sql"
 	"github.com/corverroos/unsure/engine"
 	"github.com/luno/fate"
 	"github.com/luno/jettison/errors"
@@ -10,10 +11,17 @@ import (
 	"unsure/player"
 	"unsure/player/internal/db/parts"
 	"unsure/player/internal/db/rounds"
+	"unsure/player/internal/db/submissions"
 	"strings"
 )
 
-func joinRounds(ctx context.Context, b Backends, f fate.Fate,
+// errNotJoined is recorded as the reason for rounds the Unsure Engine didn't
+// join the Player to.
+var errNotJoined = errors.New("engine did not join player",
+	j.C("ERR_b8e51c0a7d3f2946"))
+
+func joinRounds(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	roundID int64) error {
 	// Lookup the round.
 	r, err := rounds.Lookup(ctx, b.PlayerDB(), roundID)
@@ -28,22 +36,22 @@ func joinRounds(ctx context.Context, b Backends, f fate.Fate,
 	}
 
 	// Attempt to join the round.
-	joined, err := b.EngineClient().JoinRound(ctx, *teamName, *playerName,
-		r.ExternalID)
+	joined, err := b.EngineClient().JoinRound(ctx, conf.TeamName,
+		conf.PlayerName, r.ExternalID)
 	if errors.Is(err, engine.ErrAlreadyJoined) {
-		if *debug {
+		if conf.Debug {
 			log.Info(ctx, "Already joined this round",
 				j.KV("external_id", r.ExternalID))
 		}
 		return f.Tempt()
 	} else if errors.Is(err, engine.ErrOutOfSyncJoin) {
-		if *debug {
+		if conf.Debug {
 			log.Info(ctx, "Too late to join round",
 				j.KV("external_id", r.ExternalID))
 		}
 		return f.Tempt()
 	} else if errors.Is(err, engine.ErrAlreadyExcluded) {
-		if *debug {
+		if conf.Debug {
 			log.Info(ctx, "You've been excluded",
 				j.KV("external_id", r.ExternalID))
 		}
@@ -56,15 +64,19 @@ func joinRounds(ctx context.Context, b Backends, f fate.Fate,
 	// Shift into a non-active state if the Unsure Engine failed to join
 	// the player to the round.
 	if !joined {
-		err = rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID)
+		err = rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID,
+			reason.WithError(errNotJoined), player.RoundStatusJoin)
 		if err != nil {
 			return errors.Wrap(err, "failed to shift to failed",
 				j.KV("round", r.ID))
 		}
+
+		return f.Tempt()
 	}
 
 	// Shift the round into RoundStatusJoined.
-	err = rounds.ShiftToJoined(ctx, b.PlayerDB(), r.ID, *playerName)
+	err = rounds.ShiftToJoined(ctx, b.PlayerDB(), r.ID, conf.PlayerName,
+		reason, player.RoundStatusJoin)
 	if err != nil {
 		return errors.Wrap(err, "failed to shift to joined",
 			j.KV("round", r.ID))
@@ -73,7 +85,8 @@ func joinRounds(ctx context.Context, b Backends, f fate.Fate,
 	return f.Tempt()
 }
 
-func collectEngineParts(ctx context.Context, b Backends, f fate.Fate,
+func collectEngineParts(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	roundID int64) error {
 	// Lookup the round.
 	r, err := rounds.Lookup(ctx, b.PlayerDB(), roundID)
@@ -88,27 +101,41 @@ func collectEngineParts(ctx context.Context, b Backends, f fate.Fate,
 	}
 
 	// Collect the parts from the Unsure Engine.
-	data, err := b.EngineClient().CollectRound(ctx, *teamName,
-		*playerName, r.ExternalID)
+	data, err := b.EngineClient().CollectRound(ctx, conf.TeamName,
+		conf.PlayerName, r.ExternalID)
 	if errors.Is(err, engine.ErrExcludedCollect) {
-		err = rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID)
+		err = rounds.ShiftToExcluded(ctx, b.PlayerDB(), r.ID,
+			reason.WithError(err), player.RoundStatusCollect)
 		if err != nil {
 			return errors.Wrap(err, "failed to shift round to failed")
 		}
+
+		// The engine returns no data for excluded players, so there are
+		// no parts to store and the round can't be collected.
+		return f.Tempt()
 	} else if err != nil {
 		return errors.Wrap(err, "failed to collect parts",
 			j.KV("external_id", r.ExternalID))
 	}
 
-	// Convert collected data into parts, adding rank where possible.
+	// Convert collected data into parts, adding rank and signing our own
+	// parts where possible.
 	var pl []player.Part
 	for _, p := range data.Players {
-		if strings.EqualFold(*playerName, p.Name) {
+		if strings.EqualFold(conf.PlayerName, p.Name) {
+			sig, err := b.Keyring().SignPart(r.ExternalID, p.Name,
+				int64(data.Rank), int64(p.Part))
+			if err != nil {
+				return errors.Wrap(err, "failed to sign part",
+					j.KV("external_id", r.ExternalID))
+			}
+
 			pl = append(pl, player.Part{
-				RoundID: r.ID,
-				Player:  p.Name,
-				Rank:    int64(data.Rank),
-				Value:   int64(p.Part),
+				RoundID:   r.ID,
+				Player:    p.Name,
+				Rank:      int64(data.Rank),
+				Value:     int64(p.Part),
+				Signature: sig,
 			})
 		} else {
 			pl = append(pl, player.Part{
@@ -127,7 +154,8 @@ func collectEngineParts(ctx context.Context, b Backends, f fate.Fate,
 	}
 
 	// Shift the round to RoundStatusCollected.
-	err = rounds.ShiftToCollected(ctx, b.PlayerDB(), r.ID)
+	err = rounds.ShiftToCollected(ctx, b.PlayerDB(), r.ID, reason,
+		player.RoundStatusCollect)
 	if err != nil {
 		return errors.Wrap(err, "failed to shift to collected",
 			j.KV("round", r.ID))
@@ -136,7 +164,8 @@ func collectEngineParts(ctx context.Context, b Backends, f fate.Fate,
 	return f.Tempt()
 }
 
-func submitParts(ctx context.Context, b Backends, f fate.Fate,
+func submitParts(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	roundID int64) error {
 	// Lookup round.
 	r, err := rounds.Lookup(ctx, b.PlayerDB(), roundID)
@@ -150,33 +179,33 @@ func submitParts(ctx context.Context, b Backends, f fate.Fate,
 		return fate.Tempt()
 	}
 
-	// List all parts for the round.
-	pl, err := parts.ListByRound(ctx, b.PlayerDB(), r.ID)
-	if err != nil {
-		return errors.Wrap(err, "failed to list parts for round",
-			j.KV("round", r.ID))
-	}
-
-	// Sum all of our parts.
-	var total int64
-	for _, p := range pl {
-		if strings.EqualFold(p.Player, *playerName) && !p.Submitted {
-			total += p.Value
+	// Resume a submission journaled before a crash, otherwise journal a
+	// new one before calling the engine.
+	s, err := submissions.LookupByRound(ctx, b.PlayerDB(), r.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s, err = journalSubmission(ctx, b, conf, r)
+		if errors.Is(err, errTotalDisputed) {
+			// Nothing is journaled, so the round can't be submitted and is
+			// failed with the dispute as its reason.
+			err = rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID,
+				reason.WithError(err), player.RoundStatusSubmit)
+			if err != nil {
+				return errors.Wrap(err, "failed to shift round to failed")
+			}
+
+			return f.Tempt()
+		} else if err != nil {
+			return errors.Wrap(err, "failed to journal submission",
+				j.KV("round", r.ID))
 		}
+	} else if err != nil {
+		return errors.Wrap(err, "failed to lookup submission",
+			j.KV("round", r.ID))
 	}
 
-	// Submit the round.
-	err = b.EngineClient().SubmitRound(ctx, *teamName, *playerName,
-		r.ExternalID, int(total))
-	if err != nil && !errors.Is(err, engine.ErrAlreadySubmitted) {
-		return errors.Wrap(err, "failed to submit parts")
-	}
-
-	// Shift round to submitted.
-	err = rounds.ShiftToSubmitted(ctx, b.PlayerDB(), r.ID, *playerName)
+	err = completeSubmission(ctx, b, conf, reason, r, s)
 	if err != nil {
-		return errors.Wrap(err, "failed to shift to submitted",
-			j.KV("round", r.ID))
+		return err
 	}
 
 	return fate.Tempt()
@@ -2,161 +2,269 @@ package ops
 
 import (
 	"context"
-	"flag"
+	"time"
 
 	"github.com/corverroos/unsure"
 	"github.com/corverroos/unsure/engine"
 	"github.com/luno/fate"
 	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
 	"github.com/luno/jettison/log"
 	"github.com/luno/reflex"
 
 	"unsure/player"
 	"unsure/player/internal/db/cursors"
 	"unsure/player/internal/db/rounds"
+	"unsure/player/playerpb/protocp"
 )
 
-var (
-	teamName   = flag.String("team_name", "", "Name of the team")
-	playerName = flag.String("player_name", "", "Name of the player")
-	debug      = flag.Bool("debug", false, "Enable debug mode")
-)
+// Start resolves the submissions and rounds left pending when the Player last
+// stopped, then starts its loops unless the context was cancelled meanwhile.
+func Start(ctx context.Context, b Backends, conf player.Config) (*Loops,
+	error) {
+	err := RecoverSubmissions(ctx, b, conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recover submissions")
+	}
+
+	// The consumers still advance rounds as new events arrive if this fails.
+	err = ReconcileRounds(ctx, b, conf)
+	if err != nil {
+		log.Error(ctx, errors.Wrap(err, "failed to reconcile rounds"))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return StartLoops(b, conf), nil
+}
+
+// StartLoops begins running reflex consumers in separate goroutines. The
+// returned Loops should be stopped before the Player exits.
+func StartLoops(b Backends, conf player.Config) *Loops {
+	l := newLoops(b, conf, cursors.Store(b.PlayerDB()))
 
-// StartLoops begins running reflex consumers in separate goroutines.
-func StartLoops(b Backends) {
 	log.Info(unsure.FatedContext(), "Starting event loop")
-	go startMatchesForever(b)
+	go electCoordinatorForever(l, b, conf)
+	go startMatchesForever(l, b, conf)
 
 	// Unsure Engine events.
-	go handleEngineEventsForever(b)
+	go handleEngineEventsForever(l, b, conf)
 	//go notifyToJoinForever(b)
 	//go notifyToCollectForever(b)
 	//go notifyToSubmitForever(b)
 	//go notifyRoundCompletionForever(b)
 
 	// Local events.
-	//go handleLocalEventsForever(b)
+	//go handleLocalEventsForever(l, b, conf)
 	//go joinRoundsForever(b)
 	//go collectEnginePartsForever(b)
 	//go submitPartsForever(b)
 
+	// Deadlines aren't events, so collected rounds are checked periodically.
+	if conf.SubmitStrategy == player.StrategyDeadline {
+		go submitOnDeadlineForever(l, b, conf)
+	}
+
+	// Compare parts with peers' copies in the background, if enabled.
+	if conf.ConsistencyCheckPeriod > 0 {
+		go checkConsistencyForever(l, b, conf)
+	}
+
+	// Round stats.
+	go handleStatsForever(l, b, conf)
+
 	// Peer events.
 	for _, p := range b.Peers() {
-		go handlePeerEventsForever(b, p)
+		go handlePeerEventsForever(l, b, conf, p)
 		//go collectPeerPartsForever(b, p)
 		//go acknowledgePeerSubmissionsForever(b, p)
 	}
 
+	return l
+}
+
+// engineConsumer names the consumer of the engine's events, whose cursor
+// marks the events the Player has handled.
+const engineConsumer = reflex.ConsumerName("engine_consumer")
+
+// PeerConsumerName returns the name of the consumer of the named peer's
+// events, whose cursor marks the peer's events the Player has handled.
+func PeerConsumerName(peer string) reflex.ConsumerName {
+	return reflex.ConsumerName("peer_consumer_" + peer)
 }
 
-func handleEngineEventsForever(b Backends) {
-	consumable := reflex.NewConsumable(b.EngineClient().Stream,
-		cursors.Store(b.PlayerDB()))
+func handleEngineEventsForever(l *Loops, b Backends, conf player.Config) {
+	consumable := reflex.NewConsumable(b.EngineClient().Stream, l.cursors)
 
 	consumerFn := func(ctx context.Context, f fate.Fate, e *reflex.Event) error {
+		reason := player.EngineEventReason(e.ID)
+
+		// Record the match so its rounds can be attributed to it. Every
+		// Player learns the match started here, not only the coordinator.
+		if reflex.IsType(e.Type, engine.EventTypeMatchStarted) {
+			l.setMatchStarted(e.Timestamp)
+			return notifyMatchStarted(ctx, b, conf, f, e.ForeignIDInt())
+		}
+
 		// Notify the players to join rounds.
 		if reflex.IsType(e.Type, engine.EventTypeRoundJoin) {
-			return notifyToJoin(ctx, b, f, e.ForeignIDInt())
+			return notifyToJoin(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		// Notify the players to collect parts.
 		if reflex.IsType(e.Type, engine.EventTypeRoundCollect) {
-			return notifyToCollect(ctx, b, f, e.ForeignIDInt())
+			return notifyToCollect(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		// Notify the players to submit their parts.
 		if reflex.IsType(e.Type, engine.EventTypeRoundSubmit) {
-			return notifyToSubmit(ctx, b, f, e.ForeignIDInt())
+			return notifyToSubmit(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		// Notify the players that the round has ended - success.
 		if reflex.IsType(e.Type, engine.EventTypeRoundSuccess) {
-			return notifyRoundSuccess(ctx, b, f, e.ForeignIDInt())
+			return notifyRoundSuccess(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		// Notify the players that the round has ended - failed.
 		if reflex.IsType(e.Type, engine.EventTypeRoundFailed) {
-			return notifyRoundFailed(ctx, b, f, e.ForeignIDInt())
+			return notifyRoundFailed(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		return fate.Tempt()
 	}
 
-	unsure.ConsumeForever(unsure.FatedContext, consumable.Consume,
-		reflex.NewConsumer("engine_consumer", consumerFn))
+	l.consumeForever(consumable.Consume,
+		reflex.NewConsumer(engineConsumer, l.track(engineConsumer,
+			consumerFn)))
 }
 
-func handlePeerEventsForever(b Backends, p player.Client) {
-	consumable := reflex.NewConsumable(p.StreamEvents,
-		cursors.Store(b.PlayerDB()))
+func handlePeerEventsForever(l *Loops, b Backends, conf player.Config,
+	p player.Client) {
+	consumable := reflex.NewConsumable(p.StreamEvents, l.cursors)
 
-	var peerName string
-	var err error
-	for {
-		peerName, err = p.GetName(unsure.FatedContext())
-		if err != nil {
-			log.Error(unsure.FatedContext(), errors.Wrap(err,
-				"failed to get player name"))
-			continue
-		}
-		break
+	hello, ok := greetPeer(l, conf, p)
+	if !ok {
+		return
 	}
 
+	// The peer responded to our greeting, so it is back if it had left.
+	PeerJoined(unsure.FatedContext(), b, hello.PlayerName)
+	peerName := hello.PlayerName
+
 	consumerFn := func(ctx context.Context, f fate.Fate, e *reflex.Event) error {
+		reason := player.PeerEventReason(peerName, e.ID)
+
+		// Decode the round metadata embedded in the event if the peer
+		// advertises it, otherwise it is fetched from the peer.
+		var meta *player.RoundMeta
+		if hello.Has(player.CapRichEvents) {
+			var err error
+			meta, err = protocp.UnmarshalRoundMeta(e.MetaData)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode round meta")
+			}
+		}
+
 		// Notify the players to collect parts from their peers.
 		if reflex.IsType(e.Type, player.RoundStatusCollected) {
-			return collectPeerParts(ctx, b, p, f, e.ForeignIDInt())
+			return collectPeerParts(ctx, b, conf, p, f, reason,
+				e.ForeignIDInt(), meta)
 		}
 
 		// Notify the players about a submission.
 		if reflex.IsType(e.Type, player.RoundStatusSubmitted) {
-			return acknowledgePeerSubmissions(ctx, b, p, f, e.ForeignIDInt())
+			return acknowledgePeerSubmissions(ctx, b, conf, p, f, reason,
+				e.ForeignIDInt(), meta)
 		}
 
 		return f.Tempt()
 	}
 
-	unsure.ConsumeForever(unsure.FatedContext, consumable.Consume,
-		reflex.NewConsumer(reflex.ConsumerName("peer_consumer_"+peerName),
-			consumerFn))
+	name := PeerConsumerName(peerName)
+	l.consumeForever(consumable.Consume,
+		reflex.NewConsumer(name, l.track(name, consumerFn)))
 }
 
-func handleLocalEventsForever(b Backends) {
+// Backoffs before retrying a peer handshake. Refused peers are retried in
+// case they are redeployed with a compatible build.
+const (
+	helloRetryBackoff   = time.Second
+	helloRefusedBackoff = 30 * time.Second
+)
+
+// greetPeer exchanges Hellos with the peer until the peer responds and is
+// compatible, returning false if the consumers are stopped first.
+func greetPeer(l *Loops, conf player.Config, p player.Client) (
+	*player.Hello, bool) {
+	for !l.isStopped() {
+		ctx := unsure.FatedContext()
+
+		hello, err := p.Hello(ctx)
+		if err == nil {
+			err = player.CheckPeer(conf, hello)
+		}
+		if err == nil {
+			log.Info(ctx, "Peer connected",
+				j.MKV{"peer": hello.PlayerName,
+					"build":    hello.BuildVersion,
+					"protocol": hello.ProtocolVersion})
+			return hello, true
+		}
+
+		l.logError(ctx, errors.Wrap(err, "peer handshake failed"))
+
+		backoff := helloRetryBackoff
+		if errors.Is(err, player.ErrIncompatiblePeer) {
+			backoff = helloRefusedBackoff
+		}
+
+		select {
+		case <-l.stopped:
+		case <-time.After(backoff):
+		}
+	}
+
+	return nil, false
+}
+
+func handleLocalEventsForever(l *Loops, b Backends, conf player.Config) {
 	consumable := reflex.NewConsumable(rounds.EventStream(b.PlayerDB()),
-		cursors.Store(b.PlayerDB()))
+		l.cursors)
 	consumerFn := func(ctx context.Context, f fate.Fate, e *reflex.Event) error {
+		reason := player.LocalEventReason(e.ID)
+
 		// Join rounds on the Unsure Engine.
 		if reflex.IsType(e.Type, player.RoundStatusJoin) {
-			return joinRounds(ctx, b, f, e.ForeignIDInt())
+			return joinRounds(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		// Collect parts from the Unsure Engine.
 		if reflex.IsType(e.Type, player.RoundStatusCollect) {
-			return collectEngineParts(ctx, b, f, e.ForeignIDInt())
+			return collectEngineParts(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		// Submit parts to the Unsure Engine.
 		if reflex.IsType(e.Type, player.RoundStatusSubmit) {
-			return submitParts(ctx, b, f, e.ForeignIDInt())
+			return submitParts(ctx, b, conf, f, reason,
+				e.ForeignIDInt())
 		}
 
 		return f.Tempt()
 	}
 
-	unsure.ConsumeForever(unsure.FatedContext, consumable.Consume,
-		reflex.NewConsumer("local_consumer", consumerFn))
-}
-
-func startMatchesForever(b Backends) {
-	for {
-		err := b.EngineClient().StartMatch(unsure.FatedContext(),
-			*teamName, len(b.Peers())+1)
-		if errors.Is(err, engine.ErrActiveMatch) {
-			break
-		} else if err != nil {
-			log.Error(unsure.FatedContext(), err)
-		}
-	}
+	name := reflex.ConsumerName("local_consumer")
+	l.consumeForever(consumable.Consume,
+		reflex.NewConsumer(name, l.track(name, consumerFn)))
 }
 
 //func notifyToJoinForever(b Backends) {
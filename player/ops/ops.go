@@ -2,40 +2,48 @@ package ops
 
 import (
 	"context"
+	"database/sql"
 	"unsure/player"
 	"unsure/player/internal/db/rounds"
-	"strings"
 
 	"github.com/luno/fate"
 	"github.com/luno/jettison/errors"
 	"github.com/luno/jettison/j"
 
 	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/stats"
 )
 
-func GetName() string {
-	return *playerName
-}
-
-func maybeReadyToSubmit(ctx context.Context, b Backends, f fate.Fate,
+func maybeReadyToSubmit(ctx context.Context, b Backends,
+	conf player.Config, f fate.Fate, reason player.TransitionReason,
 	roundID int64) error {
-	// Lookup parts for round.
-	pl, err := parts.ListByRound(ctx, b.PlayerDB(), roundID)
+	r, err := rounds.Lookup(ctx, b.PlayerDB(), roundID)
 	if err != nil {
-		return errors.Wrap(err, "failed to list parts for round",
+		return errors.Wrap(err, "failed to lookup round",
 			j.KV("round", roundID))
 	}
 
-	// If a peer has a lower rank, but has not yet submitted then we skip.
-	for _, p := range pl {
-		if !strings.EqualFold(p.Player, *playerName) && !p.Submitted {
-			return fate.Tempt()
-		}
+	// Only collected rounds are waiting to submit.
+	if r.Status != player.RoundStatusCollected {
+		return fate.Tempt()
 	}
 
-	// Shift the round into submit.
-	err = rounds.ShiftToSubmit(ctx, b.PlayerDB(), roundID)
+	rs, err := loadRoundState(ctx, b, conf, r)
 	if err != nil {
+		return err
+	}
+
+	// Skip until the strategy decides it's our turn to submit.
+	if !StrategyFor(conf).ReadyToSubmit(rs) {
+		return fate.Tempt()
+	}
+
+	// Shift the round into submit, unless it has already moved on.
+	err = rounds.ShiftToSubmit(ctx, b.PlayerDB(), roundID, reason,
+		player.RoundStatusCollected)
+	if errors.Is(err, player.ErrRoundConflict) {
+		return fate.Tempt()
+	} else if err != nil {
 		return errors.Wrap(err, "failed to update state to submit")
 	}
 
@@ -43,13 +51,97 @@ func maybeReadyToSubmit(ctx context.Context, b Backends, f fate.Fate,
 }
 
 // GetParts returns a list of parts the player has received from the engine.
-func GetParts(ctx context.Context, b Backends, externalID int64) (
+// It returns player.ErrRoundNotFound if the round doesn't exist and
+// player.ErrRoundNotReady if the parts haven't been collected yet.
+func GetParts(ctx context.Context, b Backends, conf player.Config,
+	externalID int64) ([]player.Part, error) {
+	r, err := rounds.LookupByExternalID(ctx, b.PlayerDB(), externalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(player.ErrRoundNotFound,
+			"failed to lookup round",
+			j.KV("external_id", externalID))
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup round",
+			j.KV("external_id", externalID))
+	}
+
+	// Parts are only available once collected from the engine.
+	if r.Status == player.RoundStatusJoin ||
+		r.Status == player.RoundStatusJoined ||
+		r.Status == player.RoundStatusCollect {
+		return nil, errors.Wrap(player.ErrRoundNotReady,
+			"parts not collected",
+			j.KV("external_id", externalID),
+			j.KV("status", r.Status.String()))
+	}
+
+	return parts.ListByRoundAndPlayer(ctx, b.PlayerDB(), r.ID,
+		conf.PlayerName)
+}
+
+// GetRoundParts returns all the parts the Player holds for a round, both its
+// own and its peers'. It returns player.ErrRoundNotFound if the round doesn't
+// exist.
+func GetRoundParts(ctx context.Context, b Backends, externalID int64) (
 	[]player.Part, error) {
 	r, err := rounds.LookupByExternalID(ctx, b.PlayerDB(), externalID)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(player.ErrRoundNotFound,
+			"failed to lookup round",
+			j.KV("external_id", externalID))
+	} else if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup round",
 			j.KV("external_id", externalID))
 	}
 
-	return parts.ListByRoundAndPlayer(ctx, b.PlayerDB(), r.ID, *playerName)
-}
\ No newline at end of file
+	return parts.ListByRound(ctx, b.PlayerDB(), r.ID)
+}
+
+// GetRound returns a round from the Player's DB. It returns
+// player.ErrRoundNotFound if the round doesn't exist.
+func GetRound(ctx context.Context, b Backends, roundID int64) (
+	*player.Round, error) {
+	r, err := rounds.Lookup(ctx, b.PlayerDB(), roundID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(player.ErrRoundNotFound,
+			"failed to lookup round",
+			j.KV("round_id", roundID))
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup round",
+			j.KV("round_id", roundID))
+	}
+
+	return r, nil
+}
+
+// maxListRounds is the maximum number of rounds returned by ListRounds.
+const maxListRounds = 1000
+
+// ListRounds returns up to limit rounds with ids greater than afterID. It
+// returns player.ErrInvalidArgument if the limit is out of range.
+func ListRounds(ctx context.Context, b Backends, afterID int64, limit int) (
+	[]player.Round, error) {
+	if limit <= 0 || limit > maxListRounds {
+		return nil, errors.Wrap(player.ErrInvalidArgument,
+			"limit out of range", j.KV("limit", limit))
+	}
+
+	return rounds.List(ctx, b.PlayerDB(), afterID, limit)
+}
+
+// GetRoundAudit returns the audit trail of a round. It returns
+// player.ErrRoundNotFound if the round doesn't exist.
+func GetRoundAudit(ctx context.Context, b Backends, roundID int64) (
+	[]player.RoundAudit, error) {
+	_, err := GetRound(ctx, b, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rounds.ListAudit(ctx, b.PlayerDB(), roundID)
+}
+
+// GetStats returns the aggregate results of the Player's completed rounds.
+func GetStats(ctx context.Context, b Backends) (*player.Stats, error) {
+	return stats.Load(ctx, b.PlayerDB())
+}
@@ -11,90 +11,224 @@ import (
 	"unsure/player"
 	"unsure/player/internal/db/parts"
 	"unsure/player/internal/db/rounds"
+	"unsure/player/internal/signing"
 )
 
-func collectPeerParts(ctx context.Context, b Backends, p player.Client,
-	f fate.Fate, foreignID int64) error {
-	if *debug {
+// errPeerRejected is recorded as the reason for rounds failed because a
+// peer's parts or submission weren't signed by it.
+var errPeerRejected = errors.New("peer signature rejected",
+	j.C("ERR_a3d70e5c18b94f26"))
+
+func collectPeerParts(ctx context.Context, b Backends,
+	conf player.Config, p player.Client, f fate.Fate,
+	reason player.TransitionReason, foreignID int64,
+	meta *player.RoundMeta) error {
+	if conf.Debug {
 		log.Info(ctx, "Parts collected by peer",
 			j.KV("peer_round", foreignID))
 	}
 
-	// Fetch round from peer.
-	peerRound, err := p.GetRound(ctx, foreignID)
-	if err != nil {
-		return errors.Wrap(err, "failed to fetch remote round",
-			j.KV("peer_round", foreignID))
+	// Fetch round and parts from peer if the event didn't carry them.
+	if meta == nil {
+		var err error
+		meta, err = fetchPeerRoundMeta(ctx, p, foreignID)
+		if errors.Is(err, player.ErrRoundNotFound) {
+			// The peer no longer has the round, so there is nothing to
+			// collect.
+			log.Info(ctx, "Peer round not found",
+				j.KV("peer_round", foreignID))
+			return f.Tempt()
+		} else if err != nil {
+			return errors.Wrap(err, "failed to fetch remote round meta",
+				j.KV("peer_round", foreignID))
+		}
 	}
 
 	// Lookup round.
-	r, err := rounds.LookupByExternalID(ctx, b.PlayerDB(),
-		peerRound.ExternalID)
+	r, err := rounds.LookupByExternalID(ctx, b.PlayerDB(), meta.ExternalID)
 	if err != nil {
 		return errors.Wrap(err, "failed to lookup round",
-			j.KV("external_id", peerRound.ExternalID))
+			j.KV("external_id", meta.ExternalID))
 	}
 
 	// Ensure we haven't already collected a peers parts by checking
 	// whether we know their rank.
-	_, err = parts.LookupRankByPlayer(ctx, b.PlayerDB(),
-		r.ID, peerRound.Player)
+	_, err = parts.LookupRankByPlayer(ctx, b.PlayerDB(), r.ID, meta.Player)
 	if err == nil {
 		// If we have a ranked part for a player, we have already
 		// collected their parts.
 		return f.Tempt()
 	}
 
-	// Fetch parts from Peer.
-	peerParts, err := p.GetParts(ctx, peerRound.ExternalID)
-	if err != nil {
-		return errors.Wrap(err, "failed to fetch remote parts",
-			j.KV("external_id", peerRound.ExternalID))
+	// Reject parts which weren't signed by the peer they belong to.
+	err = verifyPeerParts(b.Keyring(), meta)
+	if isSignatureErr(err) {
+		return rejectPeer(ctx, b, f, reason, r, meta.Player,
+			errors.Wrap(err, "rejected peer parts"))
+	} else if err != nil {
+		return errors.Wrap(err, "failed to verify peer parts")
 	}
 
-	for _, p := range peerParts {
+	// Convert peer parts into parts of our local round.
+	var peerParts []player.Part
+	for i, v := range meta.Parts {
 		log.Info(ctx, "Peer part collected",
-			j.MKV{"value": p.Value, "rank": p.Rank, "submitted": p.Submitted})
+			j.MKV{"value": v, "rank": meta.Rank, "submitted": meta.Submitted})
+
+		peerParts = append(peerParts, player.Part{
+			RoundID:   r.ID,
+			Player:    meta.Player,
+			Rank:      meta.Rank,
+			Value:     v,
+			Signature: partSignature(meta, i),
+		})
 	}
 
 	// Store peer parts.
 	err = parts.CreateBatch(ctx, b.PlayerDB(), peerParts)
 	if err != nil {
 		return errors.Wrap(err, "failed to store peer parts",
-			j.KV("external_id", peerRound.ExternalID))
+			j.KV("external_id", meta.ExternalID))
 	}
 
 	return f.Tempt()
 }
 
 func acknowledgePeerSubmissions(ctx context.Context, b Backends,
-	p player.Client, f fate.Fate, foreignID int64) error {
-	// Fetch round from peer.
-	peerRound, err := p.GetRound(ctx, foreignID)
-	if err != nil {
-		return errors.Wrap(err, "failed to fetch remote round",
-			j.KV("peer_round", foreignID))
+	conf player.Config, p player.Client, f fate.Fate,
+	reason player.TransitionReason, foreignID int64,
+	meta *player.RoundMeta) error {
+	// Fetch round and parts from peer if the event didn't carry them.
+	if meta == nil {
+		var err error
+		meta, err = fetchPeerRoundMeta(ctx, p, foreignID)
+		if errors.Is(err, player.ErrRoundNotFound) {
+			// The peer no longer has the round, so there is nothing to
+			// acknowledge.
+			log.Info(ctx, "Peer round not found",
+				j.KV("peer_round", foreignID))
+			return f.Tempt()
+		} else if err != nil {
+			return errors.Wrap(err, "failed to fetch remote round meta",
+				j.KV("peer_round", foreignID))
+		}
 	}
 
 	// Lookup round.
-	r, err := rounds.LookupByExternalID(ctx, b.PlayerDB(),
-		peerRound.ExternalID)
+	r, err := rounds.LookupByExternalID(ctx, b.PlayerDB(), meta.ExternalID)
 	if err != nil {
 		return errors.Wrap(err, "failed to lookup round",
-			j.KV("external_id", peerRound.ExternalID))
+			j.KV("external_id", meta.ExternalID))
+	}
+
+	// Reject acknowledgements which weren't signed by the peer.
+	err = b.Keyring().VerifySubmitted(meta.ExternalID, meta.Player,
+		meta.SubmittedSignature)
+	if isSignatureErr(err) {
+		return rejectPeer(ctx, b, f, reason, r, meta.Player,
+			errors.Wrap(err, "rejected peer submission"))
+	} else if err != nil {
+		return errors.Wrap(err, "failed to verify peer submission")
 	}
 
 	// Mark the peer player's parts as submitted.
-	err = parts.MarkAsSubmitted(ctx, b.PlayerDB(), r.ID, peerRound.Player)
+	err = parts.MarkAsSubmitted(ctx, b.PlayerDB(), r.ID, meta.Player,
+		meta.SubmittedSignature)
 	if err != nil {
 		return errors.Wrap(err, "failed to mark parts as submitted")
 	}
 
 	// Check whether it's our turn to submit parts.
-	err = maybeReadyToSubmit(ctx, b, f, r.ID)
+	err = maybeReadyToSubmit(ctx, b, conf, f, reason, r.ID)
 	if err != nil {
 		return errors.Wrap(err, "failed to check if player should submit")
 	}
 
 	return fate.Tempt()
 }
+
+// rejectPeer fails the round since the named peer's parts or submission
+// weren't signed by it, so the round's total can't be trusted. The
+// rejection is recorded in the round's audit trail.
+func rejectPeer(ctx context.Context, b Backends, f fate.Fate,
+	reason player.TransitionReason, r *player.Round, peer string,
+	rejectErr error) error {
+	log.Error(ctx, errors.Wrap(rejectErr, "failing round",
+		j.KV("external_id", r.ExternalID), j.KV("peer", peer)))
+
+	err := rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID,
+		reason.WithError(errors.Wrap(errPeerRejected, peer)),
+		statusFirst(r.Status, joinedStatuses)...)
+	if errors.Is(err, player.ErrRoundConflict) {
+		// The round already ended.
+		return f.Tempt()
+	} else if err != nil {
+		return errors.Wrap(err, "failed to shift round to failed",
+			j.KV("round", r.ID))
+	}
+
+	return f.Tempt()
+}
+
+// fetchPeerRoundMeta builds a round's metadata by calling back into the peer.
+// It is only required for events which don't embed the metadata themselves.
+func fetchPeerRoundMeta(ctx context.Context, p player.Client,
+	foreignID int64) (*player.RoundMeta, error) {
+	peerRound, err := p.GetRound(ctx, foreignID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch remote round")
+	}
+
+	peerParts, err := p.GetParts(ctx, peerRound.ExternalID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch remote parts",
+			j.KV("external_id", peerRound.ExternalID))
+	}
+
+	meta := player.RoundMeta{
+		ExternalID: peerRound.ExternalID,
+		Player:     peerRound.Player,
+		Submitted:  len(peerParts) > 0,
+	}
+	for _, pp := range peerParts {
+		if pp.Rank != 0 {
+			meta.Rank = pp.Rank
+		}
+		meta.Parts = append(meta.Parts, pp.Value)
+		meta.PartSignatures = append(meta.PartSignatures, pp.Signature)
+		meta.Submitted = meta.Submitted && pp.Submitted
+		meta.SubmittedSignature = pp.SubmittedSignature
+	}
+
+	return &meta, nil
+}
+
+// verifyPeerParts verifies the peer's signature of each of its parts.
+func verifyPeerParts(k *signing.Keyring, meta *player.RoundMeta) error {
+	for i, v := range meta.Parts {
+		err := k.VerifyPart(meta.ExternalID, meta.Player, meta.Rank, v,
+			partSignature(meta, i))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// partSignature returns the signature of the i'th part in the metadata, or
+// nil if it wasn't signed.
+func partSignature(meta *player.RoundMeta, i int) []byte {
+	if i >= len(meta.PartSignatures) {
+		return nil
+	}
+
+	return meta.PartSignatures[i]
+}
+
+// isSignatureErr returns whether the error indicates a tampered or spoofed
+// peer rather than a transient failure.
+func isSignatureErr(err error) bool {
+	return errors.IsAny(err, signing.ErrInvalidSignature,
+		signing.ErrUnknownPeer)
+}
@@ -0,0 +1,327 @@
+package ops
+
+import (
+	"context"
+	"time"
+
+	"github.com/corverroos/unsure/engine"
+	"github.com/luno/fate"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/internal/db/cursors"
+	"unsure/player/internal/db/rounds"
+)
+
+// reconcileIdle is how long to wait for the next engine event before
+// assuming the engine's stream has been read to its head.
+const reconcileIdle = 2 * time.Second
+
+// maxReconcileSteps bounds the number of times a round is re-driven, since
+// each step should advance it.
+const maxReconcileSteps = 8
+
+// errRoundEnded is recorded as the reason for rounds which ended on the
+// Unsure Engine before the Player joined them.
+var errRoundEnded = errors.New("round ended before join",
+	j.C("ERR_e27d40b9c6a5f318"))
+
+// engineStatuses maps the engine's round event types to the round status
+// they correspond to.
+var engineStatuses = map[engine.EventType]player.RoundStatus{
+	engine.EventTypeRoundJoin:      player.RoundStatusJoin,
+	engine.EventTypeRoundJoined:    player.RoundStatusJoined,
+	engine.EventTypeRoundCollect:   player.RoundStatusCollect,
+	engine.EventTypeRoundCollected: player.RoundStatusCollected,
+	engine.EventTypeRoundSubmit:    player.RoundStatusSubmit,
+	engine.EventTypeRoundSubmitted: player.RoundStatusSubmitted,
+	engine.EventTypeRoundSuccess:   player.RoundStatusSuccess,
+	engine.EventTypeRoundFailed:    player.RoundStatusFailed,
+}
+
+// ReconcileRounds brings the rounds left active when the Player last stopped
+// up to date before its consumers start, since the events which would have
+// advanced them may already have been consumed. Each round is compared with
+// the engine's latest event for it since the engine consumer's cursor, then
+// terminated if the engine has ended it, or re-driven through the steps the
+// consumers would have taken, collecting parts and submissions from peers as
+// they become due.
+func ReconcileRounds(ctx context.Context, b Backends,
+	conf player.Config) error {
+	rl, err := rounds.ListActive(ctx, b.PlayerDB())
+	if err != nil {
+		return errors.Wrap(err, "failed to list active rounds")
+	}
+	if len(rl) == 0 {
+		return nil
+	}
+
+	active := make(map[int64]bool)
+	for _, r := range rl {
+		active[r.ExternalID] = true
+	}
+
+	latest, err := readEngineStatuses(ctx, b, active)
+	if err != nil {
+		return errors.Wrap(err, "failed to read engine events")
+	}
+
+	// The consumers' fate is tempted after their work is done, so the
+	// reconciler doesn't tempt it again.
+	f := fate.New(fate.WithDefaultP(0), fate.WithoutOfficeHours())
+
+	summary := make(map[string]int)
+	for _, r := range rl {
+		outcome, err := reconcileRound(ctx, b, conf, f, r, latest[r.ExternalID])
+		if err != nil {
+			// The consumers pick up from wherever the round was left.
+			log.Error(ctx, errors.Wrap(err, "failed to reconcile round",
+				j.KV("round", r.ID)))
+			outcome = "failed"
+		}
+		summary[outcome]++
+	}
+
+	log.Info(ctx, "Reconciled active rounds", j.MKV{
+		"rounds":     len(rl),
+		"terminated": summary["terminated"],
+		"advanced":   summary["advanced"],
+		"unchanged":  summary["unchanged"],
+		"failed":     summary["failed"],
+	})
+
+	return nil
+}
+
+// reconcileRound terminates or re-drives the round given the engine's status
+// for it, returning whether it was "terminated", "advanced" or left
+// "unchanged".
+func reconcileRound(ctx context.Context, b Backends, conf player.Config,
+	f fate.Fate, r player.Round, engineStatus player.RoundStatus) (
+	string, error) {
+	reason := player.RecoveryReason("reconcile")
+
+	switch engineStatus {
+	case player.RoundStatusSuccess, player.RoundStatusFailed:
+		return "terminated", terminateRound(ctx, b, conf, f, reason, r,
+			engineStatus)
+	}
+
+	from := r.Status
+	for i := 0; i < maxReconcileSteps; i++ {
+		err := stepRound(ctx, b, conf, f, reason, r, engineStatus)
+		if err != nil {
+			return "", err
+		}
+
+		next, err := rounds.Lookup(ctx, b.PlayerDB(), r.ID)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to lookup round")
+		}
+		if next.Status == r.Status {
+			break
+		}
+		r = *next
+	}
+
+	if r.Status == from {
+		return "unchanged", nil
+	}
+
+	return "advanced", nil
+}
+
+// terminateRound ends the round as the engine did. Rounds the Player never
+// joined are failed.
+func terminateRound(ctx context.Context, b Backends, conf player.Config,
+	f fate.Fate, reason player.TransitionReason, r player.Round,
+	engineStatus player.RoundStatus) error {
+	if r.Status == player.RoundStatusJoin {
+		return rounds.ShiftToFailed(ctx, b.PlayerDB(), r.ID,
+			reason.WithError(errRoundEnded), player.RoundStatusJoin)
+	}
+
+	if engineStatus == player.RoundStatusSuccess {
+		return ignoreTempt(notifyRoundSuccess(ctx, b, conf, f, reason,
+			r.ExternalID))
+	}
+
+	return ignoreTempt(notifyRoundFailed(ctx, b, conf, f, reason,
+		r.ExternalID))
+}
+
+// stepRound takes the step a consumer would take for the round in its
+// current status, given the status of the round on the engine.
+func stepRound(ctx context.Context, b Backends, conf player.Config,
+	f fate.Fate, reason player.TransitionReason, r player.Round,
+	engineStatus player.RoundStatus) error {
+	var err error
+	switch r.Status {
+	case player.RoundStatusJoin:
+		err = joinRounds(ctx, b, conf, f, reason, r.ID)
+
+	case player.RoundStatusJoined:
+		if engineStatus >= player.RoundStatusCollect {
+			err = notifyToCollect(ctx, b, conf, f, reason, r.ExternalID)
+		}
+
+	case player.RoundStatusCollect:
+		err = collectEngineParts(ctx, b, conf, f, reason, r.ID)
+
+	case player.RoundStatusCollected:
+		err = syncPeers(ctx, b, conf, f, reason, r.ExternalID)
+		if err == nil && engineStatus >= player.RoundStatusSubmit {
+			err = notifyToSubmit(ctx, b, conf, f, reason, r.ExternalID)
+		}
+
+	case player.RoundStatusSubmit:
+		err = submitParts(ctx, b, conf, f, reason, r.ID)
+
+	case player.RoundStatusSubmitted:
+		// Only the engine can end the round.
+	}
+
+	return ignoreTempt(err)
+}
+
+// syncPeers collects the parts and submissions of the round from each peer,
+// as the peer consumers would have from the peers' events.
+func syncPeers(ctx context.Context, b Backends, conf player.Config,
+	f fate.Fate, reason player.TransitionReason, externalID int64) error {
+	for _, p := range b.Peers() {
+		meta, err := peerRoundMeta(ctx, p, externalID)
+		if errors.IsAny(err, player.ErrRoundNotFound,
+			player.ErrRoundNotReady) {
+			// The peer hasn't collected its parts yet.
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "failed to fetch peer round meta")
+		}
+
+		err = collectPeerParts(ctx, b, conf, p, f, reason, 0, meta)
+		if err = ignoreTempt(err); err != nil {
+			return err
+		}
+
+		if !meta.Submitted {
+			continue
+		}
+
+		err = acknowledgePeerSubmissions(ctx, b, conf, p, f, reason, 0, meta)
+		if err = ignoreTempt(err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// peerRoundMeta builds a round's metadata from the peer's parts, looked up by
+// the round's external id.
+func peerRoundMeta(ctx context.Context, p player.Client, externalID int64) (
+	*player.RoundMeta, error) {
+	name, err := p.GetName(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get peer name")
+	}
+
+	pl, err := p.GetParts(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := player.RoundMeta{
+		ExternalID: externalID,
+		Player:     name,
+		Submitted:  len(pl) > 0,
+	}
+	for _, pp := range pl {
+		if pp.Rank != 0 {
+			meta.Rank = pp.Rank
+		}
+		meta.Parts = append(meta.Parts, pp.Value)
+		meta.PartSignatures = append(meta.PartSignatures, pp.Signature)
+		meta.Submitted = meta.Submitted && pp.Submitted
+		meta.SubmittedSignature = pp.SubmittedSignature
+	}
+
+	return &meta, nil
+}
+
+// readEngineStatuses returns the status of each of the given rounds
+// according to the engine's latest event for it which the engine consumer
+// hasn't yet handled. Reading resumes from the consumer's stored cursor, since
+// the rounds already reflect the events before it, so rounds without newer
+// events are left out. Since the engine's stream never ends, reading stops
+// once it has been idle for reconcileIdle.
+func readEngineStatuses(ctx context.Context, b Backends,
+	externalIDs map[int64]bool) (map[int64]player.RoundStatus, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cursor, err := cursors.Store(b.PlayerDB()).GetCursor(ctx,
+		engineConsumer.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get engine cursor")
+	}
+
+	sc, err := b.EngineClient().Stream(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		e   *reflex.Event
+		err error
+	}
+
+	results := make(chan result)
+	go func() {
+		for {
+			e, err := sc.Recv()
+			select {
+			case results <- result{e: e, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	res := make(map[int64]player.RoundStatus)
+	for {
+		var r result
+		select {
+		case r = <-results:
+		case <-time.After(reconcileIdle):
+			return res, nil
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		if !externalIDs[r.e.ForeignIDInt()] {
+			continue
+		}
+
+		st, ok := engineStatuses[engine.EventType(r.e.Type.ReflexType())]
+		if ok {
+			res[r.e.ForeignIDInt()] = st
+		}
+	}
+}
+
+// ignoreTempt returns nil if the error is only from tempting fate, which the
+// handlers do after their work is done.
+func ignoreTempt(err error) error {
+	if errors.Is(err, fate.ErrTempt) {
+		return nil
+	}
+
+	return err
+}
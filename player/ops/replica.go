@@ -0,0 +1,317 @@
+package ops
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corverroos/unsure"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/internal/db/cursors"
+	"unsure/player/internal/db/leases"
+)
+
+// Replica runs the Player's loops only while it holds the Player's lease, so
+// that replicas sharing the Player's database take over from each other when
+// one fails. Every replica serves the Player's read-only RPCs from the shared
+// database, whether or not it holds the lease.
+type Replica struct {
+	b    Backends
+	conf player.Config
+
+	// loops are nil while the replica stands by, guarded by mu.
+	mu    sync.Mutex
+	loops *Loops
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// StartReplica begins competing for the Player's lease, starting the loops
+// whenever this replica takes it. The returned Replica should be stopped
+// before the Player exits.
+func StartReplica(b Backends, conf player.Config) *Replica {
+	r := &Replica{
+		b:    b,
+		conf: conf,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go r.holdLeaseForever()
+
+	return r
+}
+
+// holdLeaseForever acquires or renews the lease every third of its ttl until
+// the replica is stopped. The loops are started when the lease is taken and
+// handed over when it is lost, or when it can't be renewed in time for them
+// to drain before it expires. Each attempt is given a third of the ttl, so
+// the loops are always stopped by the time the lease expires, and the lease
+// keeps being renewed while the loops start.
+func (r *Replica) holdLeaseForever() {
+	defer close(r.done)
+
+	period := r.conf.LeaseTTL / 3
+
+	var (
+		heldUntil time.Time
+		held      bool
+	)
+	for {
+		heldUntil, held = r.renew(heldUntil, period, r.isLeading())
+
+		if held && !r.isLeading() {
+			heldUntil = r.lead(heldUntil, period)
+		} else if !held && r.isLeading() {
+			r.standBy(heldUntil)
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-time.After(period):
+		}
+	}
+}
+
+// renew acquires or renews the lease, returning when it expires and whether
+// the replica holds it. If the lease can't be renewed, a leading replica
+// keeps it only while there is time to drain before it expires.
+func (r *Replica) renew(heldUntil time.Time, period time.Duration,
+	leading bool) (time.Time, bool) {
+	t0 := time.Now()
+	held, err := r.acquire(period)
+	if err != nil {
+		log.Error(nil, errors.Wrap(err, "failed to renew lease"))
+		return heldUntil, leading && time.Now().Add(period).Before(heldUntil)
+	} else if !held {
+		return heldUntil, false
+	}
+
+	return t0.Add(r.conf.LeaseTTL), true
+}
+
+// acquire acquires or renews the lease, giving up after the timeout.
+func (r *Replica) acquire(timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(unsure.FatedContext(), timeout)
+	defer cancel()
+
+	return leases.Acquire(ctx, r.b.PlayerDB(), r.conf.PlayerName,
+		r.conf.ReplicaID, r.conf.LeaseTTL)
+}
+
+// lead starts the loops, renewing the lease every period while recovering
+// and reconciling rounds, since that may outlast the lease. Starting is
+// cancelled if the lease is lost or the replica is stopped, and the lease
+// is checked once more before leaving standby, so that only one replica
+// runs the loops at a time. It returns when the lease now expires.
+func (r *Replica) lead(heldUntil time.Time, period time.Duration) time.Time {
+	ctx, cancel := context.WithCancel(unsure.FatedContext())
+	defer cancel()
+
+	log.Info(ctx, "Replica took the lease, starting loops",
+		j.KV("replica", r.conf.ReplicaID))
+
+	type started struct {
+		l   *Loops
+		err error
+	}
+	done := make(chan started, 1)
+	go func() {
+		l, err := Start(ctx, r.b, r.conf)
+		done <- started{l: l, err: err}
+	}()
+
+	for {
+		var held bool
+		select {
+		case s := <-done:
+			if s.err != nil {
+				log.Error(ctx, errors.Wrap(s.err, "failed to start loops"))
+				r.release()
+				return heldUntil
+			}
+
+			heldUntil, held = r.renew(heldUntil, period, true)
+			if !held {
+				r.handOver(s.l, heldUntil)
+				return heldUntil
+			}
+
+			r.mu.Lock()
+			r.loops = s.l
+			r.mu.Unlock()
+
+			r.b.Standby().Set(false)
+
+			return heldUntil
+
+		case <-r.stop:
+			// Don't release the lease if the loops didn't drain in time,
+			// since rounds may still be in flight.
+			cancel()
+			if s := <-done; s.err != nil || r.handOver(s.l, heldUntil) {
+				r.release()
+			}
+			return heldUntil
+
+		case <-time.After(period):
+			heldUntil, held = r.renew(heldUntil, period, true)
+			if held {
+				continue
+			}
+
+			log.Info(ctx, "Replica lost the lease while starting loops",
+				j.KV("replica", r.conf.ReplicaID))
+
+			cancel()
+			if s := <-done; s.err == nil {
+				r.handOver(s.l, heldUntil)
+			}
+			return heldUntil
+		}
+	}
+}
+
+// release releases the lease so that a standby replica can take it without
+// waiting for it to expire.
+func (r *Replica) release() {
+	err := leases.Release(unsure.ContextWithFate(context.Background(), 0),
+		r.b.PlayerDB(), r.conf.PlayerName, r.conf.ReplicaID)
+	if err != nil {
+		log.Error(nil, errors.Wrap(err, "failed to release lease"))
+	}
+}
+
+// standBy hands over the loops and refuses requests which change the
+// Player's state from now on.
+func (r *Replica) standBy(heldUntil time.Time) {
+	r.b.Standby().Set(true)
+
+	r.mu.Lock()
+	l := r.loops
+	r.loops = nil
+	r.mu.Unlock()
+
+	log.Info(nil, "Replica lost the lease, standing by",
+		j.KV("replica", r.conf.ReplicaID))
+
+	r.handOver(l, heldUntil)
+}
+
+// handOver stops the loops, waiting for in-flight round work until the lease
+// expires. If it already has, in-flight work is cancelled immediately since
+// another replica may have taken over. It returns true if the loops drained.
+func (r *Replica) handOver(l *Loops, heldUntil time.Time) bool {
+	ctx, cancel := context.WithDeadline(
+		unsure.ContextWithFate(context.Background(), 0), heldUntil)
+	defer cancel()
+
+	if err := l.handOver(ctx); err != nil {
+		log.Error(ctx, errors.Wrap(err, "failed to hand over loops"))
+		return false
+	}
+
+	return true
+}
+
+func (r *Replica) isLeading() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.loops != nil
+}
+
+// Stop stops competing for the lease. If the replica holds it, the loops are
+// stopped like Loops.Stop but without notifying peers, and the lease is
+// released so that a standby replica takes over without waiting for it to
+// expire.
+func (r *Replica) Stop(ctx context.Context) error {
+	close(r.stop)
+	<-r.done
+
+	r.b.Standby().Set(true)
+
+	r.mu.Lock()
+	l := r.loops
+	r.loops = nil
+	r.mu.Unlock()
+
+	if l == nil {
+		return nil
+	}
+
+	err := l.handOver(ctx)
+
+	// Don't release the lease if the deadline passed, since rounds may still
+	// be in flight.
+	if err != nil {
+		return err
+	}
+
+	return leases.Release(unsure.ContextWithFate(context.Background(), 0),
+		r.b.PlayerDB(), r.conf.PlayerName, r.conf.ReplicaID)
+}
+
+// Status returns a snapshot of what the Player is doing. A standby replica
+// reports the rounds and peers as seen from the shared database, without
+// any consumer progress.
+func (r *Replica) Status(ctx context.Context) (*Status, error) {
+	r.mu.Lock()
+	l := r.loops
+	r.mu.Unlock()
+
+	standby := l == nil
+	if standby {
+		l = newLoops(r.b, r.conf, cursors.Store(r.b.PlayerDB()))
+	}
+
+	s, err := l.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.ReplicaID = r.conf.ReplicaID
+	s.Standby = standby
+
+	return s, nil
+}
+
+// Standby records whether the Player is a replica standing by for its lease.
+// A standby replica refuses requests which change the Player's state, since
+// they wouldn't reach the replica leading. The zero value isn't standing by,
+// as for Players without replicas.
+type Standby struct {
+	mu sync.Mutex
+	on bool
+}
+
+// Set records whether the Player is standing by.
+func (s *Standby) Set(on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.on = on
+}
+
+// IsOn returns whether the Player is standing by.
+func (s *Standby) IsOn() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.on
+}
+
+// checkLeading returns player.ErrStandby if the Player is standing by.
+func checkLeading(b Backends) error {
+	if b.Standby().IsOn() {
+		return errors.Wrap(player.ErrStandby, "not leading")
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package ops
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/luno/fate"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/rounds"
+	"unsure/player/internal/db/stats"
+)
+
+// handleStatsForever records the stats of each round as it completes.
+func handleStatsForever(l *Loops, b Backends, conf player.Config) {
+	consumable := reflex.NewConsumable(rounds.EventStream(b.PlayerDB()),
+		l.cursors)
+	consumerFn := func(ctx context.Context, f fate.Fate, e *reflex.Event) error {
+		if !reflex.IsAnyType(e.Type, player.RoundStatusSuccess,
+			player.RoundStatusFailed) {
+			return f.Tempt()
+		}
+
+		return recordRoundStats(ctx, b, conf, f, e.ForeignIDInt())
+	}
+
+	name := reflex.ConsumerName("stats_consumer")
+	l.consumeForever(consumable.Consume,
+		reflex.NewConsumer(name, l.track(name, consumerFn)))
+}
+
+func recordRoundStats(ctx context.Context, b Backends, conf player.Config,
+	f fate.Fate, roundID int64) error {
+	// Lookup the round.
+	r, err := rounds.Lookup(ctx, b.PlayerDB(), roundID)
+	if err != nil {
+		return errors.Wrap(err, "failed to lookup round",
+			j.KV("round", roundID))
+	}
+
+	// Skip rounds which haven't completed.
+	if r.Status != player.RoundStatusSuccess &&
+		r.Status != player.RoundStatusFailed {
+		return f.Tempt()
+	}
+
+	tl, err := rounds.ListTransitions(ctx, b.PlayerDB(), r.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list transitions",
+			j.KV("round", r.ID))
+	}
+
+	pl, err := parts.ListByRound(ctx, b.PlayerDB(), r.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list parts",
+			j.KV("round", r.ID))
+	}
+
+	rs := stats.Round{
+		ID:       r.ID,
+		MatchID:  r.MatchID,
+		Status:   r.Status,
+		Excluded: r.Excluded,
+		Phases:   make(map[player.RoundStatus]time.Duration),
+		Peers:    make(map[string]bool),
+	}
+
+	// A round spends time in each status until its next transition, and
+	// completes with its last.
+	var completedAt time.Time
+	for i, t := range tl {
+		if i+1 < len(tl) {
+			rs.Phases[t.Status] += tl[i+1].Timestamp.Sub(t.Timestamp)
+		}
+		completedAt = t.Timestamp
+	}
+
+	for _, p := range pl {
+		if strings.EqualFold(p.Player, conf.PlayerName) {
+			rs.Parts++
+			rs.PartsTotal += p.Value
+			if p.Submitted {
+				rs.SubmittedTotal += p.Value
+			}
+			continue
+		}
+
+		// A peer's submission is late if it was only acknowledged after
+		// the round completed. Peers which never submitted aren't late.
+		late := p.Submitted && p.UpdatedAt.After(completedAt)
+		rs.Peers[p.Player] = rs.Peers[p.Player] || late
+	}
+
+	err = stats.Record(ctx, b.PlayerDB(), rs)
+	if err != nil {
+		return errors.Wrap(err, "failed to record stats",
+			j.KV("round", r.ID))
+	}
+
+	return f.Tempt()
+}
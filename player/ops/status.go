@@ -0,0 +1,215 @@
+package ops
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/corverroos/unsure"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/rounds"
+)
+
+const (
+	// statusRounds is the number of latest rounds included in a Status.
+	statusRounds = 20
+
+	// maxRecentErrors is the number of recent errors included in a Status.
+	maxRecentErrors = 20
+
+	// peerPingTimeout bounds checking the health of each peer.
+	peerPingTimeout = time.Second
+)
+
+// Status is a snapshot of what the Player is doing, as shown on its status
+// page.
+type Status struct {
+	TeamName   string
+	PlayerName string
+	Timestamp  time.Time
+
+	// MatchStartedAt is when the Player found the team's match active, or
+	// zero if it hasn't yet.
+	MatchStartedAt time.Time
+
+	// Coordinator is the name of the Player last elected to make the team's
+	// decisions, or empty if no election has completed yet.
+	Coordinator string
+
+	// ReplicaID identifies the replica reporting the status, if the Player
+	// runs as replicas, and Standby whether it is waiting to take over.
+	ReplicaID string
+	Standby   bool
+
+	// Rounds are the latest rounds, newest first.
+	Rounds []RoundProgress
+	Peers  []PeerHealth
+
+	// EventsHead is the id of the Player's latest round event.
+	EventsHead int64
+	Consumers  []ConsumerProgress
+
+	// Errors are the most recent errors logged by the consumers, oldest
+	// first.
+	Errors []RecentError
+}
+
+// RoundProgress defines a round along with its status timeline and the parts
+// collected for it.
+type RoundProgress struct {
+	Round       player.Round
+	Transitions []player.RoundTransition
+	Parts       []player.Part
+}
+
+// PeerHealth defines the result of pinging a peer.
+type PeerHealth struct {
+	// Name of the peer, empty if it couldn't be reached.
+	Name    string
+	Healthy bool
+	Left    bool
+	Latency time.Duration
+	Error   string
+}
+
+// ConsumerProgress defines the position of a reflex consumer.
+type ConsumerProgress struct {
+	Name string
+	// Cursor is the consumer's persisted cursor.
+	Cursor string
+	// EventID is the id of the last event handled since the Player started.
+	EventID string
+	// EventTime is the timestamp of the last event handled.
+	EventTime time.Time
+	// HandledAt is when the last event was handled.
+	HandledAt time.Time
+}
+
+// Lag returns how far behind its stream the consumer was when it last
+// handled an event.
+func (cp ConsumerProgress) Lag() time.Duration {
+	if cp.EventTime.IsZero() {
+		return 0
+	}
+
+	return cp.HandledAt.Sub(cp.EventTime)
+}
+
+// RecentError defines an error logged by the consumers.
+type RecentError struct {
+	Message   string
+	Timestamp time.Time
+}
+
+// Status returns a snapshot of what the Player is doing.
+func (l *Loops) Status(ctx context.Context) (*Status, error) {
+	s := Status{
+		TeamName:   l.conf.TeamName,
+		PlayerName: l.conf.PlayerName,
+		Timestamp:  time.Now(),
+	}
+
+	l.mu.Lock()
+	s.MatchStartedAt = l.matchStartedAt
+	s.Coordinator = l.coordinator
+	for name, cp := range l.consumers {
+		cp.Name = name
+		s.Consumers = append(s.Consumers, cp)
+	}
+	s.Errors = append(s.Errors, l.errs...)
+	l.mu.Unlock()
+
+	sort.Slice(s.Consumers, func(i, j int) bool {
+		return s.Consumers[i].Name < s.Consumers[j].Name
+	})
+
+	for i, cp := range s.Consumers {
+		cursor, err := l.cursors.GetCursor(ctx, cp.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get cursor",
+				j.KV("consumer", cp.Name))
+		}
+		s.Consumers[i].Cursor = cursor
+	}
+
+	dbc := l.b.PlayerDB()
+
+	head, err := rounds.EventsHead(ctx, dbc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get events head")
+	}
+	s.EventsHead = head
+
+	rl, err := rounds.ListRecent(ctx, dbc, statusRounds)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list rounds")
+	}
+
+	for _, r := range rl {
+		tl, err := rounds.ListTransitions(ctx, dbc, r.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list transitions",
+				j.KV("round", r.ID))
+		}
+
+		pl, err := parts.ListByRound(ctx, dbc, r.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list parts",
+				j.KV("round", r.ID))
+		}
+
+		s.Rounds = append(s.Rounds, RoundProgress{
+			Round:       r,
+			Transitions: tl,
+			Parts:       pl,
+		})
+	}
+
+	s.Peers = l.pingPeers(ctx)
+
+	return &s, nil
+}
+
+// pingPeers checks the health of all peers concurrently.
+func (l *Loops) pingPeers(ctx context.Context) []PeerHealth {
+	// Peers' gRPC clients require a fate, which shouldn't fail the pings.
+	ctx = unsure.ContextWithFate(ctx, 0)
+
+	peers := l.b.Peers()
+	res := make([]PeerHealth, len(peers))
+
+	var wg sync.WaitGroup
+	for i, p := range peers {
+		wg.Add(1)
+		go func(i int, p player.Client) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, peerPingTimeout)
+			defer cancel()
+
+			t0 := time.Now()
+			name, err := p.GetName(ctx)
+			if err == nil {
+				err = p.Ping(ctx)
+			}
+
+			res[i] = PeerHealth{
+				Name:    name,
+				Healthy: err == nil,
+				Left:    name != "" && l.b.Departures().Has(name),
+				Latency: time.Since(t0),
+			}
+			if err != nil {
+				res[i].Error = err.Error()
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	return res
+}
@@ -0,0 +1,250 @@
+package ops
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/luno/fate"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/rounds"
+)
+
+// Strategy decides when the Player submits its parts for a round and what
+// total it submits.
+type Strategy interface {
+	// ReadyToSubmit returns whether the Player should submit now.
+	ReadyToSubmit(rs RoundState) bool
+
+	// Total returns the total the Player submits to the engine.
+	Total(rs RoundState) int64
+}
+
+// RoundState is what the Player knows about a collected round.
+type RoundState struct {
+	Round player.Round
+
+	// Own are the Player's parts and Rank its rank in the round.
+	Own  []player.Part
+	Rank int64
+
+	Peers []PeerState
+	Now   time.Time
+}
+
+// PeerState is what the Player knows about a peer in a round.
+type PeerState struct {
+	Name string
+
+	// Rank is the peer's rank, or zero if none of its parts are ranked.
+	Rank  int64
+	Parts []player.Part
+
+	// Submitted is true once the Player has received the peer's
+	// acknowledgement of its submission.
+	Submitted bool
+
+	// Departed is true if the peer has announced that it is leaving.
+	Departed bool
+}
+
+// Done returns true if the peer won't submit anything more for the round.
+func (p PeerState) Done() bool {
+	return p.Submitted || p.Departed
+}
+
+// StrategyFor returns the Strategy selected by the config, defaulting to the
+// all peers strategy.
+func StrategyFor(conf player.Config) Strategy {
+	switch conf.SubmitStrategy {
+	case player.StrategyRankOrdered:
+		return rankOrderedStrategy{}
+	case player.StrategyDeadline:
+		return deadlineStrategy{
+			next:     rankOrderedStrategy{},
+			deadline: conf.SubmitDeadline,
+		}
+	default:
+		return allPeersStrategy{}
+	}
+}
+
+// allPeersStrategy submits the sum of the Player's parts once every peer has
+// submitted or left.
+type allPeersStrategy struct{}
+
+func (allPeersStrategy) ReadyToSubmit(rs RoundState) bool {
+	for _, p := range rs.Peers {
+		if !p.Done() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (allPeersStrategy) Total(rs RoundState) int64 {
+	return sumUnsubmitted(rs.Own)
+}
+
+// rankOrderedStrategy submits the sum of the Player's parts once every
+// lower-ranked peer has submitted or left. Peers without a known rank are
+// treated as lower-ranked, since they may yet turn out to be.
+type rankOrderedStrategy struct{}
+
+func (rankOrderedStrategy) ReadyToSubmit(rs RoundState) bool {
+	for _, p := range rs.Peers {
+		if p.Done() {
+			continue
+		}
+
+		if p.Rank == 0 || rs.Rank == 0 || p.Rank < rs.Rank {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (rankOrderedStrategy) Total(rs RoundState) int64 {
+	return sumUnsubmitted(rs.Own)
+}
+
+// deadlineStrategy defers to the next strategy, but submits regardless once
+// the round has been collected for longer than the deadline.
+type deadlineStrategy struct {
+	next     Strategy
+	deadline time.Duration
+}
+
+func (s deadlineStrategy) ReadyToSubmit(rs RoundState) bool {
+	if rs.Now.Sub(rs.Round.UpdatedAt) >= s.deadline {
+		return true
+	}
+
+	return s.next.ReadyToSubmit(rs)
+}
+
+func (s deadlineStrategy) Total(rs RoundState) int64 {
+	return s.next.Total(rs)
+}
+
+func sumUnsubmitted(pl []player.Part) int64 {
+	var total int64
+	for _, p := range pl {
+		if !p.Submitted {
+			total += p.Value
+		}
+	}
+
+	return total
+}
+
+// loadRoundState returns what the Player knows about the round from the
+// parts it has collected from the engine and its peers.
+func loadRoundState(ctx context.Context, b Backends, conf player.Config,
+	r *player.Round) (RoundState, error) {
+	pl, err := parts.ListByRound(ctx, b.PlayerDB(), r.ID)
+	if err != nil {
+		return RoundState{}, errors.Wrap(err,
+			"failed to list parts for round", j.KV("round", r.ID))
+	}
+
+	rs := RoundState{
+		Round: *r,
+		Now:   time.Now(),
+	}
+
+	peers := make(map[string]int)
+	for _, p := range pl {
+		if strings.EqualFold(p.Player, conf.PlayerName) {
+			rs.Own = append(rs.Own, p)
+			if p.Rank != 0 {
+				rs.Rank = p.Rank
+			}
+			continue
+		}
+
+		i, ok := peers[p.Player]
+		if !ok {
+			i = len(rs.Peers)
+			peers[p.Player] = i
+			rs.Peers = append(rs.Peers, PeerState{
+				Name:      p.Player,
+				Submitted: true,
+				Departed:  b.Departures().Has(p.Player),
+			})
+		}
+
+		ps := &rs.Peers[i]
+		ps.Parts = append(ps.Parts, p)
+		ps.Submitted = ps.Submitted && p.Submitted
+		if p.Rank != 0 {
+			ps.Rank = p.Rank
+		}
+	}
+
+	return rs, nil
+}
+
+// deadlineCheckPeriod is how often collected rounds are checked against the
+// deadline strategy, since passing the deadline isn't an event.
+const deadlineCheckPeriod = time.Second
+
+// submitOnDeadlineForever periodically checks whether collected rounds are
+// ready to submit, until the consumers are stopped.
+func submitOnDeadlineForever(l *Loops, b Backends, conf player.Config) {
+	ctx := l.fatedContext()
+
+	for {
+		select {
+		case <-l.stopped:
+			return
+		case <-time.After(deadlineCheckPeriod):
+		}
+
+		err := checkDeadlines(ctx, l, b, conf)
+		if err != nil && !l.isStopped() && !errors.IsAny(err,
+			context.Canceled, errStopping) {
+			l.logError(ctx, errors.Wrap(err, "deadline check error"))
+		}
+	}
+}
+
+func checkDeadlines(ctx context.Context, l *Loops, b Backends,
+	conf player.Config) error {
+	l.mu.Lock()
+	if l.stopping {
+		l.mu.Unlock()
+		return errStopping
+	}
+	l.inflight.Add(1)
+	l.mu.Unlock()
+
+	defer l.inflight.Done()
+
+	rl, err := rounds.ListActive(ctx, b.PlayerDB())
+	if err != nil {
+		return errors.Wrap(err, "failed to list active rounds")
+	}
+
+	f := fate.New(fate.WithDefaultP(0), fate.WithoutOfficeHours())
+	reason := player.TimerReason("submit_deadline")
+
+	for _, r := range rl {
+		if r.Status != player.RoundStatusCollected {
+			continue
+		}
+
+		err := maybeReadyToSubmit(ctx, b, conf, f, reason, r.ID)
+		if err != nil && !errors.Is(err, fate.ErrTempt) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package ops
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"unsure/player"
+)
+
+func TestStrategySimulation(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []simPlayer
+		want    player.RoundStatus
+		order   []string
+	}{
+		{
+			name: "rank ordered",
+			players: []simPlayer{
+				{name: "alice", rank: 2, strategy: player.StrategyRankOrdered},
+				{name: "bob", rank: 1, strategy: player.StrategyRankOrdered},
+				{name: "carol", rank: 3, strategy: player.StrategyRankOrdered},
+			},
+			want:  player.RoundStatusSuccess,
+			order: []string{"bob", "alice", "carol"},
+		},
+		{
+			name: "rank ordered waits for unannounced departure",
+			players: []simPlayer{
+				{name: "alice", rank: 1, strategy: player.StrategyRankOrdered},
+				{name: "bob", rank: 2, strategy: player.StrategyRankOrdered},
+				{name: "carol", excluded: true},
+			},
+			want: player.RoundStatusSubmit,
+		},
+		{
+			name: "deadline submits after unannounced departure",
+			players: []simPlayer{
+				{name: "alice", rank: 1, strategy: player.StrategyDeadline},
+				{name: "bob", rank: 2, strategy: player.StrategyDeadline},
+				{name: "carol", excluded: true},
+			},
+			want:  player.RoundStatusSuccess,
+			order: []string{"alice", "bob"},
+		},
+		{
+			name: "deadline still submits in rank order",
+			players: []simPlayer{
+				{name: "alice", rank: 2, strategy: player.StrategyDeadline},
+				{name: "bob", rank: 1, strategy: player.StrategyDeadline},
+			},
+			want:  player.RoundStatusSuccess,
+			order: []string{"bob", "alice"},
+		},
+		{
+			name: "all peers after announced departure",
+			players: []simPlayer{
+				{name: "alice", rank: 1, strategy: player.StrategyAllPeers},
+				{name: "bob", excluded: true, announced: true},
+			},
+			want:  player.RoundStatusSuccess,
+			order: []string{"alice"},
+		},
+		{
+			name: "all peers last in rank order",
+			players: []simPlayer{
+				{name: "alice", rank: 1, strategy: player.StrategyRankOrdered},
+				{name: "bob", rank: 2, strategy: player.StrategyAllPeers},
+			},
+			want:  player.RoundStatusSuccess,
+			order: []string{"alice", "bob"},
+		},
+		{
+			name: "all peers waits for every peer",
+			players: []simPlayer{
+				{name: "alice", rank: 1, strategy: player.StrategyAllPeers},
+				{name: "bob", rank: 2, strategy: player.StrategyAllPeers},
+			},
+			want: player.RoundStatusSubmit,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, order := simulateRound(test.players)
+			if status != test.want {
+				t.Errorf("got %v, want %v", status, test.want)
+			}
+			if !equalNames(order, test.order) {
+				t.Errorf("got order %v, want %v", order, test.order)
+			}
+		})
+	}
+}
+
+const (
+	simStep     = time.Second
+	simSteps    = 60
+	simDeadline = 10 * time.Second
+)
+
+// simPlayer is a member of a simulated team.
+type simPlayer struct {
+	name     string
+	rank     int64
+	strategy string
+
+	// excluded players left before collecting, so the engine excludes them
+	// from the round. Announced departures are known to the other players.
+	excluded  bool
+	announced bool
+
+	submitted bool
+}
+
+// simulateRound plays a collected round through to its end, with each
+// player's strategy checked every simStep. Like the engine, the round fails
+// if a player submits out of rank order or submits the wrong total. It
+// returns the round's status, which remains submit if the players stop
+// making progress, and the order in which the players submitted.
+func simulateRound(players []simPlayer) (player.RoundStatus, []string) {
+	ps := append([]simPlayer(nil), players...)
+
+	var included []*simPlayer
+	for i := range ps {
+		if !ps[i].excluded {
+			included = append(included, &ps[i])
+		}
+	}
+	sort.Slice(included, func(i, j int) bool {
+		return included[i].rank < included[j].rank
+	})
+
+	start := time.Now()
+	collected := player.Round{Status: player.RoundStatusCollected,
+		UpdatedAt: start}
+
+	var order []string
+	for step := 0; step < simSteps; step++ {
+		// Players only learn of submissions at the next step.
+		var submitted []*simPlayer
+
+		for i := range ps {
+			p := &ps[i]
+			if p.excluded || p.submitted {
+				continue
+			}
+
+			s := StrategyFor(player.Config{
+				SubmitStrategy: p.strategy,
+				SubmitDeadline: simDeadline,
+			})
+
+			rs := simRoundState(collected, ps, *p,
+				start.Add(time.Duration(step)*simStep))
+			if !s.ReadyToSubmit(rs) {
+				continue
+			}
+
+			next := included[len(order)]
+			if p.name != next.name || s.Total(rs) != simTotal(ps, p.name) {
+				return player.RoundStatusFailed, order
+			}
+
+			order = append(order, p.name)
+			submitted = append(submitted, p)
+		}
+
+		for _, p := range submitted {
+			p.submitted = true
+		}
+
+		if len(order) == len(included) {
+			return player.RoundStatusSuccess, order
+		}
+	}
+
+	return player.RoundStatusSubmit, order
+}
+
+// simRoundState returns what the player knows about the round. Each
+// included player collects a part for every player, and the players share
+// their ranks once collected.
+func simRoundState(r player.Round, ps []simPlayer, p simPlayer,
+	now time.Time) RoundState {
+	rs := RoundState{
+		Round: r,
+		Rank:  p.rank,
+		Now:   now,
+	}
+
+	for _, q := range ps {
+		if !q.excluded {
+			rs.Own = append(rs.Own, player.Part{
+				Player: p.name,
+				Value:  simPart(q.name, p.name),
+			})
+		}
+
+		if q.name == p.name {
+			continue
+		}
+
+		rs.Peers = append(rs.Peers, PeerState{
+			Name:      q.name,
+			Rank:      q.rank,
+			Submitted: q.submitted,
+			Departed:  q.announced,
+		})
+	}
+
+	return rs
+}
+
+// simTotal returns the total the engine expects from the player.
+func simTotal(ps []simPlayer, name string) int64 {
+	var total int64
+	for _, q := range ps {
+		if !q.excluded {
+			total += simPart(q.name, name)
+		}
+	}
+
+	return total
+}
+
+// simPart returns the part the collector received for the player.
+func simPart(collector, name string) int64 {
+	return int64(len(collector)*10 + len(name))
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,167 @@
+package ops
+
+import (
+	"context"
+
+	"github.com/corverroos/unsure/engine"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/internal/db/rounds"
+	"unsure/player/internal/db/submissions"
+)
+
+// journalSubmission writes the intent to submit the total chosen by the
+// submission strategy for the round, once peers have cross-checked it. It
+// returns errTotalDisputed if a peer's disagreement blocks the submission.
+func journalSubmission(ctx context.Context, b Backends, conf player.Config,
+	r *player.Round) (*submissions.Submission, error) {
+	rs, err := loadRoundState(ctx, b, conf, r)
+	if err != nil {
+		return nil, err
+	}
+
+	total := StrategyFor(conf).Total(rs)
+
+	if err := verifyTotal(ctx, b, conf, rs, total); err != nil {
+		return nil, err
+	}
+
+	return submissions.Create(ctx, b.PlayerDB(), r.ID, r.ExternalID, total)
+}
+
+// completeSubmission submits the journaled total to the engine and shifts the
+// round to submitted. The engine rejects repeated submissions with
+// engine.ErrAlreadySubmitted, which confirms an earlier attempt reached it.
+func completeSubmission(ctx context.Context, b Backends, conf player.Config,
+	reason player.TransitionReason, r *player.Round,
+	s *submissions.Submission) error {
+	err := b.EngineClient().SubmitRound(ctx, conf.TeamName,
+		conf.PlayerName, r.ExternalID, int(s.Total))
+	if err != nil && !errors.Is(err, engine.ErrAlreadySubmitted) {
+		// Leave the submission pending to be retried.
+		if err := submissions.RecordAttempt(ctx, b.PlayerDB(), s.ID,
+			err); err != nil {
+			return errors.Wrap(err, "failed to record submission attempt")
+		}
+
+		return errors.Wrap(err, "failed to submit parts")
+	}
+
+	return markSubmitted(ctx, b, conf, reason, r, s)
+}
+
+// markSubmitted completes a submission which reached the engine, shifting
+// the round to submitted if it is still waiting to submit.
+func markSubmitted(ctx context.Context, b Backends, conf player.Config,
+	reason player.TransitionReason, r *player.Round,
+	s *submissions.Submission) error {
+	if r.Status != player.RoundStatusSubmit {
+		return submissions.Complete(ctx, b.PlayerDB(), s.ID)
+	}
+
+	// Sign our submission acknowledgement for our peers.
+	sig, err := b.Keyring().SignSubmitted(r.ExternalID, conf.PlayerName)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign submission",
+			j.KV("round", r.ID))
+	}
+
+	// Shift round to submitted, completing the submission.
+	err = rounds.ShiftToSubmitted(ctx, b.PlayerDB(), r.ID,
+		conf.PlayerName, sig, s.ID, reason, player.RoundStatusSubmit)
+	if err != nil {
+		return errors.Wrap(err, "failed to shift to submitted",
+			j.KV("round", r.ID))
+	}
+
+	return nil
+}
+
+// RecoverSubmissions reconciles the submissions left pending when the Player
+// last stopped, before its consumers start. Whether a pending submission
+// reached the engine can only be told from the engine, so each round is
+// looked up there first. Submissions of rounds the engine completed are
+// marked as submitted and those of rounds it failed are abandoned. The rest
+// are resubmitted with their journaled total if their round is still waiting
+// to submit, relying on the engine to reject a repeated submission.
+func RecoverSubmissions(ctx context.Context, b Backends,
+	conf player.Config) error {
+	sl, err := submissions.ListPending(ctx, b.PlayerDB())
+	if err != nil {
+		return errors.Wrap(err, "failed to list pending submissions")
+	} else if len(sl) == 0 {
+		return nil
+	}
+
+	pending := make(map[int64]bool)
+	for _, s := range sl {
+		pending[s.ExternalID] = true
+	}
+
+	engineStatuses, err := readEngineStatuses(ctx, b, pending)
+	if err != nil {
+		return errors.Wrap(err, "failed to read engine events")
+	}
+
+	summary := make(map[string]int)
+	for _, s := range sl {
+		s := s
+		r, err := rounds.Lookup(ctx, b.PlayerDB(), s.RoundID)
+		if err != nil {
+			return errors.Wrap(err, "failed to lookup round",
+				j.KV("round", s.RoundID))
+		}
+
+		outcome, err := recoverSubmission(ctx, b, conf, r, &s,
+			engineStatuses[s.ExternalID])
+		if err != nil {
+			// The local consumer retries submissions which are still
+			// pending.
+			log.Error(ctx, errors.Wrap(err, "failed to recover submission",
+				j.KV("round", r.ID)))
+			outcome = "failed"
+		}
+		summary[outcome]++
+	}
+
+	log.Info(ctx, "Recovered pending submissions", j.MKV{
+		"resubmitted": summary["resubmitted"],
+		"completed":   summary["completed"],
+		"abandoned":   summary["abandoned"],
+		"failed":      summary["failed"],
+	})
+
+	return nil
+}
+
+// recoverSubmission resolves a pending submission given the engine's status
+// for its round, returning whether it was "resubmitted", "completed" or
+// "abandoned".
+func recoverSubmission(ctx context.Context, b Backends, conf player.Config,
+	r *player.Round, s *submissions.Submission,
+	engineStatus player.RoundStatus) (string, error) {
+	reason := player.RecoveryReason("submission")
+
+	switch {
+	case engineStatus == player.RoundStatusSuccess:
+		// The engine only succeeds rounds once every player submitted.
+		return "completed", markSubmitted(ctx, b, conf, reason, r, s)
+
+	case engineStatus == player.RoundStatusFailed:
+		// The total can no longer be submitted.
+		return "abandoned", submissions.Abandon(ctx, b.PlayerDB(), s.ID)
+
+	case r.Status == player.RoundStatusSubmit:
+		return "resubmitted", completeSubmission(ctx, b, conf, reason, r, s)
+
+	case r.Status == player.RoundStatusSubmitted,
+		r.Status == player.RoundStatusSuccess:
+		return "completed", submissions.Complete(ctx, b.PlayerDB(), s.ID)
+
+	default:
+		return "abandoned", submissions.Abandon(ctx, b.PlayerDB(), s.ID)
+	}
+}
@@ -0,0 +1,329 @@
+package ops
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/corverroos/unsure"
+	"github.com/corverroos/unsure/engine"
+	"github.com/luno/fate"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/internal/db"
+	"unsure/player/internal/db/rounds"
+	"unsure/player/internal/db/submissions"
+	"unsure/player/internal/signing"
+)
+
+const testExternalID = 42
+
+var errCrash = errors.New("crashed")
+
+func TestRecoverSubmissions(t *testing.T) {
+	tests := []struct {
+		name string
+		// crashAfterSubmit crashes once the engine accepted the
+		// submission, rather than once the intent was journaled.
+		crashAfterSubmit bool
+		engineStatus     player.RoundStatus
+
+		wantRound      player.RoundStatus
+		wantSubmission submissions.Status
+		wantCalls      int
+	}{
+		{
+			name:           "crash after journal",
+			engineStatus:   player.RoundStatusSubmit,
+			wantRound:      player.RoundStatusSubmitted,
+			wantSubmission: submissions.StatusSubmitted,
+			wantCalls:      1,
+		},
+		{
+			name:             "crash after submit",
+			crashAfterSubmit: true,
+			engineStatus:     player.RoundStatusSubmitted,
+			wantRound:        player.RoundStatusSubmitted,
+			wantSubmission:   submissions.StatusSubmitted,
+			wantCalls:        2,
+		},
+		{
+			name:             "crash after submit round succeeded",
+			crashAfterSubmit: true,
+			engineStatus:     player.RoundStatusSuccess,
+			wantRound:        player.RoundStatusSubmitted,
+			wantSubmission:   submissions.StatusSubmitted,
+			wantCalls:        1,
+		},
+		{
+			name:           "crash after journal round failed",
+			engineStatus:   player.RoundStatusFailed,
+			wantRound:      player.RoundStatusSubmit,
+			wantSubmission: submissions.StatusAbandoned,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer unsure.CheatFateForTesting(t)()
+
+			b := newTestBackends(t)
+			defer b.dbc.Close()
+
+			ctx := context.Background()
+			conf := player.Config{
+				TeamName:     "team",
+				PlayerName:   "alice",
+				VerifyTotals: player.VerifyTotalsOff,
+			}
+
+			id := createSubmitRound(t, b.dbc)
+
+			r, err := rounds.Lookup(ctx, b.dbc, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s, err := journalSubmission(ctx, b, conf, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.crashAfterSubmit {
+				b.engine.crash = true
+				err = completeSubmission(ctx, b, conf,
+					player.LocalEventReason("1"), r, s)
+				if !errors.Is(err, errCrash) {
+					t.Fatalf("got %v, want %v", err, errCrash)
+				}
+				b.engine.crash = false
+			}
+
+			// Restart, with the engine having moved the round on.
+			b.engine.addEvent(testExternalID, test.engineStatus)
+
+			err = RecoverSubmissions(ctx, b, conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err = rounds.Lookup(ctx, b.dbc, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Status != test.wantRound {
+				t.Errorf("got round %v, want %v", r.Status, test.wantRound)
+			}
+
+			s, err = submissions.LookupByRound(ctx, b.dbc, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Status != test.wantSubmission {
+				t.Errorf("got submission %v, want %v", s.Status,
+					test.wantSubmission)
+			}
+
+			if b.engine.calls != test.wantCalls {
+				t.Errorf("got %d engine calls, want %d", b.engine.calls,
+					test.wantCalls)
+			}
+			if b.engine.submitted > 1 {
+				t.Errorf("submitted %d times", b.engine.submitted)
+			}
+		})
+	}
+}
+
+func TestSubmitDisputedTotal(t *testing.T) {
+	defer unsure.CheatFateForTesting(t)()
+
+	b := newTestBackends(t)
+	defer b.dbc.Close()
+	b.peers = []player.Client{disputingPeer{}}
+
+	ctx := context.Background()
+	conf := player.Config{
+		TeamName:     "team",
+		PlayerName:   "alice",
+		VerifyTotals: player.VerifyTotalsBlock,
+	}
+
+	id := createSubmitRound(t, b.dbc)
+
+	f := fate.New(fate.WithDefaultP(0), fate.WithoutOfficeHours())
+	err := submitParts(ctx, b, conf, f, player.LocalEventReason("1"), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := rounds.Lookup(ctx, b.dbc, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Status != player.RoundStatusFailed {
+		t.Errorf("got round %v, want %v", r.Status, player.RoundStatusFailed)
+	}
+
+	if b.engine.calls != 0 {
+		t.Errorf("got %d engine calls, want none", b.engine.calls)
+	}
+}
+
+// createSubmitRound creates a round waiting for the Player to submit.
+func createSubmitRound(t *testing.T, dbc *sql.DB) int64 {
+	ctx := context.Background()
+	reason := player.LocalEventReason("test")
+
+	id, err := rounds.Create(ctx, dbc, testExternalID, 0, reason)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rounds.ShiftToJoined(ctx, dbc, id, "alice", reason,
+		player.RoundStatusJoin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shifts := []func(context.Context, *sql.DB, int64,
+		player.TransitionReason, ...player.RoundStatus) error{
+		rounds.ShiftToCollect, rounds.ShiftToCollected, rounds.ShiftToSubmit,
+	}
+	from := player.RoundStatusJoined
+	for _, shift := range shifts {
+		if err := shift(ctx, dbc, id, reason, from); err != nil {
+			t.Fatal(err)
+		}
+		from++
+	}
+
+	return id
+}
+
+type testBackends struct {
+	dbc        *sql.DB
+	engine     *testEngine
+	peers      []player.Client
+	departures Departures
+	standby    Standby
+}
+
+func newTestBackends(t *testing.T) *testBackends {
+	return &testBackends{
+		dbc:    db.ConnectForTesting(t),
+		engine: new(testEngine),
+	}
+}
+
+func (b *testBackends) PlayerDB() *sql.DB           { return b.dbc }
+func (b *testBackends) EngineClient() engine.Client { return b.engine }
+func (b *testBackends) Peers() []player.Client      { return b.peers }
+func (b *testBackends) Keyring() *signing.Keyring   { return nil }
+func (b *testBackends) Departures() *Departures     { return &b.departures }
+func (b *testBackends) Standby() *Standby           { return &b.standby }
+
+// testEngine is an Unsure Engine which accepts a single submission and
+// streams the round events added to it.
+type testEngine struct {
+	engine.Client
+
+	mu     sync.Mutex
+	events []*reflex.Event
+
+	// crash fails calls to SubmitRound after they are accepted.
+	crash     bool
+	calls     int
+	submitted int
+}
+
+func (e *testEngine) addEvent(externalID int64, st player.RoundStatus) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var typ engine.EventType
+	for et, s := range engineStatuses {
+		if s == st {
+			typ = et
+		}
+	}
+
+	e.events = append(e.events, &reflex.Event{
+		ID:        strconv.Itoa(len(e.events) + 1),
+		Type:      typ,
+		ForeignID: strconv.FormatInt(externalID, 10),
+	})
+}
+
+func (e *testEngine) SubmitRound(ctx context.Context, team, player string,
+	roundID int64, total int) error {
+	e.calls++
+	if e.submitted > 0 {
+		return engine.ErrAlreadySubmitted
+	}
+	e.submitted++
+
+	if e.crash {
+		return errCrash
+	}
+
+	return nil
+}
+
+func (e *testEngine) Stream(ctx context.Context, after string,
+	opts ...reflex.StreamOption) (reflex.StreamClient, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var afterID int64
+	if after != "" {
+		afterID, _ = strconv.ParseInt(after, 10, 64)
+	}
+
+	var events []*reflex.Event
+	for _, ev := range e.events {
+		if ev.IDInt() > afterID {
+			events = append(events, ev)
+		}
+	}
+
+	return &testStream{ctx: ctx, events: events}, nil
+}
+
+// disputingPeer is a peer which collected different parts for the Player.
+type disputingPeer struct {
+	player.Client
+}
+
+func (disputingPeer) CheckTotal(ctx context.Context,
+	claim player.TotalClaim) (*player.TotalCheck, error) {
+	return &player.TotalCheck{
+		Peer:  "bob",
+		Known: true,
+		Total: claim.Total + 1,
+		Parts: []int64{claim.Total + 1},
+	}, nil
+}
+
+// testStream streams its events, then blocks like the engine's stream until
+// the context is cancelled.
+type testStream struct {
+	ctx    context.Context
+	events []*reflex.Event
+}
+
+func (s *testStream) Recv() (*reflex.Event, error) {
+	if len(s.events) == 0 {
+		<-s.ctx.Done()
+		return nil, s.ctx.Err()
+	}
+
+	e := s.events[0]
+	s.events = s.events[1:]
+
+	return e, nil
+}
@@ -0,0 +1,157 @@
+package ops
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/internal/db/divergences"
+	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/rounds"
+)
+
+// errTotalDisputed is returned when a peer disagrees with the total the
+// Player is about to submit and disagreements block submission.
+var errTotalDisputed = errors.New("total disputed by peer",
+	j.C("ERR_7d1c94e0b2a6f583"))
+
+// CheckTotal compares a peer's claimed total and parts for a round against
+// the parts the Player collected from that peer. The check isn't known until
+// the peer's parts have been collected. It returns player.ErrRoundNotFound if
+// the round doesn't exist, or player.ErrStandby if the Player is a replica
+// standing by, since disagreements are recorded by the replica leading.
+func CheckTotal(ctx context.Context, b Backends, conf player.Config,
+	claim player.TotalClaim) (*player.TotalCheck, error) {
+	if err := checkLeading(b); err != nil {
+		return nil, err
+	}
+
+	if claim.ExternalID <= 0 || claim.Player == "" {
+		return nil, errors.Wrap(player.ErrInvalidArgument,
+			"external_id and player required")
+	}
+
+	r, err := rounds.LookupByExternalID(ctx, b.PlayerDB(), claim.ExternalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(player.ErrRoundNotFound,
+			"failed to lookup round",
+			j.KV("external_id", claim.ExternalID))
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup round",
+			j.KV("external_id", claim.ExternalID))
+	}
+
+	pl, err := parts.ListByRoundAndPlayer(ctx, b.PlayerDB(), r.ID,
+		claim.Player)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list parts",
+			j.KV("round", r.ID))
+	}
+
+	res := player.TotalCheck{Peer: conf.PlayerName}
+	for _, p := range pl {
+		// Only ranked parts were collected from the peer itself, the
+		// others are what the engine dealt us for it.
+		if p.Rank == 0 {
+			continue
+		}
+
+		res.Known = true
+		res.Total += p.Value
+		res.Parts = append(res.Parts, p.Value)
+	}
+
+	res.Agreed = res.Known && res.Total == claim.Total &&
+		sameParts(res.Parts, claim.Parts)
+
+	return &res, nil
+}
+
+// verifyTotal publishes the total and parts the Player is about to submit to
+// its peers, which compare them against the parts they collected from it.
+// Disagreements are recorded and logged. It returns errTotalDisputed if a
+// peer disagrees and conf.VerifyTotals blocks submission. Peers which can't
+// be reached or haven't collected our parts yet don't block submission.
+func verifyTotal(ctx context.Context, b Backends, conf player.Config,
+	rs RoundState, total int64) error {
+	if conf.VerifyTotals == player.VerifyTotalsOff {
+		return nil
+	}
+
+	claim := player.TotalClaim{
+		ExternalID: rs.Round.ExternalID,
+		Player:     conf.PlayerName,
+		Total:      total,
+	}
+	for _, p := range rs.Own {
+		if !p.Submitted {
+			claim.Parts = append(claim.Parts, p.Value)
+		}
+	}
+
+	var disputed bool
+	for _, p := range b.Peers() {
+		check, err := p.CheckTotal(ctx, claim)
+		if err != nil {
+			log.Error(ctx, errors.Wrap(err, "failed to check total with peer",
+				j.KV("external_id", claim.ExternalID)))
+			continue
+		}
+
+		if !check.Known || check.Agreed {
+			continue
+		}
+
+		disputed = true
+
+		err = divergences.Create(ctx, b.PlayerDB(), divergences.Divergence{
+			RoundID:    rs.Round.ID,
+			ExternalID: claim.ExternalID,
+			Peer:       check.Peer,
+			Total:      claim.Total,
+			Parts:      claim.Parts,
+			PeerTotal:  check.Total,
+			PeerParts:  check.Parts,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to record divergence",
+				j.KV("round", rs.Round.ID))
+		}
+
+		log.Error(ctx, errors.Wrap(errTotalDisputed, "peer disagrees",
+			j.MKV{"external_id": claim.ExternalID, "peer": check.Peer,
+				"total": claim.Total, "peer_total": check.Total}))
+	}
+
+	if disputed && conf.VerifyTotals == player.VerifyTotalsBlock {
+		return errors.Wrap(errTotalDisputed, "submission blocked",
+			j.KV("external_id", claim.ExternalID))
+	}
+
+	return nil
+}
+
+// sameParts returns whether the part values are equal, ignoring order.
+func sameParts(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a = append([]int64(nil), a...)
+	b = append([]int64(nil), b...)
+	sort.Slice(a, func(i, j int) bool { return a[i] < a[j] })
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"os"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/client"
+	"unsure/player/consistency"
+	"unsure/player/internal/db"
+)
+
+// runCheck compares the parts of the Player's recent completed rounds with
+// its peers' copies and prints the differences. It exits with status 1 if
+// any are found.
+func runCheck(args []string) {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	limit := fs.Int("rounds", 100, "number of recent rounds to check")
+
+	conf, err := player.LoadConfig(fs, args)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load player config"))
+	}
+
+	dbc, err := db.Connect(conf.PlayerDB)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to connect to player db"))
+	}
+
+	var cl []player.Client
+	for _, address := range conf.PeerAddresses() {
+		c, err := client.Make(address, conf.TLS)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to create peer client",
+				j.KV("address", address)))
+		}
+		cl = append(cl, c)
+	}
+
+	ctx := context.Background()
+
+	peers, errs := consistency.ResolvePeers(ctx, cl)
+
+	report, err := consistency.Check(ctx, dbc, conf.PlayerName, peers,
+		*limit)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to check consistency"))
+	}
+	report.Errors = append(errs, report.Errors...)
+
+	w := bufio.NewWriter(os.Stdout)
+	if err := consistency.Write(w, report); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to write report"))
+	}
+
+	if err := w.Flush(); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to write report"))
+	}
+
+	if len(report.Diffs) > 0 {
+		os.Exit(1)
+	}
+}
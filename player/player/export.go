@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"os"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/export"
+	"unsure/player/internal/db"
+)
+
+// runExport writes the Player's round results or summary, read from
+// its DB, to stdout as CSV or JSON.
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", string(export.FormatCSV),
+		"encoding of the export: csv or json")
+	kind := fs.String("kind", string(export.KindRounds),
+		"contents of the export: rounds or summary")
+
+	conf, err := player.LoadConfig(fs, args)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load player config"))
+	}
+
+	err = export.Validate(export.Format(*format), export.Kind(*kind))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dbc, err := db.Connect(conf.PlayerDB)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to connect to player db"))
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+
+	err = export.Write(context.Background(), w, dbc, *conf,
+		export.Format(*format), export.Kind(*kind))
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to export"))
+	}
+
+	if err := w.Flush(); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to write export"))
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/internal/db"
+	"unsure/player/internal/db/rounds"
+)
+
+// activeStatuses are the statuses an operator may fail a round from.
+var activeStatuses = []player.RoundStatus{
+	player.RoundStatusJoin,
+	player.RoundStatusJoined,
+	player.RoundStatusCollect,
+	player.RoundStatusCollected,
+	player.RoundStatusSubmit,
+	player.RoundStatusSubmitted,
+}
+
+// runFail shifts a stuck round to failed on the operator's behalf, recording
+// the given note as the reason in the round's transitions.
+func runFail(args []string) {
+	fs := flag.NewFlagSet("fail", flag.ExitOnError)
+	externalID := fs.Int64("round", 0, "engine id of the round to fail")
+	note := fs.String("note", "", "why the round is being failed")
+
+	conf, err := player.LoadConfig(fs, args)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load player config"))
+	}
+
+	if *externalID == 0 || *note == "" {
+		log.Fatal(errors.New("round and note are required"))
+	}
+
+	dbc, err := db.Connect(conf.PlayerDB)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to connect to player db"))
+	}
+
+	ctx := context.Background()
+
+	r, err := rounds.LookupByExternalID(ctx, dbc, *externalID)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to lookup round",
+			j.KV("round", *externalID)))
+	}
+
+	reason := player.AdminReason("fail").WithError(errors.New(*note))
+
+	err = rounds.ShiftToFailed(ctx, dbc, r.ID, reason, activeStatuses...)
+	if errors.Is(err, player.ErrRoundConflict) {
+		log.Fatal(errors.Wrap(err, "round already completed",
+			j.MKV{"round": *externalID, "status": r.Status}))
+	} else if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to fail round",
+			j.KV("round", *externalID)))
+	}
+
+	log.Info(ctx, "Failed round", j.MKV{"round": *externalID,
+		"from": r.Status})
+}
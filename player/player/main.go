@@ -1,46 +1,199 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"net/http"
+	"os"
+	"time"
+
 	"github.com/corverroos/unsure"
 	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
 	"github.com/luno/jettison/log"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"unsure/player"
+	"unsure/player/gateway"
+	"unsure/player/internal/grpctls"
 	"unsure/player/ops"
 	"unsure/player/playerpb"
+	playerpbv2 "unsure/player/playerpb/v2"
 
 	"unsure/player/server"
 	"unsure/player/state"
+	"unsure/player/status"
 )
 
-var grpcAddress = flag.String("grpc_address", "", "player grpc address")
+// httpShutdownTimeout bounds waiting for in-flight HTTP requests once the
+// Player has stopped.
+const httpShutdownTimeout = time.Second
 
 func main() {
-	flag.Parse()
+	// Run a whole team in this process if invoked as "player team".
+	if len(os.Args) > 1 && os.Args[1] == "team" {
+		runTeam(os.Args[2:])
+		return
+	}
+
+	// Replay the Player's rounds if invoked as "player replay".
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		runReplay(os.Args[2:])
+		return
+	}
+
+	// Export the Player's results if invoked as "player export".
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		runExport(os.Args[2:])
+		return
+	}
 
-	s, err := state.New()
+	// Compare parts with peers' copies if invoked as "player check".
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		runCheck(os.Args[2:])
+		return
+	}
+
+	// Fail a stuck round if invoked as "player fail".
+	if len(os.Args) > 1 && os.Args[1] == "fail" {
+		runFail(os.Args[2:])
+		return
+	}
+
+	conf, err := player.LoadConfig(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load player config"))
+	}
+
+	s, err := state.New(*conf)
 	if err != nil {
 		log.Fatal(errors.Wrap(err, "failed to create player state"))
 	}
 
-	go serveGRPCForever(s)
-	ops.StartLoops(s)
+	startPlayer(s, *conf)
 
 	unsure.WaitForShutdown()
 }
 
-func serveGRPCForever(s *state.State) {
-	grpcServer, err := unsure.NewServer(*grpcAddress)
+// runTeam runs all the Players configured in the team config file. The
+// Players are connected to each other in-process, but each is still served on
+// its own gRPC address for external inspection.
+func runTeam(args []string) {
+	tc, err := player.LoadTeamConfig(flag.CommandLine, args)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load team config"))
+	}
+
+	states, err := state.NewTeam(tc.Players)
 	if err != nil {
-		unsure.Fatal(errors.Wrap(err, "new grpctls server"))
+		log.Fatal(errors.Wrap(err, "failed to create team state"))
+	}
+
+	for i, s := range states {
+		startPlayer(s, tc.Players[i])
 	}
 
-	playerSrv := server.New(s)
+	unsure.WaitForShutdown()
+}
+
+// startPlayer serves the Player's gRPC API and starts its consumers, along
+// with its HTTP gateway and status page if configured. A replica of the
+// Player serves its API but only starts the consumers once it takes the
+// Player's lease. On shutdown the Player reports NOT_SERVING, drains its
+// in-flight round work and notifies its peers, or hands over to a standby
+// replica, before it stops serving.
+func startPlayer(s *state.State, conf player.Config) {
+	grpcServer, err := grpctls.NewServer(conf.GRPCAddress, conf.TLS,
+		conf.PeerNames())
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "new grpctls server"))
+	}
+
+	hs := server.NewHealth(s)
+	playerSrv := server.New(s, conf)
 	playerpb.RegisterPlayerServer(grpcServer.GRPCServer(), playerSrv)
+	playerpbv2.RegisterPlayerServer(grpcServer.GRPCServer(),
+		server.NewV2(playerSrv))
+	healthpb.RegisterHealthServer(grpcServer.GRPCServer(), hs)
+
+	if conf.GRPCReflection {
+		reflection.Register(grpcServer.GRPCServer())
+	}
+
+	go func() {
+		unsure.Fatal(grpcServer.ServeForever())
+	}()
+
+	// Replicas of the Player only run its loops while holding its lease.
+	var (
+		src  status.Source
+		stop func(context.Context) error
+	)
+	if conf.ReplicaID != "" {
+		r := ops.StartReplica(s, conf)
+		src, stop = r, r.Stop
+	} else {
+		l, err := ops.Start(unsure.FatedContext(), s, conf)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to start loops"))
+		}
+		src, stop = l, l.Stop
+	}
+
+	var httpSrv *http.Server
+	if conf.HTTPAddress != "" {
+		st := status.New(src)
+		mux := http.NewServeMux()
+		mux.Handle("/", gateway.New(s, conf))
+		mux.Handle("/status", st)
+		mux.Handle("/status.json", st)
+
+		httpSrv = &http.Server{Addr: conf.HTTPAddress, Handler: mux}
+		go serveHTTP(httpSrv)
+	}
+
+	hs.SetStarted()
+	go hs.CheckForever()
+
+	unsure.RegisterShutdown(func() error {
+		hs.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(),
+			conf.ShutdownTimeout)
+		defer cancel()
+
+		err := stop(ctx)
 
-	unsure.RegisterNoErr(func() {
 		playerSrv.Stop()
 		grpcServer.Stop()
+
+		if httpSrv != nil {
+			stopHTTP(httpSrv)
+		}
+
+		return err
 	})
+}
+
+// serveHTTP serves the gateway and status page until the server is stopped.
+func serveHTTP(srv *http.Server) {
+	log.Info(nil, "Listening for HTTP requests", j.KV("address", srv.Addr))
 
-	unsure.Fatal(grpcServer.ServeForever())
+	err := srv.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		unsure.Fatal(err)
+	}
+}
+
+// stopHTTP waits for in-flight HTTP requests to finish until the timeout,
+// then closes any connections left, such as event streams.
+func stopHTTP(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(),
+		httpShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Error(ctx, errors.Wrap(err, "failed to stop http server"))
+		_ = srv.Close()
+	}
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+
+	"unsure/player"
+	"unsure/player/client"
+	"unsure/player/internal/db"
+	"unsure/player/replay"
+)
+
+// runReplay prints the timelines of the Player's rounds reconstructed from
+// its DB, and optionally its peers' round events, along with any anomalies.
+func runReplay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	externalID := fs.Int64("external_id", 0,
+		"only replay the round with this engine id (all rounds if zero)")
+	withPeers := fs.Bool("with_peers", false,
+		"merge the round events streamed from the configured peers")
+	peerIdle := fs.Duration("peer_idle", 2*time.Second,
+		"stop streaming a peer's events once idle for this long")
+
+	conf, err := player.LoadConfig(fs, args)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to load player config"))
+	}
+
+	dbc, err := db.Connect(conf.PlayerDB)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to connect to player db"))
+	}
+
+	ctx := context.Background()
+
+	var peers []replay.PeerEvents
+	if *withPeers {
+		for _, address := range conf.PeerAddresses() {
+			c, err := client.Make(address, conf.TLS)
+			if err != nil {
+				log.Fatal(errors.Wrap(err, "failed to create peer client",
+					j.KV("address", address)))
+			}
+
+			pe, err := replay.ReadPeer(ctx, c, *peerIdle)
+			if err != nil {
+				log.Fatal(errors.Wrap(err, "failed to read peer events",
+					j.KV("address", address)))
+			}
+			peers = append(peers, *pe)
+		}
+	}
+
+	report, err := replay.Build(ctx, dbc, conf.PlayerName, *externalID, peers)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to build replay"))
+	}
+
+	if err := report.Write(os.Stdout); err != nil {
+		log.Fatal(errors.Wrap(err, "failed to write replay"))
+	}
+}
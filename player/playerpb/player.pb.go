@@ -35,7 +35,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{0}
+	return fileDescriptor_player_65354325f2191bba, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -66,7 +66,7 @@ func (m *GetNameResp) Reset()         { *m = GetNameResp{} }
 func (m *GetNameResp) String() string { return proto.CompactTextString(m) }
 func (*GetNameResp) ProtoMessage()    {}
 func (*GetNameResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{1}
+	return fileDescriptor_player_65354325f2191bba, []int{1}
 }
 func (m *GetNameResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNameResp.Unmarshal(m, b)
@@ -93,6 +93,114 @@ func (m *GetNameResp) GetName() string {
 	return ""
 }
 
+type HelloResp struct {
+	PlayerName           string   `protobuf:"bytes,1,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	TeamName             string   `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	BuildVersion         string   `protobuf:"bytes,3,opt,name=build_version,json=buildVersion,proto3" json:"build_version,omitempty"`
+	ProtocolVersion      int32    `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities         []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HelloResp) Reset()         { *m = HelloResp{} }
+func (m *HelloResp) String() string { return proto.CompactTextString(m) }
+func (*HelloResp) ProtoMessage()    {}
+func (*HelloResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{2}
+}
+func (m *HelloResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HelloResp.Unmarshal(m, b)
+}
+func (m *HelloResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HelloResp.Marshal(b, m, deterministic)
+}
+func (dst *HelloResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HelloResp.Merge(dst, src)
+}
+func (m *HelloResp) XXX_Size() int {
+	return xxx_messageInfo_HelloResp.Size(m)
+}
+func (m *HelloResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_HelloResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HelloResp proto.InternalMessageInfo
+
+func (m *HelloResp) GetPlayerName() string {
+	if m != nil {
+		return m.PlayerName
+	}
+	return ""
+}
+
+func (m *HelloResp) GetTeamName() string {
+	if m != nil {
+		return m.TeamName
+	}
+	return ""
+}
+
+func (m *HelloResp) GetBuildVersion() string {
+	if m != nil {
+		return m.BuildVersion
+	}
+	return ""
+}
+
+func (m *HelloResp) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *HelloResp) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type LeaveReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaveReq) Reset()         { *m = LeaveReq{} }
+func (m *LeaveReq) String() string { return proto.CompactTextString(m) }
+func (*LeaveReq) ProtoMessage()    {}
+func (*LeaveReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{3}
+}
+func (m *LeaveReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaveReq.Unmarshal(m, b)
+}
+func (m *LeaveReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaveReq.Marshal(b, m, deterministic)
+}
+func (dst *LeaveReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveReq.Merge(dst, src)
+}
+func (m *LeaveReq) XXX_Size() int {
+	return xxx_messageInfo_LeaveReq.Size(m)
+}
+func (m *LeaveReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveReq proto.InternalMessageInfo
+
+func (m *LeaveReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type GetPartsReq struct {
 	ExternalId           int64    `protobuf:"varint,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -104,7 +212,7 @@ func (m *GetPartsReq) Reset()         { *m = GetPartsReq{} }
 func (m *GetPartsReq) String() string { return proto.CompactTextString(m) }
 func (*GetPartsReq) ProtoMessage()    {}
 func (*GetPartsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{2}
+	return fileDescriptor_player_65354325f2191bba, []int{4}
 }
 func (m *GetPartsReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPartsReq.Unmarshal(m, b)
@@ -142,7 +250,7 @@ func (m *GetPartsResp) Reset()         { *m = GetPartsResp{} }
 func (m *GetPartsResp) String() string { return proto.CompactTextString(m) }
 func (*GetPartsResp) ProtoMessage()    {}
 func (*GetPartsResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{3}
+	return fileDescriptor_player_65354325f2191bba, []int{5}
 }
 func (m *GetPartsResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPartsResp.Unmarshal(m, b)
@@ -180,7 +288,7 @@ func (m *GetRoundReq) Reset()         { *m = GetRoundReq{} }
 func (m *GetRoundReq) String() string { return proto.CompactTextString(m) }
 func (*GetRoundReq) ProtoMessage()    {}
 func (*GetRoundReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{4}
+	return fileDescriptor_player_65354325f2191bba, []int{6}
 }
 func (m *GetRoundReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRoundReq.Unmarshal(m, b)
@@ -218,7 +326,7 @@ func (m *GetRoundResp) Reset()         { *m = GetRoundResp{} }
 func (m *GetRoundResp) String() string { return proto.CompactTextString(m) }
 func (*GetRoundResp) ProtoMessage()    {}
 func (*GetRoundResp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{5}
+	return fileDescriptor_player_65354325f2191bba, []int{7}
 }
 func (m *GetRoundResp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRoundResp.Unmarshal(m, b)
@@ -245,174 +353,972 @@ func (m *GetRoundResp) GetRound() *Round {
 	return nil
 }
 
-type Round struct {
-	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ExternalId           int64                `protobuf:"varint,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
-	Player               string               `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
-	Status               int32                `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type GetRoundAuditReq struct {
+	RoundId              int64    `protobuf:"varint,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Round) Reset()         { *m = Round{} }
-func (m *Round) String() string { return proto.CompactTextString(m) }
-func (*Round) ProtoMessage()    {}
-func (*Round) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{6}
+func (m *GetRoundAuditReq) Reset()         { *m = GetRoundAuditReq{} }
+func (m *GetRoundAuditReq) String() string { return proto.CompactTextString(m) }
+func (*GetRoundAuditReq) ProtoMessage()    {}
+func (*GetRoundAuditReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{8}
 }
-func (m *Round) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Round.Unmarshal(m, b)
+func (m *GetRoundAuditReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoundAuditReq.Unmarshal(m, b)
 }
-func (m *Round) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Round.Marshal(b, m, deterministic)
+func (m *GetRoundAuditReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoundAuditReq.Marshal(b, m, deterministic)
 }
-func (dst *Round) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Round.Merge(dst, src)
+func (dst *GetRoundAuditReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoundAuditReq.Merge(dst, src)
 }
-func (m *Round) XXX_Size() int {
-	return xxx_messageInfo_Round.Size(m)
+func (m *GetRoundAuditReq) XXX_Size() int {
+	return xxx_messageInfo_GetRoundAuditReq.Size(m)
+}
+func (m *GetRoundAuditReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoundAuditReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoundAuditReq proto.InternalMessageInfo
+
+func (m *GetRoundAuditReq) GetRoundId() int64 {
+	if m != nil {
+		return m.RoundId
+	}
+	return 0
+}
+
+type GetRoundAuditResp struct {
+	Audit                []*RoundAudit `protobuf:"bytes,1,rep,name=audit,proto3" json:"audit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetRoundAuditResp) Reset()         { *m = GetRoundAuditResp{} }
+func (m *GetRoundAuditResp) String() string { return proto.CompactTextString(m) }
+func (*GetRoundAuditResp) ProtoMessage()    {}
+func (*GetRoundAuditResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{9}
+}
+func (m *GetRoundAuditResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoundAuditResp.Unmarshal(m, b)
+}
+func (m *GetRoundAuditResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoundAuditResp.Marshal(b, m, deterministic)
+}
+func (dst *GetRoundAuditResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoundAuditResp.Merge(dst, src)
+}
+func (m *GetRoundAuditResp) XXX_Size() int {
+	return xxx_messageInfo_GetRoundAuditResp.Size(m)
+}
+func (m *GetRoundAuditResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoundAuditResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoundAuditResp proto.InternalMessageInfo
+
+func (m *GetRoundAuditResp) GetAudit() []*RoundAudit {
+	if m != nil {
+		return m.Audit
+	}
+	return nil
+}
+
+type ExportReq struct {
+	Format               string   `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportReq) Reset()         { *m = ExportReq{} }
+func (m *ExportReq) String() string { return proto.CompactTextString(m) }
+func (*ExportReq) ProtoMessage()    {}
+func (*ExportReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{10}
+}
+func (m *ExportReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportReq.Unmarshal(m, b)
+}
+func (m *ExportReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportReq.Marshal(b, m, deterministic)
+}
+func (dst *ExportReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportReq.Merge(dst, src)
+}
+func (m *ExportReq) XXX_Size() int {
+	return xxx_messageInfo_ExportReq.Size(m)
+}
+func (m *ExportReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportReq proto.InternalMessageInfo
+
+func (m *ExportReq) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *ExportReq) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+type ExportChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportChunk) Reset()         { *m = ExportChunk{} }
+func (m *ExportChunk) String() string { return proto.CompactTextString(m) }
+func (*ExportChunk) ProtoMessage()    {}
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{11}
+}
+func (m *ExportChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportChunk.Unmarshal(m, b)
+}
+func (m *ExportChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportChunk.Marshal(b, m, deterministic)
+}
+func (dst *ExportChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportChunk.Merge(dst, src)
+}
+func (m *ExportChunk) XXX_Size() int {
+	return xxx_messageInfo_ExportChunk.Size(m)
+}
+func (m *ExportChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportChunk proto.InternalMessageInfo
+
+func (m *ExportChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetStatsResp struct {
+	Stats                *Stats   `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatsResp) Reset()         { *m = GetStatsResp{} }
+func (m *GetStatsResp) String() string { return proto.CompactTextString(m) }
+func (*GetStatsResp) ProtoMessage()    {}
+func (*GetStatsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{12}
+}
+func (m *GetStatsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatsResp.Unmarshal(m, b)
+}
+func (m *GetStatsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatsResp.Marshal(b, m, deterministic)
+}
+func (dst *GetStatsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatsResp.Merge(dst, src)
+}
+func (m *GetStatsResp) XXX_Size() int {
+	return xxx_messageInfo_GetStatsResp.Size(m)
+}
+func (m *GetStatsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatsResp proto.InternalMessageInfo
+
+func (m *GetStatsResp) GetStats() *Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type TotalClaim struct {
+	ExternalId           int64    `protobuf:"varint,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Player               string   `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Total                int64    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Parts                []int64  `protobuf:"varint,4,rep,packed,name=parts,proto3" json:"parts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TotalClaim) Reset()         { *m = TotalClaim{} }
+func (m *TotalClaim) String() string { return proto.CompactTextString(m) }
+func (*TotalClaim) ProtoMessage()    {}
+func (*TotalClaim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{13}
+}
+func (m *TotalClaim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TotalClaim.Unmarshal(m, b)
+}
+func (m *TotalClaim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TotalClaim.Marshal(b, m, deterministic)
+}
+func (dst *TotalClaim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TotalClaim.Merge(dst, src)
+}
+func (m *TotalClaim) XXX_Size() int {
+	return xxx_messageInfo_TotalClaim.Size(m)
+}
+func (m *TotalClaim) XXX_DiscardUnknown() {
+	xxx_messageInfo_TotalClaim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TotalClaim proto.InternalMessageInfo
+
+func (m *TotalClaim) GetExternalId() int64 {
+	if m != nil {
+		return m.ExternalId
+	}
+	return 0
+}
+
+func (m *TotalClaim) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *TotalClaim) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *TotalClaim) GetParts() []int64 {
+	if m != nil {
+		return m.Parts
+	}
+	return nil
+}
+
+type TotalCheck struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Known                bool     `protobuf:"varint,2,opt,name=known,proto3" json:"known,omitempty"`
+	Agreed               bool     `protobuf:"varint,3,opt,name=agreed,proto3" json:"agreed,omitempty"`
+	Total                int64    `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Parts                []int64  `protobuf:"varint,5,rep,packed,name=parts,proto3" json:"parts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TotalCheck) Reset()         { *m = TotalCheck{} }
+func (m *TotalCheck) String() string { return proto.CompactTextString(m) }
+func (*TotalCheck) ProtoMessage()    {}
+func (*TotalCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{14}
+}
+func (m *TotalCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TotalCheck.Unmarshal(m, b)
+}
+func (m *TotalCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TotalCheck.Marshal(b, m, deterministic)
+}
+func (dst *TotalCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TotalCheck.Merge(dst, src)
+}
+func (m *TotalCheck) XXX_Size() int {
+	return xxx_messageInfo_TotalCheck.Size(m)
+}
+func (m *TotalCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_TotalCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TotalCheck proto.InternalMessageInfo
+
+func (m *TotalCheck) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *TotalCheck) GetKnown() bool {
+	if m != nil {
+		return m.Known
+	}
+	return false
+}
+
+func (m *TotalCheck) GetAgreed() bool {
+	if m != nil {
+		return m.Agreed
+	}
+	return false
+}
+
+func (m *TotalCheck) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *TotalCheck) GetParts() []int64 {
+	if m != nil {
+		return m.Parts
+	}
+	return nil
+}
+
+type Stats struct {
+	Succeeded            int64         `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed               int64         `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Excluded             int64         `protobuf:"varint,3,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	Phases               []*PhaseStats `protobuf:"bytes,4,rep,name=phases,proto3" json:"phases,omitempty"`
+	Parts                int64         `protobuf:"varint,5,opt,name=parts,proto3" json:"parts,omitempty"`
+	PartsTotal           int64         `protobuf:"varint,6,opt,name=parts_total,json=partsTotal,proto3" json:"parts_total,omitempty"`
+	SubmittedTotal       int64         `protobuf:"varint,7,opt,name=submitted_total,json=submittedTotal,proto3" json:"submitted_total,omitempty"`
+	Peers                []*PeerStats  `protobuf:"bytes,8,rep,name=peers,proto3" json:"peers,omitempty"`
+	Matches              []*MatchStats `protobuf:"bytes,9,rep,name=matches,proto3" json:"matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Stats) Reset()         { *m = Stats{} }
+func (m *Stats) String() string { return proto.CompactTextString(m) }
+func (*Stats) ProtoMessage()    {}
+func (*Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{15}
+}
+func (m *Stats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Stats.Unmarshal(m, b)
+}
+func (m *Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Stats.Marshal(b, m, deterministic)
+}
+func (dst *Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Stats.Merge(dst, src)
+}
+func (m *Stats) XXX_Size() int {
+	return xxx_messageInfo_Stats.Size(m)
+}
+func (m *Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Stats proto.InternalMessageInfo
+
+func (m *Stats) GetSucceeded() int64 {
+	if m != nil {
+		return m.Succeeded
+	}
+	return 0
+}
+
+func (m *Stats) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *Stats) GetExcluded() int64 {
+	if m != nil {
+		return m.Excluded
+	}
+	return 0
+}
+
+func (m *Stats) GetPhases() []*PhaseStats {
+	if m != nil {
+		return m.Phases
+	}
+	return nil
+}
+
+func (m *Stats) GetParts() int64 {
+	if m != nil {
+		return m.Parts
+	}
+	return 0
+}
+
+func (m *Stats) GetPartsTotal() int64 {
+	if m != nil {
+		return m.PartsTotal
+	}
+	return 0
+}
+
+func (m *Stats) GetSubmittedTotal() int64 {
+	if m != nil {
+		return m.SubmittedTotal
+	}
+	return 0
+}
+
+func (m *Stats) GetPeers() []*PeerStats {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *Stats) GetMatches() []*MatchStats {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+type MatchStats struct {
+	MatchId              int64    `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Succeeded            int64    `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed               int64    `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Excluded             int64    `protobuf:"varint,4,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatchStats) Reset()         { *m = MatchStats{} }
+func (m *MatchStats) String() string { return proto.CompactTextString(m) }
+func (*MatchStats) ProtoMessage()    {}
+func (*MatchStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{16}
+}
+func (m *MatchStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchStats.Unmarshal(m, b)
+}
+func (m *MatchStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchStats.Marshal(b, m, deterministic)
+}
+func (dst *MatchStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchStats.Merge(dst, src)
+}
+func (m *MatchStats) XXX_Size() int {
+	return xxx_messageInfo_MatchStats.Size(m)
+}
+func (m *MatchStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchStats proto.InternalMessageInfo
+
+func (m *MatchStats) GetMatchId() int64 {
+	if m != nil {
+		return m.MatchId
+	}
+	return 0
+}
+
+func (m *MatchStats) GetSucceeded() int64 {
+	if m != nil {
+		return m.Succeeded
+	}
+	return 0
+}
+
+func (m *MatchStats) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *MatchStats) GetExcluded() int64 {
+	if m != nil {
+		return m.Excluded
+	}
+	return 0
+}
+
+type PhaseStats struct {
+	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	MeanMs               int64    `protobuf:"varint,3,opt,name=mean_ms,json=meanMs,proto3" json:"mean_ms,omitempty"`
+	P95Ms                int64    `protobuf:"varint,4,opt,name=p95_ms,json=p95Ms,proto3" json:"p95_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PhaseStats) Reset()         { *m = PhaseStats{} }
+func (m *PhaseStats) String() string { return proto.CompactTextString(m) }
+func (*PhaseStats) ProtoMessage()    {}
+func (*PhaseStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{17}
+}
+func (m *PhaseStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PhaseStats.Unmarshal(m, b)
+}
+func (m *PhaseStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PhaseStats.Marshal(b, m, deterministic)
+}
+func (dst *PhaseStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PhaseStats.Merge(dst, src)
+}
+func (m *PhaseStats) XXX_Size() int {
+	return xxx_messageInfo_PhaseStats.Size(m)
+}
+func (m *PhaseStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PhaseStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PhaseStats proto.InternalMessageInfo
+
+func (m *PhaseStats) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *PhaseStats) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PhaseStats) GetMeanMs() int64 {
+	if m != nil {
+		return m.MeanMs
+	}
+	return 0
+}
+
+func (m *PhaseStats) GetP95Ms() int64 {
+	if m != nil {
+		return m.P95Ms
+	}
+	return 0
+}
+
+type PeerStats struct {
+	Player               string   `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	LateSubmissions      int64    `protobuf:"varint,2,opt,name=late_submissions,json=lateSubmissions,proto3" json:"late_submissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerStats) Reset()         { *m = PeerStats{} }
+func (m *PeerStats) String() string { return proto.CompactTextString(m) }
+func (*PeerStats) ProtoMessage()    {}
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{18}
+}
+func (m *PeerStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerStats.Unmarshal(m, b)
+}
+func (m *PeerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerStats.Marshal(b, m, deterministic)
+}
+func (dst *PeerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStats.Merge(dst, src)
+}
+func (m *PeerStats) XXX_Size() int {
+	return xxx_messageInfo_PeerStats.Size(m)
+}
+func (m *PeerStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerStats proto.InternalMessageInfo
+
+func (m *PeerStats) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *PeerStats) GetLateSubmissions() int64 {
+	if m != nil {
+		return m.LateSubmissions
+	}
+	return 0
+}
+
+type Round struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId           int64                `protobuf:"varint,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Player               string               `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
+	Status               int32                `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Round) Reset()         { *m = Round{} }
+func (m *Round) String() string { return proto.CompactTextString(m) }
+func (*Round) ProtoMessage()    {}
+func (*Round) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{19}
+}
+func (m *Round) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Round.Unmarshal(m, b)
+}
+func (m *Round) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Round.Marshal(b, m, deterministic)
+}
+func (dst *Round) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Round.Merge(dst, src)
+}
+func (m *Round) XXX_Size() int {
+	return xxx_messageInfo_Round.Size(m)
+}
+func (m *Round) XXX_DiscardUnknown() {
+	xxx_messageInfo_Round.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Round proto.InternalMessageInfo
+
+func (m *Round) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Round) GetExternalId() int64 {
+	if m != nil {
+		return m.ExternalId
+	}
+	return 0
+}
+
+func (m *Round) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *Round) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *Round) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Round) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type Part struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoundId              int64                `protobuf:"varint,2,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Player               string               `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
+	Rank                 int64                `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	Value                int64                `protobuf:"varint,5,opt,name=value,proto3" json:"value,omitempty"`
+	Submitted            bool                 `protobuf:"varint,6,opt,name=submitted,proto3" json:"submitted,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Signature            []byte               `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	SubmittedSignature   []byte               `protobuf:"bytes,10,opt,name=submitted_signature,json=submittedSignature,proto3" json:"submitted_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Part) Reset()         { *m = Part{} }
+func (m *Part) String() string { return proto.CompactTextString(m) }
+func (*Part) ProtoMessage()    {}
+func (*Part) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{20}
+}
+func (m *Part) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Part.Unmarshal(m, b)
+}
+func (m *Part) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Part.Marshal(b, m, deterministic)
+}
+func (dst *Part) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Part.Merge(dst, src)
+}
+func (m *Part) XXX_Size() int {
+	return xxx_messageInfo_Part.Size(m)
+}
+func (m *Part) XXX_DiscardUnknown() {
+	xxx_messageInfo_Part.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Part proto.InternalMessageInfo
+
+func (m *Part) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Part) GetRoundId() int64 {
+	if m != nil {
+		return m.RoundId
+	}
+	return 0
+}
+
+func (m *Part) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *Part) GetRank() int64 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *Part) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Part) GetSubmitted() bool {
+	if m != nil {
+		return m.Submitted
+	}
+	return false
+}
+
+func (m *Part) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Part) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Part) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Part) GetSubmittedSignature() []byte {
+	if m != nil {
+		return m.SubmittedSignature
+	}
+	return nil
+}
+
+type RoundAudit struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoundId              int64                `protobuf:"varint,2,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	FromStatus           int32                `protobuf:"varint,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus             int32                `protobuf:"varint,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Trigger              string               `protobuf:"bytes,5,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Error                string               `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs           int64                `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RoundAudit) Reset()         { *m = RoundAudit{} }
+func (m *RoundAudit) String() string { return proto.CompactTextString(m) }
+func (*RoundAudit) ProtoMessage()    {}
+func (*RoundAudit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{21}
+}
+func (m *RoundAudit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoundAudit.Unmarshal(m, b)
+}
+func (m *RoundAudit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoundAudit.Marshal(b, m, deterministic)
+}
+func (dst *RoundAudit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundAudit.Merge(dst, src)
 }
-func (m *Round) XXX_DiscardUnknown() {
-	xxx_messageInfo_Round.DiscardUnknown(m)
+func (m *RoundAudit) XXX_Size() int {
+	return xxx_messageInfo_RoundAudit.Size(m)
+}
+func (m *RoundAudit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundAudit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Round proto.InternalMessageInfo
+var xxx_messageInfo_RoundAudit proto.InternalMessageInfo
 
-func (m *Round) GetId() int64 {
+func (m *RoundAudit) GetId() int64 {
 	if m != nil {
 		return m.Id
 	}
 	return 0
 }
 
-func (m *Round) GetExternalId() int64 {
+func (m *RoundAudit) GetRoundId() int64 {
 	if m != nil {
-		return m.ExternalId
+		return m.RoundId
 	}
 	return 0
 }
 
-func (m *Round) GetPlayer() string {
+func (m *RoundAudit) GetFromStatus() int32 {
 	if m != nil {
-		return m.Player
+		return m.FromStatus
 	}
-	return ""
+	return 0
 }
 
-func (m *Round) GetStatus() int32 {
+func (m *RoundAudit) GetToStatus() int32 {
 	if m != nil {
-		return m.Status
+		return m.ToStatus
 	}
 	return 0
 }
 
-func (m *Round) GetCreatedAt() *timestamp.Timestamp {
+func (m *RoundAudit) GetTrigger() string {
 	if m != nil {
-		return m.CreatedAt
+		return m.Trigger
 	}
-	return nil
+	return ""
 }
 
-func (m *Round) GetUpdatedAt() *timestamp.Timestamp {
+func (m *RoundAudit) GetError() string {
 	if m != nil {
-		return m.UpdatedAt
+		return m.Error
+	}
+	return ""
+}
+
+func (m *RoundAudit) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *RoundAudit) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
 	}
 	return nil
 }
 
-type Part struct {
-	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	RoundId              int64                `protobuf:"varint,2,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
-	Player               string               `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
-	Rank                 int64                `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
-	Value                int64                `protobuf:"varint,5,opt,name=value,proto3" json:"value,omitempty"`
-	Submitted            bool                 `protobuf:"varint,6,opt,name=submitted,proto3" json:"submitted,omitempty"`
-	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type RoundMeta struct {
+	ExternalId           int64    `protobuf:"varint,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Player               string   `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Rank                 int64    `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	Parts                []int64  `protobuf:"varint,4,rep,packed,name=parts,proto3" json:"parts,omitempty"`
+	Submitted            bool     `protobuf:"varint,5,opt,name=submitted,proto3" json:"submitted,omitempty"`
+	PartSignatures       [][]byte `protobuf:"bytes,6,rep,name=part_signatures,json=partSignatures,proto3" json:"part_signatures,omitempty"`
+	SubmittedSignature   []byte   `protobuf:"bytes,7,opt,name=submitted_signature,json=submittedSignature,proto3" json:"submitted_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Part) Reset()         { *m = Part{} }
-func (m *Part) String() string { return proto.CompactTextString(m) }
-func (*Part) ProtoMessage()    {}
-func (*Part) Descriptor() ([]byte, []int) {
-	return fileDescriptor_player_0179889ae3869a80, []int{7}
+func (m *RoundMeta) Reset()         { *m = RoundMeta{} }
+func (m *RoundMeta) String() string { return proto.CompactTextString(m) }
+func (*RoundMeta) ProtoMessage()    {}
+func (*RoundMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_65354325f2191bba, []int{22}
 }
-func (m *Part) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Part.Unmarshal(m, b)
+func (m *RoundMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoundMeta.Unmarshal(m, b)
 }
-func (m *Part) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Part.Marshal(b, m, deterministic)
+func (m *RoundMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoundMeta.Marshal(b, m, deterministic)
 }
-func (dst *Part) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Part.Merge(dst, src)
+func (dst *RoundMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundMeta.Merge(dst, src)
 }
-func (m *Part) XXX_Size() int {
-	return xxx_messageInfo_Part.Size(m)
+func (m *RoundMeta) XXX_Size() int {
+	return xxx_messageInfo_RoundMeta.Size(m)
 }
-func (m *Part) XXX_DiscardUnknown() {
-	xxx_messageInfo_Part.DiscardUnknown(m)
+func (m *RoundMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundMeta.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Part proto.InternalMessageInfo
-
-func (m *Part) GetId() int64 {
-	if m != nil {
-		return m.Id
-	}
-	return 0
-}
+var xxx_messageInfo_RoundMeta proto.InternalMessageInfo
 
-func (m *Part) GetRoundId() int64 {
+func (m *RoundMeta) GetExternalId() int64 {
 	if m != nil {
-		return m.RoundId
+		return m.ExternalId
 	}
 	return 0
 }
 
-func (m *Part) GetPlayer() string {
+func (m *RoundMeta) GetPlayer() string {
 	if m != nil {
 		return m.Player
 	}
 	return ""
 }
 
-func (m *Part) GetRank() int64 {
+func (m *RoundMeta) GetRank() int64 {
 	if m != nil {
 		return m.Rank
 	}
 	return 0
 }
 
-func (m *Part) GetValue() int64 {
+func (m *RoundMeta) GetParts() []int64 {
 	if m != nil {
-		return m.Value
+		return m.Parts
 	}
-	return 0
+	return nil
 }
 
-func (m *Part) GetSubmitted() bool {
+func (m *RoundMeta) GetSubmitted() bool {
 	if m != nil {
 		return m.Submitted
 	}
 	return false
 }
 
-func (m *Part) GetCreatedAt() *timestamp.Timestamp {
+func (m *RoundMeta) GetPartSignatures() [][]byte {
 	if m != nil {
-		return m.CreatedAt
+		return m.PartSignatures
 	}
 	return nil
 }
 
-func (m *Part) GetUpdatedAt() *timestamp.Timestamp {
+func (m *RoundMeta) GetSubmittedSignature() []byte {
 	if m != nil {
-		return m.UpdatedAt
+		return m.SubmittedSignature
 	}
 	return nil
 }
@@ -420,12 +1326,27 @@ func (m *Part) GetUpdatedAt() *timestamp.Timestamp {
 func init() {
 	proto.RegisterType((*Empty)(nil), "playerpb.Empty")
 	proto.RegisterType((*GetNameResp)(nil), "playerpb.GetNameResp")
+	proto.RegisterType((*HelloResp)(nil), "playerpb.HelloResp")
+	proto.RegisterType((*LeaveReq)(nil), "playerpb.LeaveReq")
 	proto.RegisterType((*GetPartsReq)(nil), "playerpb.GetPartsReq")
 	proto.RegisterType((*GetPartsResp)(nil), "playerpb.GetPartsResp")
 	proto.RegisterType((*GetRoundReq)(nil), "playerpb.GetRoundReq")
 	proto.RegisterType((*GetRoundResp)(nil), "playerpb.GetRoundResp")
+	proto.RegisterType((*GetRoundAuditReq)(nil), "playerpb.GetRoundAuditReq")
+	proto.RegisterType((*GetRoundAuditResp)(nil), "playerpb.GetRoundAuditResp")
+	proto.RegisterType((*ExportReq)(nil), "playerpb.ExportReq")
+	proto.RegisterType((*ExportChunk)(nil), "playerpb.ExportChunk")
+	proto.RegisterType((*GetStatsResp)(nil), "playerpb.GetStatsResp")
+	proto.RegisterType((*TotalClaim)(nil), "playerpb.TotalClaim")
+	proto.RegisterType((*TotalCheck)(nil), "playerpb.TotalCheck")
+	proto.RegisterType((*Stats)(nil), "playerpb.Stats")
+	proto.RegisterType((*MatchStats)(nil), "playerpb.MatchStats")
+	proto.RegisterType((*PhaseStats)(nil), "playerpb.PhaseStats")
+	proto.RegisterType((*PeerStats)(nil), "playerpb.PeerStats")
 	proto.RegisterType((*Round)(nil), "playerpb.Round")
 	proto.RegisterType((*Part)(nil), "playerpb.Part")
+	proto.RegisterType((*RoundAudit)(nil), "playerpb.RoundAudit")
+	proto.RegisterType((*RoundMeta)(nil), "playerpb.RoundMeta")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -443,8 +1364,15 @@ type PlayerClient interface {
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	StreamRoundEvents(ctx context.Context, in *reflexpb.StreamRequest, opts ...grpc.CallOption) (Player_StreamRoundEventsClient, error)
 	GetParts(ctx context.Context, in *GetPartsReq, opts ...grpc.CallOption) (*GetPartsResp, error)
+	GetRoundParts(ctx context.Context, in *GetPartsReq, opts ...grpc.CallOption) (*GetPartsResp, error)
 	GetRound(ctx context.Context, in *GetRoundReq, opts ...grpc.CallOption) (*GetRoundResp, error)
 	GetName(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetNameResp, error)
+	Hello(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HelloResp, error)
+	Leave(ctx context.Context, in *LeaveReq, opts ...grpc.CallOption) (*Empty, error)
+	GetRoundAudit(ctx context.Context, in *GetRoundAuditReq, opts ...grpc.CallOption) (*GetRoundAuditResp, error)
+	Export(ctx context.Context, in *ExportReq, opts ...grpc.CallOption) (Player_ExportClient, error)
+	GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetStatsResp, error)
+	CheckTotal(ctx context.Context, in *TotalClaim, opts ...grpc.CallOption) (*TotalCheck, error)
 }
 
 type playerClient struct {
@@ -505,6 +1433,15 @@ func (c *playerClient) GetParts(ctx context.Context, in *GetPartsReq, opts ...gr
 	return out, nil
 }
 
+func (c *playerClient) GetRoundParts(ctx context.Context, in *GetPartsReq, opts ...grpc.CallOption) (*GetPartsResp, error) {
+	out := new(GetPartsResp)
+	err := c.cc.Invoke(ctx, "/playerpb.Player/GetRoundParts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playerClient) GetRound(ctx context.Context, in *GetRoundReq, opts ...grpc.CallOption) (*GetRoundResp, error) {
 	out := new(GetRoundResp)
 	err := c.cc.Invoke(ctx, "/playerpb.Player/GetRound", in, out, opts...)
@@ -523,13 +1460,97 @@ func (c *playerClient) GetName(ctx context.Context, in *Empty, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *playerClient) Hello(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HelloResp, error) {
+	out := new(HelloResp)
+	err := c.cc.Invoke(ctx, "/playerpb.Player/Hello", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Leave(ctx context.Context, in *LeaveReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/playerpb.Player/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetRoundAudit(ctx context.Context, in *GetRoundAuditReq, opts ...grpc.CallOption) (*GetRoundAuditResp, error) {
+	out := new(GetRoundAuditResp)
+	err := c.cc.Invoke(ctx, "/playerpb.Player/GetRoundAudit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Export(ctx context.Context, in *ExportReq, opts ...grpc.CallOption) (Player_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Player_serviceDesc.Streams[1], "/playerpb.Player/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playerExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Player_ExportClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type playerExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *playerExportClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playerClient) GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetStatsResp, error) {
+	out := new(GetStatsResp)
+	err := c.cc.Invoke(ctx, "/playerpb.Player/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) CheckTotal(ctx context.Context, in *TotalClaim, opts ...grpc.CallOption) (*TotalCheck, error) {
+	out := new(TotalCheck)
+	err := c.cc.Invoke(ctx, "/playerpb.Player/CheckTotal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlayerServer is the server API for Player service.
 type PlayerServer interface {
 	Ping(context.Context, *Empty) (*Empty, error)
 	StreamRoundEvents(*reflexpb.StreamRequest, Player_StreamRoundEventsServer) error
 	GetParts(context.Context, *GetPartsReq) (*GetPartsResp, error)
+	GetRoundParts(context.Context, *GetPartsReq) (*GetPartsResp, error)
 	GetRound(context.Context, *GetRoundReq) (*GetRoundResp, error)
 	GetName(context.Context, *Empty) (*GetNameResp, error)
+	Hello(context.Context, *Empty) (*HelloResp, error)
+	Leave(context.Context, *LeaveReq) (*Empty, error)
+	GetRoundAudit(context.Context, *GetRoundAuditReq) (*GetRoundAuditResp, error)
+	Export(*ExportReq, Player_ExportServer) error
+	GetStats(context.Context, *Empty) (*GetStatsResp, error)
+	CheckTotal(context.Context, *TotalClaim) (*TotalCheck, error)
 }
 
 func RegisterPlayerServer(s *grpc.Server, srv PlayerServer) {
@@ -593,6 +1614,24 @@ func _Player_GetParts_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Player_GetRoundParts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetRoundParts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.Player/GetRoundParts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetRoundParts(ctx, req.(*GetPartsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Player_GetRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRoundReq)
 	if err := dec(in); err != nil {
@@ -629,6 +1668,117 @@ func _Player_GetName_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Player_Hello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Hello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.Player/Hello",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Hello(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.Player/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Leave(ctx, req.(*LeaveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetRoundAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundAuditReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetRoundAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.Player/GetRoundAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetRoundAudit(ctx, req.(*GetRoundAuditReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerServer).Export(m, &playerExportServer{stream})
+}
+
+type Player_ExportServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type playerExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *playerExportServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Player_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.Player/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_CheckTotal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotalClaim)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).CheckTotal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.Player/CheckTotal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).CheckTotal(ctx, req.(*TotalClaim))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Player_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "playerpb.Player",
 	HandlerType: (*PlayerServer)(nil),
@@ -641,6 +1791,10 @@ var _Player_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetParts",
 			Handler:    _Player_GetParts_Handler,
 		},
+		{
+			MethodName: "GetRoundParts",
+			Handler:    _Player_GetRoundParts_Handler,
+		},
 		{
 			MethodName: "GetRound",
 			Handler:    _Player_GetRound_Handler,
@@ -649,6 +1803,26 @@ var _Player_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetName",
 			Handler:    _Player_GetName_Handler,
 		},
+		{
+			MethodName: "Hello",
+			Handler:    _Player_Hello_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _Player_Leave_Handler,
+		},
+		{
+			MethodName: "GetRoundAudit",
+			Handler:    _Player_GetRoundAudit_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Player_GetStats_Handler,
+		},
+		{
+			MethodName: "CheckTotal",
+			Handler:    _Player_CheckTotal_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -656,45 +1830,98 @@ var _Player_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Player_StreamRoundEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Export",
+			Handler:       _Player_Export_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "player.proto",
 }
 
-func init() { proto.RegisterFile("player.proto", fileDescriptor_player_0179889ae3869a80) }
-
-var fileDescriptor_player_0179889ae3869a80 = []byte{
-	// 518 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xdb, 0x6e, 0xd3, 0x4c,
-	0x10, 0x8e, 0x9d, 0x38, 0x87, 0x49, 0xd5, 0xea, 0x5f, 0xfd, 0x14, 0x63, 0x21, 0x35, 0xac, 0x40,
-	0x8a, 0x10, 0x72, 0x50, 0x0a, 0x17, 0x88, 0xab, 0x5c, 0x54, 0xa8, 0x37, 0x28, 0x32, 0xdc, 0x57,
-	0xeb, 0x7a, 0x1a, 0x2c, 0x7c, 0xd8, 0x7a, 0xd7, 0x55, 0xfb, 0x04, 0xbc, 0x09, 0x8f, 0xc5, 0xb3,
-	0x20, 0xcf, 0xae, 0xeb, 0x24, 0x05, 0x21, 0xb8, 0xf2, 0x1c, 0xbe, 0x99, 0xf1, 0xf7, 0xed, 0x0c,
-	0x1c, 0xc8, 0x4c, 0xdc, 0x61, 0x15, 0xca, 0xaa, 0xd4, 0x25, 0x1b, 0x1b, 0x4f, 0xc6, 0xc1, 0xab,
-	0x4d, 0xaa, 0xbf, 0xd4, 0x71, 0x78, 0x59, 0xe6, 0x8b, 0xac, 0x2e, 0xca, 0x45, 0x85, 0x57, 0x19,
-	0xde, 0xda, 0x8f, 0x8c, 0xad, 0x61, 0xea, 0x82, 0x93, 0x4d, 0x59, 0x6e, 0x32, 0x5c, 0x90, 0x17,
-	0xd7, 0x57, 0x0b, 0x9d, 0xe6, 0xa8, 0xb4, 0xc8, 0xa5, 0x01, 0xf0, 0x11, 0x78, 0x67, 0xb9, 0xd4,
-	0x77, 0xfc, 0x19, 0x4c, 0x3f, 0xa0, 0xfe, 0x28, 0x72, 0x8c, 0x50, 0x49, 0xc6, 0x60, 0x50, 0x88,
-	0x1c, 0x7d, 0x67, 0xe6, 0xcc, 0x27, 0x11, 0xd9, 0x3c, 0x24, 0xc8, 0x5a, 0x54, 0x5a, 0x45, 0x78,
-	0xcd, 0x4e, 0x60, 0x8a, 0xb7, 0x1a, 0xab, 0x42, 0x64, 0x17, 0x69, 0x42, 0xc8, 0x7e, 0x04, 0x6d,
-	0xe8, 0x3c, 0xe1, 0x6f, 0xe0, 0xa0, 0xc3, 0x2b, 0xc9, 0x9e, 0x83, 0x27, 0x1b, 0xc7, 0x77, 0x66,
-	0xfd, 0xf9, 0x74, 0x79, 0x18, 0xb6, 0xa4, 0xc2, 0x06, 0x13, 0x99, 0x24, 0x9f, 0xd3, 0x94, 0xa8,
-	0xac, 0x8b, 0xa4, 0x99, 0xf2, 0x04, 0xc6, 0x55, 0x63, 0x77, 0x23, 0x46, 0xe4, 0x9f, 0x27, 0xfc,
-	0x2d, 0xf5, 0xb7, 0x48, 0x25, 0xd9, 0x0b, 0xf0, 0x28, 0x45, 0xb8, 0xe9, 0xf2, 0xa8, 0xeb, 0x6f,
-	0x30, 0x26, 0xcb, 0x7f, 0x38, 0xe0, 0x51, 0x80, 0x1d, 0x82, 0x7b, 0xdf, 0xd5, 0x4d, 0x93, 0x7d,
-	0x46, 0xee, 0x3e, 0x23, 0x76, 0x0c, 0x43, 0xd3, 0xd3, 0xef, 0x93, 0x2e, 0xd6, 0x6b, 0xe2, 0x4a,
-	0x0b, 0x5d, 0x2b, 0x7f, 0x30, 0x73, 0xe6, 0x5e, 0x64, 0x3d, 0xf6, 0x0e, 0xe0, 0xb2, 0x42, 0xa1,
-	0x31, 0xb9, 0x10, 0xda, 0x1f, 0xd2, 0x6f, 0x05, 0xa1, 0x79, 0x93, 0xb0, 0x7d, 0x93, 0xf0, 0x73,
-	0xfb, 0x26, 0xd1, 0xc4, 0xa2, 0x57, 0xba, 0x29, 0xad, 0x65, 0xd2, 0x96, 0x8e, 0xfe, 0x5c, 0x6a,
-	0xd1, 0x2b, 0xcd, 0xbf, 0xb9, 0x30, 0x68, 0x14, 0x7d, 0xc0, 0x6f, 0x5b, 0x4b, 0x77, 0x47, 0xcb,
-	0xdf, 0x32, 0x63, 0x30, 0xa8, 0x44, 0xf1, 0x95, 0x78, 0xf5, 0x23, 0xb2, 0xd9, 0xff, 0xe0, 0xdd,
-	0x88, 0xac, 0x46, 0xdf, 0xa3, 0xa0, 0x71, 0xd8, 0x53, 0x98, 0xa8, 0x3a, 0xce, 0x53, 0xad, 0x31,
-	0x21, 0xaa, 0xe3, 0xa8, 0x0b, 0xec, 0x29, 0x31, 0xfa, 0x77, 0x25, 0xc6, 0x7f, 0xa1, 0xc4, 0xf2,
-	0xbb, 0x0b, 0xc3, 0xb5, 0x21, 0xf2, 0x12, 0x06, 0xeb, 0xb4, 0xd8, 0xb0, 0xad, 0xad, 0xa0, 0xc5,
-	0x0f, 0xf6, 0x03, 0xbc, 0xc7, 0x56, 0xf0, 0xdf, 0x27, 0x5d, 0xa1, 0xc8, 0x69, 0x4d, 0xce, 0x6e,
-	0xb0, 0xd0, 0x8a, 0x3d, 0x0e, 0xdb, 0x13, 0x0b, 0x6d, 0x12, 0xaf, 0x6b, 0x54, 0x3a, 0x38, 0xea,
-	0x12, 0x04, 0xe5, 0xbd, 0xd7, 0x0e, 0x7b, 0x0f, 0xe3, 0x76, 0xf7, 0xd9, 0xa3, 0x6e, 0xc2, 0xd6,
-	0xfd, 0x04, 0xc7, 0xbf, 0x0a, 0x2b, 0xc9, 0x7b, 0xb6, 0xd8, 0xec, 0xe8, 0x6e, 0x71, 0x7b, 0x16,
-	0x7b, 0xc5, 0xf7, 0x37, 0xc0, 0x7b, 0xec, 0x14, 0x46, 0xf6, 0x90, 0x1f, 0x72, 0xdd, 0x6d, 0xd6,
-	0x1e, 0x3b, 0xef, 0xc5, 0x43, 0xd2, 0xf1, 0xf4, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5a, 0x89,
-	0xc4, 0xfc, 0x76, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("player.proto", fileDescriptor_player_65354325f2191bba) }
+
+var fileDescriptor_player_65354325f2191bba = []byte{
+	// 1281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5f, 0x73, 0x1b, 0x35,
+	0x10, 0xb7, 0x7d, 0x3e, 0xfb, 0xbc, 0x76, 0x93, 0x56, 0xfd, 0x67, 0x5c, 0x86, 0x04, 0x41, 0xa7,
+	0x69, 0xa7, 0x5c, 0x98, 0x94, 0x0c, 0x14, 0x1e, 0x20, 0xd3, 0xe9, 0x40, 0x19, 0xd2, 0xc9, 0x5c,
+	0x3a, 0xbc, 0x7a, 0x64, 0x9f, 0xe2, 0x1c, 0xb9, 0x7f, 0x3d, 0xe9, 0x42, 0xca, 0x1b, 0x1f, 0x8c,
+	0x97, 0x7e, 0x08, 0x1e, 0x79, 0xe4, 0x73, 0x30, 0x5a, 0x49, 0xbe, 0x3b, 0x3b, 0xa1, 0xd3, 0x3e,
+	0xdd, 0xed, 0x6f, 0x7f, 0xd2, 0x4a, 0xbf, 0x5d, 0x49, 0x0b, 0xa3, 0x3c, 0x66, 0x6f, 0x78, 0xe1,
+	0xe7, 0x45, 0x26, 0x33, 0xe2, 0x69, 0x2b, 0x9f, 0x4d, 0x1e, 0x2f, 0x22, 0x79, 0x5a, 0xce, 0xfc,
+	0x79, 0x96, 0xec, 0xc6, 0x65, 0x9a, 0xed, 0x16, 0xfc, 0x24, 0xe6, 0x17, 0xe6, 0x93, 0xcf, 0xcc,
+	0x8f, 0x1e, 0x37, 0xd9, 0x5a, 0x64, 0xd9, 0x22, 0xe6, 0xbb, 0x68, 0xcd, 0xca, 0x93, 0x5d, 0x19,
+	0x25, 0x5c, 0x48, 0x96, 0xe4, 0x9a, 0x40, 0xfb, 0xe0, 0x3e, 0x4f, 0x72, 0xf9, 0x86, 0x7e, 0x0a,
+	0xc3, 0x1f, 0xb9, 0x7c, 0xc9, 0x12, 0x1e, 0x70, 0x91, 0x13, 0x02, 0xdd, 0x94, 0x25, 0x7c, 0xdc,
+	0xde, 0x6e, 0xef, 0x0c, 0x02, 0xfc, 0xa7, 0x7f, 0xb5, 0x61, 0xf0, 0x13, 0x8f, 0xe3, 0x0c, 0x19,
+	0x5b, 0x30, 0xd4, 0x8b, 0x9a, 0xd6, 0x88, 0xa0, 0x21, 0x35, 0x0d, 0xb9, 0x07, 0x03, 0xc9, 0x59,
+	0xa2, 0xdd, 0x1d, 0x74, 0x7b, 0x0a, 0x40, 0xe7, 0x67, 0x70, 0x6d, 0x56, 0x46, 0x71, 0x38, 0x3d,
+	0xe7, 0x85, 0x88, 0xb2, 0x74, 0xec, 0x20, 0x61, 0x84, 0xe0, 0xaf, 0x1a, 0x23, 0x0f, 0xe1, 0x3a,
+	0xae, 0x72, 0x9e, 0xc5, 0x4b, 0x5e, 0x77, 0xbb, 0xbd, 0xe3, 0x06, 0x9b, 0x16, 0xb7, 0x54, 0x0a,
+	0xa3, 0x39, 0xcb, 0xd9, 0x2c, 0x8a, 0x23, 0x19, 0x71, 0x31, 0x76, 0xb7, 0x1d, 0x35, 0x5d, 0x1d,
+	0xa3, 0x9f, 0x80, 0xf7, 0x0b, 0x67, 0xe7, 0x3c, 0xe0, 0xaf, 0x2f, 0xdd, 0x9f, 0x8f, 0x12, 0x1c,
+	0xb1, 0x42, 0x0a, 0x45, 0xd9, 0x82, 0x21, 0xbf, 0x90, 0xbc, 0x48, 0x59, 0x3c, 0x8d, 0x42, 0x64,
+	0x3a, 0x01, 0x58, 0xe8, 0x45, 0x48, 0xbf, 0x82, 0x51, 0xc5, 0x17, 0x39, 0xf9, 0x1c, 0xdc, 0x5c,
+	0x19, 0xe3, 0xf6, 0xb6, 0xb3, 0x33, 0xdc, 0xdb, 0xf0, 0x6d, 0xd2, 0x7c, 0xc5, 0x09, 0xb4, 0x93,
+	0xee, 0x60, 0x94, 0x20, 0x2b, 0xd3, 0x50, 0x45, 0xf9, 0x08, 0xbc, 0x42, 0xfd, 0x57, 0x21, 0xfa,
+	0x68, 0xbf, 0x08, 0xe9, 0x3e, 0x8c, 0x2a, 0xa6, 0xc8, 0xc9, 0x7d, 0x70, 0xd1, 0x85, 0xbc, 0xe1,
+	0xde, 0x66, 0x35, 0xbf, 0xe6, 0x68, 0x2f, 0xfd, 0x02, 0xae, 0xdb, 0x61, 0x07, 0x65, 0x18, 0xc9,
+	0x77, 0x44, 0xf9, 0x1e, 0x6e, 0xac, 0xd0, 0x45, 0x4e, 0x1e, 0x81, 0xcb, 0x94, 0x61, 0xb6, 0x72,
+	0x6b, 0x25, 0x94, 0x26, 0x6a, 0x0a, 0xfd, 0x1a, 0x06, 0xcf, 0x2f, 0xf2, 0xac, 0xc0, 0x40, 0x77,
+	0xa0, 0x77, 0x92, 0x15, 0x09, 0x93, 0x46, 0x59, 0x63, 0x29, 0xbd, 0xcf, 0xa2, 0x34, 0x34, 0x75,
+	0x80, 0xff, 0xaa, 0xe4, 0xf4, 0xc0, 0x67, 0xa7, 0x65, 0x7a, 0xa6, 0x28, 0x21, 0x93, 0x0c, 0x07,
+	0x8e, 0x02, 0xfc, 0x37, 0x12, 0x1c, 0x4b, 0x66, 0x24, 0xbe, 0x0f, 0xae, 0x50, 0xc6, 0xba, 0x04,
+	0x9a, 0xa3, 0xbd, 0xf4, 0x35, 0xc0, 0xab, 0x4c, 0xb2, 0xf8, 0x59, 0xcc, 0xa2, 0xe4, 0x9d, 0x89,
+	0x54, 0x8b, 0xd6, 0xf3, 0x98, 0xe5, 0x19, 0x8b, 0xdc, 0x02, 0x57, 0xaa, 0x69, 0xb0, 0x38, 0x9d,
+	0x40, 0x1b, 0x0a, 0xd5, 0x69, 0xee, 0x6e, 0x3b, 0x0a, 0xd5, 0x69, 0xfd, 0xc3, 0x86, 0x3c, 0xe5,
+	0x73, 0xdc, 0x4b, 0xce, 0x79, 0x61, 0xcb, 0x4b, 0xfd, 0xab, 0x71, 0x67, 0x69, 0xf6, 0x7b, 0x8a,
+	0x41, 0xbc, 0x40, 0x1b, 0x2a, 0x36, 0x5b, 0x14, 0x9c, 0x87, 0x18, 0xc4, 0x0b, 0x8c, 0x55, 0xc5,
+	0xee, 0x5e, 0x1a, 0xdb, 0xad, 0xc7, 0x7e, 0xdb, 0x01, 0x17, 0xf7, 0x4f, 0x3e, 0x86, 0x81, 0x28,
+	0xe7, 0x73, 0xce, 0x43, 0x6e, 0x37, 0x5a, 0x01, 0x98, 0x1c, 0x16, 0xc5, 0x5c, 0xa7, 0xc1, 0x09,
+	0x8c, 0x45, 0x26, 0xe0, 0xf1, 0x8b, 0x79, 0x5c, 0x86, 0x66, 0x15, 0x4e, 0xb0, 0xb4, 0xc9, 0x63,
+	0xe8, 0xe5, 0xa7, 0x4c, 0x70, 0xbd, 0xdd, 0x46, 0x29, 0x1c, 0x29, 0x5c, 0xeb, 0x6e, 0x38, 0xf5,
+	0xf5, 0xb5, 0x97, 0xeb, 0xc3, 0xab, 0x42, 0xfd, 0x4c, 0xf5, 0x8e, 0x7a, 0x3a, 0x01, 0x08, 0xa1,
+	0x66, 0xe4, 0x01, 0x6c, 0x8a, 0x72, 0x96, 0x44, 0x52, 0xf2, 0xd0, 0x90, 0xfa, 0x48, 0xda, 0x58,
+	0xc2, 0x9a, 0xf8, 0x10, 0x5c, 0xa5, 0xa5, 0x18, 0x7b, 0xb8, 0x98, 0x9b, 0xb5, 0xc5, 0x70, 0x5e,
+	0x98, 0x1a, 0x40, 0x06, 0xf1, 0xa1, 0x9f, 0x30, 0x39, 0x3f, 0xe5, 0x62, 0x3c, 0x58, 0x5d, 0xf9,
+	0xa1, 0x72, 0x68, 0xb6, 0x25, 0xd1, 0x37, 0x00, 0x15, 0xac, 0x0e, 0x0c, 0x3a, 0x6a, 0x07, 0x06,
+	0xed, 0x17, 0x61, 0x53, 0xe3, 0xce, 0xd5, 0x1a, 0x3b, 0x57, 0x6a, 0xdc, 0x6d, 0x6a, 0x4c, 0x7f,
+	0x03, 0xa8, 0xb4, 0x54, 0x33, 0xa8, 0x2a, 0x2e, 0x75, 0x91, 0xbb, 0x81, 0xb1, 0x94, 0xb6, 0xf3,
+	0xac, 0x4c, 0xa5, 0x89, 0xa9, 0x0d, 0x72, 0x17, 0xfa, 0x09, 0x67, 0xe9, 0x34, 0x11, 0x36, 0xa0,
+	0x32, 0x0f, 0x05, 0xb9, 0x0d, 0xbd, 0xfc, 0xe9, 0xbe, 0xc2, 0x4d, 0x05, 0xe5, 0x4f, 0xf7, 0x0f,
+	0x05, 0x7d, 0x09, 0x83, 0xa5, 0x54, 0xb5, 0xc2, 0x6f, 0x37, 0x0a, 0xff, 0x21, 0x5c, 0x8f, 0x99,
+	0xe4, 0x53, 0x54, 0x5f, 0xa8, 0x0b, 0x56, 0x98, 0xa8, 0x9b, 0x0a, 0x3f, 0xae, 0x60, 0xfa, 0x4f,
+	0x1b, 0x5c, 0xbc, 0x13, 0xc8, 0x06, 0x74, 0x96, 0x62, 0x75, 0xa2, 0x70, 0xf5, 0xd8, 0x75, 0xfe,
+	0xe7, 0xd8, 0x39, 0x8d, 0xe8, 0x95, 0x00, 0xdd, 0x86, 0x00, 0x4f, 0x01, 0xe6, 0x05, 0x67, 0xaa,
+	0x46, 0x98, 0xc4, 0x2a, 0x1a, 0xee, 0x4d, 0x7c, 0xfd, 0xc2, 0xf9, 0xf6, 0x85, 0xf3, 0x5f, 0xd9,
+	0x17, 0x2e, 0x18, 0x18, 0xf6, 0x81, 0x54, 0x43, 0xcb, 0x3c, 0xb4, 0x43, 0xfb, 0xef, 0x1e, 0x6a,
+	0xd8, 0x07, 0x92, 0xfe, 0xdd, 0x81, 0xae, 0xba, 0xbf, 0xd7, 0xf6, 0x57, 0xbf, 0x53, 0x3b, 0x8d,
+	0x3b, 0xf5, 0xca, 0x9d, 0x11, 0xe8, 0x16, 0x2c, 0x3d, 0x33, 0x19, 0xc1, 0x7f, 0x95, 0xd6, 0x73,
+	0x16, 0x97, 0xdc, 0x1e, 0x19, 0x34, 0x74, 0x91, 0x99, 0xd2, 0xc7, 0xad, 0x7a, 0x41, 0x05, 0xac,
+	0x28, 0xd1, 0xff, 0x70, 0x25, 0xbc, 0xf7, 0x50, 0x02, 0xd7, 0x14, 0x2d, 0x52, 0x26, 0xcb, 0x82,
+	0x8f, 0x07, 0x78, 0x4b, 0x57, 0x00, 0xd9, 0x85, 0x9b, 0xd5, 0x19, 0xae, 0x78, 0x80, 0x3c, 0xb2,
+	0x74, 0x1d, 0x5b, 0x0f, 0xfd, 0xb3, 0x03, 0x50, 0xbd, 0x26, 0xef, 0x23, 0xef, 0x16, 0x0c, 0x4f,
+	0x8a, 0x2c, 0x99, 0x9a, 0x2a, 0x71, 0xb0, 0x4a, 0x40, 0x41, 0xc7, 0x88, 0x60, 0xeb, 0x91, 0x4d,
+	0x1b, 0x45, 0xe4, 0xc9, 0xcc, 0x38, 0xc7, 0xd0, 0x97, 0x45, 0xb4, 0x58, 0xf0, 0x02, 0x25, 0x1f,
+	0x04, 0xd6, 0x54, 0xa9, 0xe0, 0x45, 0x91, 0x15, 0x28, 0xf8, 0x20, 0xd0, 0x86, 0x8a, 0x16, 0x96,
+	0x05, 0x93, 0x51, 0x86, 0xa7, 0x4c, 0x5f, 0x4c, 0x60, 0xa1, 0xc3, 0xd5, 0xba, 0xf4, 0xde, 0x23,
+	0x1b, 0xf4, 0xdf, 0x36, 0x0c, 0x50, 0x83, 0x43, 0x2e, 0xd9, 0x87, 0x3f, 0x54, 0xb6, 0xae, 0x9c,
+	0x66, 0x5d, 0xad, 0x3f, 0x53, 0xcd, 0xba, 0x72, 0x57, 0xeb, 0xea, 0x01, 0x6c, 0x2a, 0x5a, 0x95,
+	0x3e, 0x31, 0xee, 0x6d, 0x3b, 0x3b, 0xa3, 0x60, 0x43, 0xc1, 0xcb, 0xd4, 0x89, 0xab, 0x92, 0xdd,
+	0xbf, 0x2a, 0xd9, 0x7b, 0x6f, 0x5d, 0xe8, 0x1d, 0xe9, 0xc5, 0x3e, 0x82, 0xee, 0x51, 0x94, 0x2e,
+	0x48, 0xed, 0xf1, 0xc6, 0x16, 0x74, 0xb2, 0x0a, 0xd0, 0x16, 0x39, 0x80, 0x1b, 0xc7, 0xb2, 0xe0,
+	0x2c, 0x41, 0x91, 0x9e, 0x9f, 0xf3, 0x54, 0x0a, 0x72, 0xd7, 0xb7, 0xcd, 0xae, 0x6f, 0x9c, 0xfc,
+	0x75, 0xc9, 0x85, 0x9c, 0x6c, 0x56, 0x0e, 0xa4, 0xd2, 0xd6, 0x97, 0x6d, 0xf2, 0x1d, 0x78, 0xb6,
+	0x4b, 0x23, 0xb7, 0xab, 0x08, 0xb5, 0x4e, 0x6f, 0x72, 0xe7, 0x32, 0x58, 0xe4, 0xb4, 0x45, 0x7e,
+	0x80, 0x6b, 0xb6, 0x39, 0xfa, 0xc0, 0x19, 0x74, 0x78, 0x7d, 0x43, 0x36, 0x07, 0xdb, 0x16, 0x70,
+	0x72, 0xe7, 0x32, 0x18, 0x07, 0x3f, 0x81, 0xbe, 0x69, 0xca, 0xd7, 0xd5, 0x6a, 0x4e, 0x66, 0x1b,
+	0x77, 0xda, 0x22, 0xbb, 0xe0, 0x62, 0x97, 0xbe, 0x3e, 0xa4, 0xf6, 0x5c, 0x2e, 0xfb, 0x78, 0xda,
+	0x22, 0x3e, 0xb8, 0xd8, 0x17, 0x13, 0x52, 0xf9, 0x6d, 0xa3, 0x7c, 0x59, 0x52, 0x7e, 0xae, 0x44,
+	0xd1, 0x47, 0x77, 0xb2, 0xbe, 0x01, 0xdb, 0x79, 0x4e, 0xee, 0x5d, 0xe9, 0xc3, 0xd8, 0xdf, 0x40,
+	0x4f, 0xf7, 0x80, 0xa4, 0xb6, 0xb8, 0x65, 0x3b, 0x39, 0xb9, 0xbd, 0x0a, 0x62, 0xab, 0x88, 0x79,
+	0xdd, 0x47, 0x61, 0xf5, 0x3b, 0xb6, 0xb6, 0xd3, 0xa6, 0xa4, 0xcb, 0xfe, 0x91, 0xb6, 0xc8, 0xb7,
+	0x00, 0xd8, 0xa2, 0xe9, 0x7e, 0xa2, 0xd6, 0x13, 0x54, 0x0d, 0xe3, 0x64, 0x0d, 0x55, 0x03, 0x68,
+	0x6b, 0xd6, 0xc3, 0xc3, 0xfc, 0xe4, 0xbf, 0x01, 0x00, 0x0b, 0x29, 0xcb, 0x95, 0x9c, 0x0d, 0x00,
+	0x00,
 }
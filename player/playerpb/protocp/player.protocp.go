@@ -1,12 +1,46 @@
 package protocp
 
 import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/luno/jettison/errors"
 	"unsure/player"
 	pb "unsure/player/playerpb"
 )
 
+// v1Statuses pins the integers the first version of the API sends as round
+// statuses, so that they don't change if player.RoundStatus is renumbered.
+var v1Statuses = map[player.RoundStatus]int32{
+	player.RoundStatusJoin:      1,
+	player.RoundStatusJoined:    2,
+	player.RoundStatusCollect:   3,
+	player.RoundStatusCollected: 4,
+	player.RoundStatusSubmit:    5,
+	player.RoundStatusSubmitted: 6,
+	player.RoundStatusSuccess:   7,
+	player.RoundStatusFailed:    8,
+}
+
+// statusToV1 returns the first version's integer for the status, or zero if
+// it has none.
+func statusToV1(st player.RoundStatus) int32 {
+	return v1Statuses[st]
+}
+
+// statusFromV1 returns the status sent as an integer by the first version,
+// or player.RoundStatusUnknown if it isn't known.
+func statusFromV1(v int32) player.RoundStatus {
+	for st, n := range v1Statuses {
+		if n == v {
+			return st
+		}
+	}
+
+	return player.RoundStatusUnknown
+}
+
 // PartFromProto converts a pb.Part to a player.Part.
 func PartFromProto(in *pb.Part) (*player.Part, error) {
 	createdAt, err := ptypes.Timestamp(in.CreatedAt)
@@ -28,6 +62,9 @@ func PartFromProto(in *pb.Part) (*player.Part, error) {
 		Submitted: in.Submitted,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+
+		Signature:          in.Signature,
+		SubmittedSignature: in.SubmittedSignature,
 	}, nil
 }
 
@@ -52,6 +89,9 @@ func PartToProto(in *player.Part) (*pb.Part, error) {
 		Submitted: in.Submitted,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+
+		Signature:          in.Signature,
+		SubmittedSignature: in.SubmittedSignature,
 	}, nil
 }
 
@@ -70,7 +110,7 @@ func RoundFromProto(in *pb.Round) (*player.Round, error) {
 		ID:         in.Id,
 		ExternalID: in.ExternalId,
 		Player:     in.Player,
-		Status:     player.RoundStatus(in.Status),
+		Status:     statusFromV1(in.Status),
 		CreatedAt:  createdAt,
 		UpdatedAt:  updatedAt,
 	}, nil
@@ -91,8 +131,240 @@ func RoundToProto(in *player.Round) (*pb.Round, error) {
 		Id:         in.ID,
 		ExternalId: in.ExternalID,
 		Player:     in.Player,
-		Status:     int32(in.Status),
+		Status:     statusToV1(in.Status),
 		CreatedAt:  createdAt,
 		UpdatedAt:  updatedAt,
 	}, nil
 }
+
+// RoundAuditFromProto converts a pb.RoundAudit to a player.RoundAudit.
+func RoundAuditFromProto(in *pb.RoundAudit) (*player.RoundAudit, error) {
+	createdAt, err := ptypes.Timestamp(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &player.RoundAudit{
+		ID:        in.Id,
+		RoundID:   in.RoundId,
+		From:      statusFromV1(in.FromStatus),
+		To:        statusFromV1(in.ToStatus),
+		Trigger:   in.Trigger,
+		Error:     in.Error,
+		Duration:  time.Duration(in.DurationMs) * time.Millisecond,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// RoundAuditToProto converts a player.RoundAudit to a pb.RoundAudit.
+func RoundAuditToProto(in *player.RoundAudit) (*pb.RoundAudit, error) {
+	createdAt, err := ptypes.TimestampProto(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &pb.RoundAudit{
+		Id:         in.ID,
+		RoundId:    in.RoundID,
+		FromStatus: statusToV1(in.From),
+		ToStatus:   statusToV1(in.To),
+		Trigger:    in.Trigger,
+		Error:      in.Error,
+		DurationMs: int64(in.Duration / time.Millisecond),
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+// RoundMetaFromProto converts a pb.RoundMeta to a player.RoundMeta.
+func RoundMetaFromProto(in *pb.RoundMeta) *player.RoundMeta {
+	return &player.RoundMeta{
+		ExternalID:         in.ExternalId,
+		Player:             in.Player,
+		Rank:               in.Rank,
+		Parts:              in.Parts,
+		PartSignatures:     in.PartSignatures,
+		Submitted:          in.Submitted,
+		SubmittedSignature: in.SubmittedSignature,
+	}
+}
+
+// RoundMetaToProto converts a player.RoundMeta to a pb.RoundMeta.
+func RoundMetaToProto(in *player.RoundMeta) *pb.RoundMeta {
+	return &pb.RoundMeta{
+		ExternalId:         in.ExternalID,
+		Player:             in.Player,
+		Rank:               in.Rank,
+		Parts:              in.Parts,
+		PartSignatures:     in.PartSignatures,
+		Submitted:          in.Submitted,
+		SubmittedSignature: in.SubmittedSignature,
+	}
+}
+
+// HelloFromProto converts a pb.HelloResp to a player.Hello.
+func HelloFromProto(in *pb.HelloResp) *player.Hello {
+	return &player.Hello{
+		PlayerName:      in.PlayerName,
+		TeamName:        in.TeamName,
+		BuildVersion:    in.BuildVersion,
+		ProtocolVersion: int(in.ProtocolVersion),
+		Capabilities:    in.Capabilities,
+	}
+}
+
+// HelloToProto converts a player.Hello to a pb.HelloResp.
+func HelloToProto(in *player.Hello) *pb.HelloResp {
+	return &pb.HelloResp{
+		PlayerName:      in.PlayerName,
+		TeamName:        in.TeamName,
+		BuildVersion:    in.BuildVersion,
+		ProtocolVersion: int32(in.ProtocolVersion),
+		Capabilities:    in.Capabilities,
+	}
+}
+
+// TotalClaimFromProto converts a pb.TotalClaim to a player.TotalClaim.
+func TotalClaimFromProto(in *pb.TotalClaim) player.TotalClaim {
+	return player.TotalClaim{
+		ExternalID: in.ExternalId,
+		Player:     in.Player,
+		Total:      in.Total,
+		Parts:      in.Parts,
+	}
+}
+
+// TotalClaimToProto converts a player.TotalClaim to a pb.TotalClaim.
+func TotalClaimToProto(in player.TotalClaim) *pb.TotalClaim {
+	return &pb.TotalClaim{
+		ExternalId: in.ExternalID,
+		Player:     in.Player,
+		Total:      in.Total,
+		Parts:      in.Parts,
+	}
+}
+
+// TotalCheckFromProto converts a pb.TotalCheck to a player.TotalCheck.
+func TotalCheckFromProto(in *pb.TotalCheck) *player.TotalCheck {
+	return &player.TotalCheck{
+		Peer:   in.Peer,
+		Known:  in.Known,
+		Agreed: in.Agreed,
+		Total:  in.Total,
+		Parts:  in.Parts,
+	}
+}
+
+// TotalCheckToProto converts a player.TotalCheck to a pb.TotalCheck.
+func TotalCheckToProto(in *player.TotalCheck) *pb.TotalCheck {
+	return &pb.TotalCheck{
+		Peer:   in.Peer,
+		Known:  in.Known,
+		Agreed: in.Agreed,
+		Total:  in.Total,
+		Parts:  in.Parts,
+	}
+}
+
+// StatsFromProto converts a pb.Stats to a player.Stats.
+func StatsFromProto(in *pb.Stats) *player.Stats {
+	res := player.Stats{
+		Succeeded:      in.Succeeded,
+		Failed:         in.Failed,
+		Excluded:       in.Excluded,
+		Parts:          in.Parts,
+		PartsTotal:     in.PartsTotal,
+		SubmittedTotal: in.SubmittedTotal,
+	}
+
+	for _, ps := range in.Phases {
+		res.Phases = append(res.Phases, player.PhaseStats{
+			Status: statusFromV1(ps.Status),
+			Count:  ps.Count,
+			Mean:   time.Duration(ps.MeanMs) * time.Millisecond,
+			P95:    time.Duration(ps.P95Ms) * time.Millisecond,
+		})
+	}
+
+	for _, ps := range in.Peers {
+		res.Peers = append(res.Peers, player.PeerStats{
+			Player:          ps.Player,
+			LateSubmissions: ps.LateSubmissions,
+		})
+	}
+
+	for _, ms := range in.Matches {
+		res.Matches = append(res.Matches, player.MatchStats{
+			MatchID:   ms.MatchId,
+			Succeeded: ms.Succeeded,
+			Failed:    ms.Failed,
+			Excluded:  ms.Excluded,
+		})
+	}
+
+	return &res
+}
+
+// StatsToProto converts a player.Stats to a pb.Stats.
+func StatsToProto(in *player.Stats) *pb.Stats {
+	res := pb.Stats{
+		Succeeded:      in.Succeeded,
+		Failed:         in.Failed,
+		Excluded:       in.Excluded,
+		Parts:          in.Parts,
+		PartsTotal:     in.PartsTotal,
+		SubmittedTotal: in.SubmittedTotal,
+	}
+
+	for _, ps := range in.Phases {
+		res.Phases = append(res.Phases, &pb.PhaseStats{
+			Status: statusToV1(ps.Status),
+			Count:  ps.Count,
+			MeanMs: int64(ps.Mean / time.Millisecond),
+			P95Ms:  int64(ps.P95 / time.Millisecond),
+		})
+	}
+
+	for _, ps := range in.Peers {
+		res.Peers = append(res.Peers, &pb.PeerStats{
+			Player:          ps.Player,
+			LateSubmissions: ps.LateSubmissions,
+		})
+	}
+
+	for _, ms := range in.Matches {
+		res.Matches = append(res.Matches, &pb.MatchStats{
+			MatchId:   ms.MatchID,
+			Succeeded: ms.Succeeded,
+			Failed:    ms.Failed,
+			Excluded:  ms.Excluded,
+		})
+	}
+
+	return &res
+}
+
+// MarshalRoundMeta encodes a player.RoundMeta as reflex event metadata.
+func MarshalRoundMeta(in *player.RoundMeta) ([]byte, error) {
+	b, err := proto.Marshal(RoundMetaToProto(in))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal round meta")
+	}
+
+	return b, nil
+}
+
+// UnmarshalRoundMeta decodes reflex event metadata into a player.RoundMeta.
+// It returns nil if the event carries no metadata.
+func UnmarshalRoundMeta(b []byte) (*player.RoundMeta, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	var meta pb.RoundMeta
+	err := proto.Unmarshal(b, &meta)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal round meta")
+	}
+
+	return RoundMetaFromProto(&meta), nil
+}
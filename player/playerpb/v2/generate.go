@@ -0,0 +1,5 @@
+package playerpb
+
+// Generated from the parent directory so that the file is registered as
+// v2/player.proto rather than clashing with the first version's player.proto.
+//go:generate sh -c "cd .. && protoc --go_out=plugins=grpc:. ./v2/player.proto"
@@ -0,0 +1,1899 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: v2/player.proto
+
+package playerpb
+
+/*
+Package playerpb.v2 is the second version of the Player API. It mirrors
+the first, but round statuses, including the types of round events, are
+sent as the RoundStatus enum rather than raw integers so that
+player.RoundStatus can be renumbered without breaking peers on other
+builds. Round event metadata remains encoded as the first
+version's RoundMeta.
+*/
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+import reflexpb "github.com/luno/reflex/reflexpb"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type RoundStatus int32
+
+const (
+	RoundStatus_ROUND_STATUS_UNKNOWN   RoundStatus = 0
+	RoundStatus_ROUND_STATUS_JOIN      RoundStatus = 1
+	RoundStatus_ROUND_STATUS_JOINED    RoundStatus = 2
+	RoundStatus_ROUND_STATUS_COLLECT   RoundStatus = 3
+	RoundStatus_ROUND_STATUS_COLLECTED RoundStatus = 4
+	RoundStatus_ROUND_STATUS_SUBMIT    RoundStatus = 5
+	RoundStatus_ROUND_STATUS_SUBMITTED RoundStatus = 6
+	RoundStatus_ROUND_STATUS_SUCCESS   RoundStatus = 7
+	RoundStatus_ROUND_STATUS_FAILED    RoundStatus = 8
+)
+
+var RoundStatus_name = map[int32]string{
+	0: "ROUND_STATUS_UNKNOWN",
+	1: "ROUND_STATUS_JOIN",
+	2: "ROUND_STATUS_JOINED",
+	3: "ROUND_STATUS_COLLECT",
+	4: "ROUND_STATUS_COLLECTED",
+	5: "ROUND_STATUS_SUBMIT",
+	6: "ROUND_STATUS_SUBMITTED",
+	7: "ROUND_STATUS_SUCCESS",
+	8: "ROUND_STATUS_FAILED",
+}
+var RoundStatus_value = map[string]int32{
+	"ROUND_STATUS_UNKNOWN":   0,
+	"ROUND_STATUS_JOIN":      1,
+	"ROUND_STATUS_JOINED":    2,
+	"ROUND_STATUS_COLLECT":   3,
+	"ROUND_STATUS_COLLECTED": 4,
+	"ROUND_STATUS_SUBMIT":    5,
+	"ROUND_STATUS_SUBMITTED": 6,
+	"ROUND_STATUS_SUCCESS":   7,
+	"ROUND_STATUS_FAILED":    8,
+}
+
+func (x RoundStatus) String() string {
+	return proto.EnumName(RoundStatus_name, int32(x))
+}
+func (RoundStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{0}
+}
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{0}
+}
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (dst *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(dst, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+type GetNameResp struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNameResp) Reset()         { *m = GetNameResp{} }
+func (m *GetNameResp) String() string { return proto.CompactTextString(m) }
+func (*GetNameResp) ProtoMessage()    {}
+func (*GetNameResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{1}
+}
+func (m *GetNameResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNameResp.Unmarshal(m, b)
+}
+func (m *GetNameResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNameResp.Marshal(b, m, deterministic)
+}
+func (dst *GetNameResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNameResp.Merge(dst, src)
+}
+func (m *GetNameResp) XXX_Size() int {
+	return xxx_messageInfo_GetNameResp.Size(m)
+}
+func (m *GetNameResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNameResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNameResp proto.InternalMessageInfo
+
+func (m *GetNameResp) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type HelloResp struct {
+	PlayerName           string   `protobuf:"bytes,1,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	TeamName             string   `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	BuildVersion         string   `protobuf:"bytes,3,opt,name=build_version,json=buildVersion,proto3" json:"build_version,omitempty"`
+	ProtocolVersion      int32    `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities         []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HelloResp) Reset()         { *m = HelloResp{} }
+func (m *HelloResp) String() string { return proto.CompactTextString(m) }
+func (*HelloResp) ProtoMessage()    {}
+func (*HelloResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{2}
+}
+func (m *HelloResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HelloResp.Unmarshal(m, b)
+}
+func (m *HelloResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HelloResp.Marshal(b, m, deterministic)
+}
+func (dst *HelloResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HelloResp.Merge(dst, src)
+}
+func (m *HelloResp) XXX_Size() int {
+	return xxx_messageInfo_HelloResp.Size(m)
+}
+func (m *HelloResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_HelloResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HelloResp proto.InternalMessageInfo
+
+func (m *HelloResp) GetPlayerName() string {
+	if m != nil {
+		return m.PlayerName
+	}
+	return ""
+}
+
+func (m *HelloResp) GetTeamName() string {
+	if m != nil {
+		return m.TeamName
+	}
+	return ""
+}
+
+func (m *HelloResp) GetBuildVersion() string {
+	if m != nil {
+		return m.BuildVersion
+	}
+	return ""
+}
+
+func (m *HelloResp) GetProtocolVersion() int32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *HelloResp) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type LeaveReq struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaveReq) Reset()         { *m = LeaveReq{} }
+func (m *LeaveReq) String() string { return proto.CompactTextString(m) }
+func (*LeaveReq) ProtoMessage()    {}
+func (*LeaveReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{3}
+}
+func (m *LeaveReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaveReq.Unmarshal(m, b)
+}
+func (m *LeaveReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaveReq.Marshal(b, m, deterministic)
+}
+func (dst *LeaveReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveReq.Merge(dst, src)
+}
+func (m *LeaveReq) XXX_Size() int {
+	return xxx_messageInfo_LeaveReq.Size(m)
+}
+func (m *LeaveReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveReq proto.InternalMessageInfo
+
+func (m *LeaveReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetPartsReq struct {
+	ExternalId           int64    `protobuf:"varint,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPartsReq) Reset()         { *m = GetPartsReq{} }
+func (m *GetPartsReq) String() string { return proto.CompactTextString(m) }
+func (*GetPartsReq) ProtoMessage()    {}
+func (*GetPartsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{4}
+}
+func (m *GetPartsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPartsReq.Unmarshal(m, b)
+}
+func (m *GetPartsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPartsReq.Marshal(b, m, deterministic)
+}
+func (dst *GetPartsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPartsReq.Merge(dst, src)
+}
+func (m *GetPartsReq) XXX_Size() int {
+	return xxx_messageInfo_GetPartsReq.Size(m)
+}
+func (m *GetPartsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPartsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPartsReq proto.InternalMessageInfo
+
+func (m *GetPartsReq) GetExternalId() int64 {
+	if m != nil {
+		return m.ExternalId
+	}
+	return 0
+}
+
+type GetPartsResp struct {
+	Parts                []*Part  `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPartsResp) Reset()         { *m = GetPartsResp{} }
+func (m *GetPartsResp) String() string { return proto.CompactTextString(m) }
+func (*GetPartsResp) ProtoMessage()    {}
+func (*GetPartsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{5}
+}
+func (m *GetPartsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPartsResp.Unmarshal(m, b)
+}
+func (m *GetPartsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPartsResp.Marshal(b, m, deterministic)
+}
+func (dst *GetPartsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPartsResp.Merge(dst, src)
+}
+func (m *GetPartsResp) XXX_Size() int {
+	return xxx_messageInfo_GetPartsResp.Size(m)
+}
+func (m *GetPartsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPartsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPartsResp proto.InternalMessageInfo
+
+func (m *GetPartsResp) GetParts() []*Part {
+	if m != nil {
+		return m.Parts
+	}
+	return nil
+}
+
+type GetRoundReq struct {
+	RoundId              int64    `protobuf:"varint,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRoundReq) Reset()         { *m = GetRoundReq{} }
+func (m *GetRoundReq) String() string { return proto.CompactTextString(m) }
+func (*GetRoundReq) ProtoMessage()    {}
+func (*GetRoundReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{6}
+}
+func (m *GetRoundReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoundReq.Unmarshal(m, b)
+}
+func (m *GetRoundReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoundReq.Marshal(b, m, deterministic)
+}
+func (dst *GetRoundReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoundReq.Merge(dst, src)
+}
+func (m *GetRoundReq) XXX_Size() int {
+	return xxx_messageInfo_GetRoundReq.Size(m)
+}
+func (m *GetRoundReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoundReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoundReq proto.InternalMessageInfo
+
+func (m *GetRoundReq) GetRoundId() int64 {
+	if m != nil {
+		return m.RoundId
+	}
+	return 0
+}
+
+type GetRoundResp struct {
+	Round                *Round   `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRoundResp) Reset()         { *m = GetRoundResp{} }
+func (m *GetRoundResp) String() string { return proto.CompactTextString(m) }
+func (*GetRoundResp) ProtoMessage()    {}
+func (*GetRoundResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{7}
+}
+func (m *GetRoundResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoundResp.Unmarshal(m, b)
+}
+func (m *GetRoundResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoundResp.Marshal(b, m, deterministic)
+}
+func (dst *GetRoundResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoundResp.Merge(dst, src)
+}
+func (m *GetRoundResp) XXX_Size() int {
+	return xxx_messageInfo_GetRoundResp.Size(m)
+}
+func (m *GetRoundResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoundResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoundResp proto.InternalMessageInfo
+
+func (m *GetRoundResp) GetRound() *Round {
+	if m != nil {
+		return m.Round
+	}
+	return nil
+}
+
+type GetRoundAuditReq struct {
+	RoundId              int64    `protobuf:"varint,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRoundAuditReq) Reset()         { *m = GetRoundAuditReq{} }
+func (m *GetRoundAuditReq) String() string { return proto.CompactTextString(m) }
+func (*GetRoundAuditReq) ProtoMessage()    {}
+func (*GetRoundAuditReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{8}
+}
+func (m *GetRoundAuditReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoundAuditReq.Unmarshal(m, b)
+}
+func (m *GetRoundAuditReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoundAuditReq.Marshal(b, m, deterministic)
+}
+func (dst *GetRoundAuditReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoundAuditReq.Merge(dst, src)
+}
+func (m *GetRoundAuditReq) XXX_Size() int {
+	return xxx_messageInfo_GetRoundAuditReq.Size(m)
+}
+func (m *GetRoundAuditReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoundAuditReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoundAuditReq proto.InternalMessageInfo
+
+func (m *GetRoundAuditReq) GetRoundId() int64 {
+	if m != nil {
+		return m.RoundId
+	}
+	return 0
+}
+
+type GetRoundAuditResp struct {
+	Audit                []*RoundAudit `protobuf:"bytes,1,rep,name=audit,proto3" json:"audit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetRoundAuditResp) Reset()         { *m = GetRoundAuditResp{} }
+func (m *GetRoundAuditResp) String() string { return proto.CompactTextString(m) }
+func (*GetRoundAuditResp) ProtoMessage()    {}
+func (*GetRoundAuditResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{9}
+}
+func (m *GetRoundAuditResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoundAuditResp.Unmarshal(m, b)
+}
+func (m *GetRoundAuditResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoundAuditResp.Marshal(b, m, deterministic)
+}
+func (dst *GetRoundAuditResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoundAuditResp.Merge(dst, src)
+}
+func (m *GetRoundAuditResp) XXX_Size() int {
+	return xxx_messageInfo_GetRoundAuditResp.Size(m)
+}
+func (m *GetRoundAuditResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoundAuditResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoundAuditResp proto.InternalMessageInfo
+
+func (m *GetRoundAuditResp) GetAudit() []*RoundAudit {
+	if m != nil {
+		return m.Audit
+	}
+	return nil
+}
+
+type ExportReq struct {
+	Format               string   `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportReq) Reset()         { *m = ExportReq{} }
+func (m *ExportReq) String() string { return proto.CompactTextString(m) }
+func (*ExportReq) ProtoMessage()    {}
+func (*ExportReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{10}
+}
+func (m *ExportReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportReq.Unmarshal(m, b)
+}
+func (m *ExportReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportReq.Marshal(b, m, deterministic)
+}
+func (dst *ExportReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportReq.Merge(dst, src)
+}
+func (m *ExportReq) XXX_Size() int {
+	return xxx_messageInfo_ExportReq.Size(m)
+}
+func (m *ExportReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportReq proto.InternalMessageInfo
+
+func (m *ExportReq) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *ExportReq) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+type ExportChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportChunk) Reset()         { *m = ExportChunk{} }
+func (m *ExportChunk) String() string { return proto.CompactTextString(m) }
+func (*ExportChunk) ProtoMessage()    {}
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{11}
+}
+func (m *ExportChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportChunk.Unmarshal(m, b)
+}
+func (m *ExportChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportChunk.Marshal(b, m, deterministic)
+}
+func (dst *ExportChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportChunk.Merge(dst, src)
+}
+func (m *ExportChunk) XXX_Size() int {
+	return xxx_messageInfo_ExportChunk.Size(m)
+}
+func (m *ExportChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportChunk proto.InternalMessageInfo
+
+func (m *ExportChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetStatsResp struct {
+	Stats                *Stats   `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatsResp) Reset()         { *m = GetStatsResp{} }
+func (m *GetStatsResp) String() string { return proto.CompactTextString(m) }
+func (*GetStatsResp) ProtoMessage()    {}
+func (*GetStatsResp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{12}
+}
+func (m *GetStatsResp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatsResp.Unmarshal(m, b)
+}
+func (m *GetStatsResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatsResp.Marshal(b, m, deterministic)
+}
+func (dst *GetStatsResp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatsResp.Merge(dst, src)
+}
+func (m *GetStatsResp) XXX_Size() int {
+	return xxx_messageInfo_GetStatsResp.Size(m)
+}
+func (m *GetStatsResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatsResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatsResp proto.InternalMessageInfo
+
+func (m *GetStatsResp) GetStats() *Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type TotalClaim struct {
+	ExternalId           int64    `protobuf:"varint,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Player               string   `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	Total                int64    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Parts                []int64  `protobuf:"varint,4,rep,packed,name=parts,proto3" json:"parts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TotalClaim) Reset()         { *m = TotalClaim{} }
+func (m *TotalClaim) String() string { return proto.CompactTextString(m) }
+func (*TotalClaim) ProtoMessage()    {}
+func (*TotalClaim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{13}
+}
+func (m *TotalClaim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TotalClaim.Unmarshal(m, b)
+}
+func (m *TotalClaim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TotalClaim.Marshal(b, m, deterministic)
+}
+func (dst *TotalClaim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TotalClaim.Merge(dst, src)
+}
+func (m *TotalClaim) XXX_Size() int {
+	return xxx_messageInfo_TotalClaim.Size(m)
+}
+func (m *TotalClaim) XXX_DiscardUnknown() {
+	xxx_messageInfo_TotalClaim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TotalClaim proto.InternalMessageInfo
+
+func (m *TotalClaim) GetExternalId() int64 {
+	if m != nil {
+		return m.ExternalId
+	}
+	return 0
+}
+
+func (m *TotalClaim) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *TotalClaim) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *TotalClaim) GetParts() []int64 {
+	if m != nil {
+		return m.Parts
+	}
+	return nil
+}
+
+type TotalCheck struct {
+	Peer                 string   `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Known                bool     `protobuf:"varint,2,opt,name=known,proto3" json:"known,omitempty"`
+	Agreed               bool     `protobuf:"varint,3,opt,name=agreed,proto3" json:"agreed,omitempty"`
+	Total                int64    `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Parts                []int64  `protobuf:"varint,5,rep,packed,name=parts,proto3" json:"parts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TotalCheck) Reset()         { *m = TotalCheck{} }
+func (m *TotalCheck) String() string { return proto.CompactTextString(m) }
+func (*TotalCheck) ProtoMessage()    {}
+func (*TotalCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{14}
+}
+func (m *TotalCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TotalCheck.Unmarshal(m, b)
+}
+func (m *TotalCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TotalCheck.Marshal(b, m, deterministic)
+}
+func (dst *TotalCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TotalCheck.Merge(dst, src)
+}
+func (m *TotalCheck) XXX_Size() int {
+	return xxx_messageInfo_TotalCheck.Size(m)
+}
+func (m *TotalCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_TotalCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TotalCheck proto.InternalMessageInfo
+
+func (m *TotalCheck) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *TotalCheck) GetKnown() bool {
+	if m != nil {
+		return m.Known
+	}
+	return false
+}
+
+func (m *TotalCheck) GetAgreed() bool {
+	if m != nil {
+		return m.Agreed
+	}
+	return false
+}
+
+func (m *TotalCheck) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *TotalCheck) GetParts() []int64 {
+	if m != nil {
+		return m.Parts
+	}
+	return nil
+}
+
+type Stats struct {
+	Succeeded            int64         `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed               int64         `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Excluded             int64         `protobuf:"varint,3,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	Phases               []*PhaseStats `protobuf:"bytes,4,rep,name=phases,proto3" json:"phases,omitempty"`
+	Parts                int64         `protobuf:"varint,5,opt,name=parts,proto3" json:"parts,omitempty"`
+	PartsTotal           int64         `protobuf:"varint,6,opt,name=parts_total,json=partsTotal,proto3" json:"parts_total,omitempty"`
+	SubmittedTotal       int64         `protobuf:"varint,7,opt,name=submitted_total,json=submittedTotal,proto3" json:"submitted_total,omitempty"`
+	Peers                []*PeerStats  `protobuf:"bytes,8,rep,name=peers,proto3" json:"peers,omitempty"`
+	Matches              []*MatchStats `protobuf:"bytes,9,rep,name=matches,proto3" json:"matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Stats) Reset()         { *m = Stats{} }
+func (m *Stats) String() string { return proto.CompactTextString(m) }
+func (*Stats) ProtoMessage()    {}
+func (*Stats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{15}
+}
+func (m *Stats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Stats.Unmarshal(m, b)
+}
+func (m *Stats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Stats.Marshal(b, m, deterministic)
+}
+func (dst *Stats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Stats.Merge(dst, src)
+}
+func (m *Stats) XXX_Size() int {
+	return xxx_messageInfo_Stats.Size(m)
+}
+func (m *Stats) XXX_DiscardUnknown() {
+	xxx_messageInfo_Stats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Stats proto.InternalMessageInfo
+
+func (m *Stats) GetSucceeded() int64 {
+	if m != nil {
+		return m.Succeeded
+	}
+	return 0
+}
+
+func (m *Stats) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *Stats) GetExcluded() int64 {
+	if m != nil {
+		return m.Excluded
+	}
+	return 0
+}
+
+func (m *Stats) GetPhases() []*PhaseStats {
+	if m != nil {
+		return m.Phases
+	}
+	return nil
+}
+
+func (m *Stats) GetParts() int64 {
+	if m != nil {
+		return m.Parts
+	}
+	return 0
+}
+
+func (m *Stats) GetPartsTotal() int64 {
+	if m != nil {
+		return m.PartsTotal
+	}
+	return 0
+}
+
+func (m *Stats) GetSubmittedTotal() int64 {
+	if m != nil {
+		return m.SubmittedTotal
+	}
+	return 0
+}
+
+func (m *Stats) GetPeers() []*PeerStats {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *Stats) GetMatches() []*MatchStats {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+type MatchStats struct {
+	MatchId              int64    `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Succeeded            int64    `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed               int64    `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Excluded             int64    `protobuf:"varint,4,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatchStats) Reset()         { *m = MatchStats{} }
+func (m *MatchStats) String() string { return proto.CompactTextString(m) }
+func (*MatchStats) ProtoMessage()    {}
+func (*MatchStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{16}
+}
+func (m *MatchStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchStats.Unmarshal(m, b)
+}
+func (m *MatchStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchStats.Marshal(b, m, deterministic)
+}
+func (dst *MatchStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchStats.Merge(dst, src)
+}
+func (m *MatchStats) XXX_Size() int {
+	return xxx_messageInfo_MatchStats.Size(m)
+}
+func (m *MatchStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchStats proto.InternalMessageInfo
+
+func (m *MatchStats) GetMatchId() int64 {
+	if m != nil {
+		return m.MatchId
+	}
+	return 0
+}
+
+func (m *MatchStats) GetSucceeded() int64 {
+	if m != nil {
+		return m.Succeeded
+	}
+	return 0
+}
+
+func (m *MatchStats) GetFailed() int64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *MatchStats) GetExcluded() int64 {
+	if m != nil {
+		return m.Excluded
+	}
+	return 0
+}
+
+type PhaseStats struct {
+	Status               RoundStatus `protobuf:"varint,1,opt,name=status,proto3,enum=playerpb.v2.RoundStatus" json:"status,omitempty"`
+	Count                int64       `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	MeanMs               int64       `protobuf:"varint,3,opt,name=mean_ms,json=meanMs,proto3" json:"mean_ms,omitempty"`
+	P95Ms                int64       `protobuf:"varint,4,opt,name=p95_ms,json=p95Ms,proto3" json:"p95_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PhaseStats) Reset()         { *m = PhaseStats{} }
+func (m *PhaseStats) String() string { return proto.CompactTextString(m) }
+func (*PhaseStats) ProtoMessage()    {}
+func (*PhaseStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{17}
+}
+func (m *PhaseStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PhaseStats.Unmarshal(m, b)
+}
+func (m *PhaseStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PhaseStats.Marshal(b, m, deterministic)
+}
+func (dst *PhaseStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PhaseStats.Merge(dst, src)
+}
+func (m *PhaseStats) XXX_Size() int {
+	return xxx_messageInfo_PhaseStats.Size(m)
+}
+func (m *PhaseStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PhaseStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PhaseStats proto.InternalMessageInfo
+
+func (m *PhaseStats) GetStatus() RoundStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RoundStatus_ROUND_STATUS_UNKNOWN
+}
+
+func (m *PhaseStats) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PhaseStats) GetMeanMs() int64 {
+	if m != nil {
+		return m.MeanMs
+	}
+	return 0
+}
+
+func (m *PhaseStats) GetP95Ms() int64 {
+	if m != nil {
+		return m.P95Ms
+	}
+	return 0
+}
+
+type PeerStats struct {
+	Player               string   `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	LateSubmissions      int64    `protobuf:"varint,2,opt,name=late_submissions,json=lateSubmissions,proto3" json:"late_submissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerStats) Reset()         { *m = PeerStats{} }
+func (m *PeerStats) String() string { return proto.CompactTextString(m) }
+func (*PeerStats) ProtoMessage()    {}
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{18}
+}
+func (m *PeerStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerStats.Unmarshal(m, b)
+}
+func (m *PeerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerStats.Marshal(b, m, deterministic)
+}
+func (dst *PeerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStats.Merge(dst, src)
+}
+func (m *PeerStats) XXX_Size() int {
+	return xxx_messageInfo_PeerStats.Size(m)
+}
+func (m *PeerStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerStats proto.InternalMessageInfo
+
+func (m *PeerStats) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *PeerStats) GetLateSubmissions() int64 {
+	if m != nil {
+		return m.LateSubmissions
+	}
+	return 0
+}
+
+type Round struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId           int64                `protobuf:"varint,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Player               string               `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
+	Status               RoundStatus          `protobuf:"varint,4,opt,name=status,proto3,enum=playerpb.v2.RoundStatus" json:"status,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Round) Reset()         { *m = Round{} }
+func (m *Round) String() string { return proto.CompactTextString(m) }
+func (*Round) ProtoMessage()    {}
+func (*Round) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{19}
+}
+func (m *Round) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Round.Unmarshal(m, b)
+}
+func (m *Round) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Round.Marshal(b, m, deterministic)
+}
+func (dst *Round) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Round.Merge(dst, src)
+}
+func (m *Round) XXX_Size() int {
+	return xxx_messageInfo_Round.Size(m)
+}
+func (m *Round) XXX_DiscardUnknown() {
+	xxx_messageInfo_Round.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Round proto.InternalMessageInfo
+
+func (m *Round) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Round) GetExternalId() int64 {
+	if m != nil {
+		return m.ExternalId
+	}
+	return 0
+}
+
+func (m *Round) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *Round) GetStatus() RoundStatus {
+	if m != nil {
+		return m.Status
+	}
+	return RoundStatus_ROUND_STATUS_UNKNOWN
+}
+
+func (m *Round) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Round) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type Part struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoundId              int64                `protobuf:"varint,2,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Player               string               `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`
+	Rank                 int64                `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	Value                int64                `protobuf:"varint,5,opt,name=value,proto3" json:"value,omitempty"`
+	Submitted            bool                 `protobuf:"varint,6,opt,name=submitted,proto3" json:"submitted,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Signature            []byte               `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	SubmittedSignature   []byte               `protobuf:"bytes,10,opt,name=submitted_signature,json=submittedSignature,proto3" json:"submitted_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Part) Reset()         { *m = Part{} }
+func (m *Part) String() string { return proto.CompactTextString(m) }
+func (*Part) ProtoMessage()    {}
+func (*Part) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{20}
+}
+func (m *Part) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Part.Unmarshal(m, b)
+}
+func (m *Part) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Part.Marshal(b, m, deterministic)
+}
+func (dst *Part) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Part.Merge(dst, src)
+}
+func (m *Part) XXX_Size() int {
+	return xxx_messageInfo_Part.Size(m)
+}
+func (m *Part) XXX_DiscardUnknown() {
+	xxx_messageInfo_Part.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Part proto.InternalMessageInfo
+
+func (m *Part) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Part) GetRoundId() int64 {
+	if m != nil {
+		return m.RoundId
+	}
+	return 0
+}
+
+func (m *Part) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *Part) GetRank() int64 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *Part) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Part) GetSubmitted() bool {
+	if m != nil {
+		return m.Submitted
+	}
+	return false
+}
+
+func (m *Part) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Part) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Part) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Part) GetSubmittedSignature() []byte {
+	if m != nil {
+		return m.SubmittedSignature
+	}
+	return nil
+}
+
+type RoundAudit struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoundId              int64                `protobuf:"varint,2,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	FromStatus           RoundStatus          `protobuf:"varint,3,opt,name=from_status,json=fromStatus,proto3,enum=playerpb.v2.RoundStatus" json:"from_status,omitempty"`
+	ToStatus             RoundStatus          `protobuf:"varint,4,opt,name=to_status,json=toStatus,proto3,enum=playerpb.v2.RoundStatus" json:"to_status,omitempty"`
+	Trigger              string               `protobuf:"bytes,5,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Error                string               `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs           int64                `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RoundAudit) Reset()         { *m = RoundAudit{} }
+func (m *RoundAudit) String() string { return proto.CompactTextString(m) }
+func (*RoundAudit) ProtoMessage()    {}
+func (*RoundAudit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_player_e4f9eda4b02bd28d, []int{21}
+}
+func (m *RoundAudit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoundAudit.Unmarshal(m, b)
+}
+func (m *RoundAudit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoundAudit.Marshal(b, m, deterministic)
+}
+func (dst *RoundAudit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundAudit.Merge(dst, src)
+}
+func (m *RoundAudit) XXX_Size() int {
+	return xxx_messageInfo_RoundAudit.Size(m)
+}
+func (m *RoundAudit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundAudit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundAudit proto.InternalMessageInfo
+
+func (m *RoundAudit) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RoundAudit) GetRoundId() int64 {
+	if m != nil {
+		return m.RoundId
+	}
+	return 0
+}
+
+func (m *RoundAudit) GetFromStatus() RoundStatus {
+	if m != nil {
+		return m.FromStatus
+	}
+	return RoundStatus_ROUND_STATUS_UNKNOWN
+}
+
+func (m *RoundAudit) GetToStatus() RoundStatus {
+	if m != nil {
+		return m.ToStatus
+	}
+	return RoundStatus_ROUND_STATUS_UNKNOWN
+}
+
+func (m *RoundAudit) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
+	}
+	return ""
+}
+
+func (m *RoundAudit) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *RoundAudit) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *RoundAudit) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "playerpb.v2.Empty")
+	proto.RegisterType((*GetNameResp)(nil), "playerpb.v2.GetNameResp")
+	proto.RegisterType((*HelloResp)(nil), "playerpb.v2.HelloResp")
+	proto.RegisterType((*LeaveReq)(nil), "playerpb.v2.LeaveReq")
+	proto.RegisterType((*GetPartsReq)(nil), "playerpb.v2.GetPartsReq")
+	proto.RegisterType((*GetPartsResp)(nil), "playerpb.v2.GetPartsResp")
+	proto.RegisterType((*GetRoundReq)(nil), "playerpb.v2.GetRoundReq")
+	proto.RegisterType((*GetRoundResp)(nil), "playerpb.v2.GetRoundResp")
+	proto.RegisterType((*GetRoundAuditReq)(nil), "playerpb.v2.GetRoundAuditReq")
+	proto.RegisterType((*GetRoundAuditResp)(nil), "playerpb.v2.GetRoundAuditResp")
+	proto.RegisterType((*ExportReq)(nil), "playerpb.v2.ExportReq")
+	proto.RegisterType((*ExportChunk)(nil), "playerpb.v2.ExportChunk")
+	proto.RegisterType((*GetStatsResp)(nil), "playerpb.v2.GetStatsResp")
+	proto.RegisterType((*TotalClaim)(nil), "playerpb.v2.TotalClaim")
+	proto.RegisterType((*TotalCheck)(nil), "playerpb.v2.TotalCheck")
+	proto.RegisterType((*Stats)(nil), "playerpb.v2.Stats")
+	proto.RegisterType((*MatchStats)(nil), "playerpb.v2.MatchStats")
+	proto.RegisterType((*PhaseStats)(nil), "playerpb.v2.PhaseStats")
+	proto.RegisterType((*PeerStats)(nil), "playerpb.v2.PeerStats")
+	proto.RegisterType((*Round)(nil), "playerpb.v2.Round")
+	proto.RegisterType((*Part)(nil), "playerpb.v2.Part")
+	proto.RegisterType((*RoundAudit)(nil), "playerpb.v2.RoundAudit")
+	proto.RegisterEnum("playerpb.v2.RoundStatus", RoundStatus_name, RoundStatus_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PlayerClient is the client API for Player service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PlayerClient interface {
+	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	StreamRoundEvents(ctx context.Context, in *reflexpb.StreamRequest, opts ...grpc.CallOption) (Player_StreamRoundEventsClient, error)
+	GetParts(ctx context.Context, in *GetPartsReq, opts ...grpc.CallOption) (*GetPartsResp, error)
+	GetRoundParts(ctx context.Context, in *GetPartsReq, opts ...grpc.CallOption) (*GetPartsResp, error)
+	GetRound(ctx context.Context, in *GetRoundReq, opts ...grpc.CallOption) (*GetRoundResp, error)
+	GetName(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetNameResp, error)
+	Hello(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HelloResp, error)
+	Leave(ctx context.Context, in *LeaveReq, opts ...grpc.CallOption) (*Empty, error)
+	GetRoundAudit(ctx context.Context, in *GetRoundAuditReq, opts ...grpc.CallOption) (*GetRoundAuditResp, error)
+	Export(ctx context.Context, in *ExportReq, opts ...grpc.CallOption) (Player_ExportClient, error)
+	GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetStatsResp, error)
+	CheckTotal(ctx context.Context, in *TotalClaim, opts ...grpc.CallOption) (*TotalCheck, error)
+}
+
+type playerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPlayerClient(cc *grpc.ClientConn) PlayerClient {
+	return &playerClient{cc}
+}
+
+func (c *playerClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) StreamRoundEvents(ctx context.Context, in *reflexpb.StreamRequest, opts ...grpc.CallOption) (Player_StreamRoundEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Player_serviceDesc.Streams[0], "/playerpb.v2.Player/StreamRoundEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playerStreamRoundEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Player_StreamRoundEventsClient interface {
+	Recv() (*reflexpb.Event, error)
+	grpc.ClientStream
+}
+
+type playerStreamRoundEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *playerStreamRoundEventsClient) Recv() (*reflexpb.Event, error) {
+	m := new(reflexpb.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playerClient) GetParts(ctx context.Context, in *GetPartsReq, opts ...grpc.CallOption) (*GetPartsResp, error) {
+	out := new(GetPartsResp)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/GetParts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetRoundParts(ctx context.Context, in *GetPartsReq, opts ...grpc.CallOption) (*GetPartsResp, error) {
+	out := new(GetPartsResp)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/GetRoundParts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetRound(ctx context.Context, in *GetRoundReq, opts ...grpc.CallOption) (*GetRoundResp, error) {
+	out := new(GetRoundResp)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/GetRound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetName(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetNameResp, error) {
+	out := new(GetNameResp)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/GetName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Hello(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HelloResp, error) {
+	out := new(HelloResp)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/Hello", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Leave(ctx context.Context, in *LeaveReq, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) GetRoundAudit(ctx context.Context, in *GetRoundAuditReq, opts ...grpc.CallOption) (*GetRoundAuditResp, error) {
+	out := new(GetRoundAuditResp)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/GetRoundAudit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Export(ctx context.Context, in *ExportReq, opts ...grpc.CallOption) (Player_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Player_serviceDesc.Streams[1], "/playerpb.v2.Player/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playerExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Player_ExportClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type playerExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *playerExportClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playerClient) GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetStatsResp, error) {
+	out := new(GetStatsResp)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) CheckTotal(ctx context.Context, in *TotalClaim, opts ...grpc.CallOption) (*TotalCheck, error) {
+	out := new(TotalCheck)
+	err := c.cc.Invoke(ctx, "/playerpb.v2.Player/CheckTotal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlayerServer is the server API for Player service.
+type PlayerServer interface {
+	Ping(context.Context, *Empty) (*Empty, error)
+	StreamRoundEvents(*reflexpb.StreamRequest, Player_StreamRoundEventsServer) error
+	GetParts(context.Context, *GetPartsReq) (*GetPartsResp, error)
+	GetRoundParts(context.Context, *GetPartsReq) (*GetPartsResp, error)
+	GetRound(context.Context, *GetRoundReq) (*GetRoundResp, error)
+	GetName(context.Context, *Empty) (*GetNameResp, error)
+	Hello(context.Context, *Empty) (*HelloResp, error)
+	Leave(context.Context, *LeaveReq) (*Empty, error)
+	GetRoundAudit(context.Context, *GetRoundAuditReq) (*GetRoundAuditResp, error)
+	Export(*ExportReq, Player_ExportServer) error
+	GetStats(context.Context, *Empty) (*GetStatsResp, error)
+	CheckTotal(context.Context, *TotalClaim) (*TotalCheck, error)
+}
+
+func RegisterPlayerServer(s *grpc.Server, srv PlayerServer) {
+	s.RegisterService(&_Player_serviceDesc, srv)
+}
+
+func _Player_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Ping(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_StreamRoundEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(reflexpb.StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerServer).StreamRoundEvents(m, &playerStreamRoundEventsServer{stream})
+}
+
+type Player_StreamRoundEventsServer interface {
+	Send(*reflexpb.Event) error
+	grpc.ServerStream
+}
+
+type playerStreamRoundEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *playerStreamRoundEventsServer) Send(m *reflexpb.Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Player_GetParts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetParts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/GetParts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetParts(ctx, req.(*GetPartsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetRoundParts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetRoundParts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/GetRoundParts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetRoundParts(ctx, req.(*GetPartsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/GetRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetRound(ctx, req.(*GetRoundReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/GetName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetName(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Hello_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Hello(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/Hello",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Hello(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Leave(ctx, req.(*LeaveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_GetRoundAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundAuditReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetRoundAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/GetRoundAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetRoundAudit(ctx, req.(*GetRoundAuditReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerServer).Export(m, &playerExportServer{stream})
+}
+
+type Player_ExportServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type playerExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *playerExportServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Player_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).GetStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_CheckTotal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotalClaim)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).CheckTotal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playerpb.v2.Player/CheckTotal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).CheckTotal(ctx, req.(*TotalClaim))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Player_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "playerpb.v2.Player",
+	HandlerType: (*PlayerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _Player_Ping_Handler,
+		},
+		{
+			MethodName: "GetParts",
+			Handler:    _Player_GetParts_Handler,
+		},
+		{
+			MethodName: "GetRoundParts",
+			Handler:    _Player_GetRoundParts_Handler,
+		},
+		{
+			MethodName: "GetRound",
+			Handler:    _Player_GetRound_Handler,
+		},
+		{
+			MethodName: "GetName",
+			Handler:    _Player_GetName_Handler,
+		},
+		{
+			MethodName: "Hello",
+			Handler:    _Player_Hello_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _Player_Leave_Handler,
+		},
+		{
+			MethodName: "GetRoundAudit",
+			Handler:    _Player_GetRoundAudit_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Player_GetStats_Handler,
+		},
+		{
+			MethodName: "CheckTotal",
+			Handler:    _Player_CheckTotal_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRoundEvents",
+			Handler:       _Player_StreamRoundEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _Player_Export_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "v2/player.proto",
+}
+
+func init() { proto.RegisterFile("v2/player.proto", fileDescriptor_player_e4f9eda4b02bd28d) }
+
+var fileDescriptor_player_e4f9eda4b02bd28d = []byte{
+	// 1373 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x4d, 0x73, 0x1b, 0x45,
+	0x13, 0xd6, 0xd7, 0x4a, 0xab, 0x96, 0x13, 0xcb, 0x93, 0x0f, 0x2b, 0x7a, 0x5f, 0x12, 0xb3, 0x1c,
+	0xe2, 0x50, 0x89, 0x14, 0x04, 0xae, 0xe0, 0xa2, 0x8a, 0x42, 0xb1, 0x15, 0x30, 0xd8, 0xb2, 0x6b,
+	0x65, 0x43, 0x15, 0x17, 0xd5, 0x48, 0x3b, 0x96, 0xb7, 0xbc, 0x5f, 0xd9, 0x9d, 0x35, 0x0e, 0xe7,
+	0xfc, 0x25, 0x8e, 0xfc, 0x02, 0xee, 0xfc, 0x13, 0xaa, 0x38, 0x52, 0xd3, 0x33, 0xab, 0xd5, 0x5a,
+	0xeb, 0x38, 0x81, 0x93, 0xa6, 0xbb, 0x9f, 0x9e, 0xee, 0x79, 0xba, 0x67, 0xb6, 0x05, 0xab, 0x17,
+	0xbd, 0x6e, 0xe0, 0xd0, 0x37, 0x2c, 0xec, 0x04, 0xa1, 0xcf, 0x7d, 0xd2, 0x90, 0x52, 0x30, 0xe9,
+	0x5c, 0xf4, 0xda, 0x4f, 0x67, 0x36, 0x3f, 0x8b, 0x27, 0x9d, 0xa9, 0xef, 0x76, 0x9d, 0xd8, 0xf3,
+	0xbb, 0x21, 0x3b, 0x75, 0xd8, 0xa5, 0xfa, 0x09, 0x26, 0x6a, 0x21, 0x5d, 0xdb, 0x8f, 0x66, 0xbe,
+	0x3f, 0x73, 0x58, 0x17, 0xa5, 0x49, 0x7c, 0xda, 0xe5, 0xb6, 0xcb, 0x22, 0x4e, 0xdd, 0x40, 0x02,
+	0x8c, 0x1a, 0x68, 0x03, 0x37, 0xe0, 0x6f, 0x8c, 0x8f, 0xa1, 0xf1, 0x2d, 0xe3, 0x43, 0xea, 0x32,
+	0x93, 0x45, 0x01, 0x21, 0x50, 0xf1, 0xa8, 0xcb, 0x5a, 0xc5, 0x8d, 0xe2, 0x66, 0xdd, 0xc4, 0xb5,
+	0xf1, 0x7b, 0x11, 0xea, 0xdf, 0x31, 0xc7, 0xf1, 0x11, 0xf1, 0x08, 0x54, 0x5e, 0xe3, 0x05, 0x20,
+	0x48, 0x95, 0xd8, 0x86, 0xfc, 0x0f, 0xea, 0x9c, 0x51, 0x57, 0x9a, 0x4b, 0x68, 0xd6, 0x85, 0x02,
+	0x8d, 0x9f, 0xc0, 0xad, 0x49, 0x6c, 0x3b, 0xd6, 0xf8, 0x82, 0x85, 0x91, 0xed, 0x7b, 0xad, 0x32,
+	0x02, 0x56, 0x50, 0xf9, 0xa3, 0xd4, 0x91, 0x27, 0xd0, 0xc4, 0x2c, 0xa7, 0xbe, 0x33, 0xc7, 0x55,
+	0x36, 0x8a, 0x9b, 0x9a, 0xb9, 0x9a, 0xe8, 0x13, 0xa8, 0x01, 0x2b, 0x53, 0x1a, 0xd0, 0x89, 0xed,
+	0xd8, 0xdc, 0x66, 0x51, 0x4b, 0xdb, 0x28, 0x8b, 0xed, 0x16, 0x75, 0xc6, 0x43, 0xd0, 0xf7, 0x19,
+	0xbd, 0x60, 0x26, 0x7b, 0x9d, 0x7b, 0xbe, 0x0e, 0x52, 0x70, 0x44, 0x43, 0x1e, 0x09, 0xc8, 0x23,
+	0x68, 0xb0, 0x4b, 0xce, 0x42, 0x8f, 0x3a, 0x63, 0xdb, 0x42, 0x64, 0xd9, 0x84, 0x44, 0xb5, 0x67,
+	0x19, 0x2f, 0x60, 0x25, 0xc5, 0x47, 0x01, 0x79, 0x0c, 0x5a, 0x20, 0x84, 0x56, 0x71, 0xa3, 0xbc,
+	0xd9, 0xe8, 0xad, 0x75, 0x16, 0xea, 0xd6, 0x11, 0x30, 0x53, 0xda, 0x8d, 0x4d, 0x0c, 0x64, 0xfa,
+	0xb1, 0x67, 0x89, 0x40, 0x0f, 0x40, 0x0f, 0xc5, 0x3a, 0x8d, 0x52, 0x43, 0x79, 0xcf, 0x32, 0xbe,
+	0x84, 0x95, 0x14, 0x19, 0x05, 0x64, 0x13, 0x34, 0x34, 0x21, 0xae, 0xd1, 0x23, 0x99, 0x10, 0x12,
+	0x26, 0x01, 0xc6, 0x33, 0x68, 0x26, 0x9e, 0xfd, 0xd8, 0xb2, 0xf9, 0x0d, 0x81, 0x5e, 0xc2, 0xda,
+	0x15, 0x78, 0x14, 0x90, 0x67, 0xa0, 0x51, 0x21, 0xa8, 0x03, 0xad, 0x2f, 0x47, 0x93, 0x58, 0x89,
+	0x32, 0x5e, 0x40, 0x7d, 0x70, 0x19, 0xf8, 0x21, 0xc6, 0xba, 0x0f, 0xd5, 0x53, 0x3f, 0x74, 0x29,
+	0x57, 0x14, 0x2b, 0x49, 0x10, 0x7f, 0x6e, 0x7b, 0x96, 0x6a, 0x08, 0x5c, 0x8b, 0xde, 0x93, 0x8e,
+	0x3b, 0x67, 0xb1, 0x77, 0x2e, 0x20, 0x16, 0xe5, 0x14, 0x1d, 0x57, 0x4c, 0x5c, 0x2b, 0x22, 0x46,
+	0x9c, 0x2a, 0xae, 0x37, 0x41, 0x8b, 0x84, 0x90, 0x4b, 0x84, 0x84, 0x49, 0x80, 0xf1, 0x1a, 0xe0,
+	0xd8, 0xe7, 0xd4, 0xd9, 0x71, 0xa8, 0xed, 0xde, 0x58, 0x54, 0x91, 0xb7, 0xdc, 0x4a, 0x65, 0xa8,
+	0x24, 0x72, 0x17, 0x34, 0x2e, 0xb6, 0xc1, 0x46, 0x2d, 0x9b, 0x52, 0x10, 0x5a, 0x59, 0xf2, 0xca,
+	0x46, 0x59, 0x68, 0x65, 0x7d, 0x7f, 0x4d, 0x42, 0x9e, 0xb1, 0x29, 0x1e, 0x27, 0x60, 0x2c, 0x4c,
+	0x5a, 0x4d, 0xac, 0x85, 0xdf, 0xb9, 0xe7, 0xff, 0xe2, 0x61, 0x10, 0xdd, 0x94, 0x82, 0x88, 0x4d,
+	0x67, 0x21, 0x63, 0x16, 0x06, 0xd1, 0x4d, 0x25, 0xa5, 0xb1, 0x2b, 0xb9, 0xb1, 0xb5, 0xc5, 0xd8,
+	0x7f, 0x94, 0x40, 0xc3, 0xf3, 0x93, 0xff, 0x43, 0x3d, 0x8a, 0xa7, 0x53, 0xc6, 0x2c, 0x96, 0x1c,
+	0x34, 0x55, 0x60, 0x7d, 0xa8, 0xed, 0x30, 0x59, 0x89, 0xb2, 0xa9, 0x24, 0xd2, 0x06, 0x9d, 0x5d,
+	0x4e, 0x9d, 0xd8, 0x52, 0x59, 0x94, 0xcd, 0xb9, 0x4c, 0xba, 0x50, 0x0d, 0xce, 0x68, 0xc4, 0xe4,
+	0x71, 0xaf, 0x36, 0xc4, 0x91, 0x30, 0x49, 0xea, 0x15, 0x6c, 0x31, 0xc5, 0xe2, 0x3c, 0x45, 0x7c,
+	0x39, 0xc4, 0x62, 0x2c, 0x0f, 0x55, 0x95, 0x35, 0x40, 0x15, 0xd2, 0x46, 0x1e, 0xc3, 0x6a, 0x14,
+	0x4f, 0x5c, 0x9b, 0x73, 0x66, 0x29, 0x50, 0x0d, 0x41, 0xb7, 0xe7, 0x6a, 0x09, 0x7c, 0x0a, 0x9a,
+	0xa0, 0x33, 0x6a, 0xe9, 0x98, 0xcf, 0xfd, 0x6c, 0x3e, 0x8c, 0x85, 0xaa, 0x13, 0x10, 0x44, 0x3e,
+	0x83, 0x9a, 0x4b, 0xf9, 0xf4, 0x8c, 0x45, 0xad, 0x7a, 0x4e, 0xfe, 0x07, 0xc2, 0x26, 0x1d, 0x12,
+	0x9c, 0xf1, 0x06, 0x20, 0x55, 0x8b, 0xfb, 0x83, 0x86, 0x85, 0xfb, 0x83, 0xf2, 0x9e, 0x95, 0x25,
+	0xbb, 0x74, 0x3d, 0xd9, 0xe5, 0x6b, 0xc9, 0xae, 0x64, 0xc9, 0x36, 0xde, 0x16, 0x01, 0x52, 0x4a,
+	0xc9, 0x73, 0xa8, 0x8a, 0x7e, 0x8e, 0x65, 0xc7, 0xdf, 0xee, 0xb5, 0x96, 0x2f, 0xe3, 0x08, 0xed,
+	0xa6, 0xc2, 0x09, 0xf2, 0xa7, 0x7e, 0xec, 0x71, 0x95, 0x8e, 0x14, 0xc8, 0x3a, 0xd4, 0x5c, 0x46,
+	0xbd, 0xb1, 0x1b, 0x25, 0xb9, 0x08, 0xf1, 0x20, 0x22, 0xf7, 0xa0, 0x1a, 0x6c, 0x6f, 0x09, 0xbd,
+	0xea, 0xb2, 0x60, 0x7b, 0xeb, 0x20, 0x32, 0x86, 0x50, 0x9f, 0x13, 0xb9, 0x70, 0x39, 0x8a, 0x99,
+	0xcb, 0xf1, 0x04, 0x9a, 0x0e, 0xe5, 0x6c, 0x8c, 0xe5, 0x89, 0xc4, 0x83, 0x1c, 0xa9, 0xa8, 0xab,
+	0x42, 0x3f, 0x4a, 0xd5, 0xc6, 0xdf, 0x45, 0xd0, 0x30, 0x5b, 0x72, 0x1b, 0x4a, 0x73, 0x1e, 0x4b,
+	0xb6, 0x75, 0xf5, 0x6a, 0x96, 0xde, 0x71, 0x35, 0xcb, 0x99, 0xe8, 0x29, 0x35, 0x95, 0xf7, 0xa4,
+	0x66, 0x1b, 0x60, 0x1a, 0x32, 0x2a, 0xda, 0x8b, 0x72, 0x6c, 0xc0, 0x46, 0xaf, 0xdd, 0x91, 0xdf,
+	0xca, 0x4e, 0xf2, 0xad, 0xec, 0x1c, 0x27, 0xdf, 0x4a, 0xb3, 0xae, 0xd0, 0x7d, 0x2e, 0x5c, 0xe3,
+	0xc0, 0x4a, 0x5c, 0x6b, 0x37, 0xbb, 0x2a, 0x74, 0x9f, 0x1b, 0x7f, 0x96, 0xa0, 0x22, 0x3e, 0x03,
+	0x4b, 0x27, 0x5f, 0x7c, 0x97, 0x4b, 0x99, 0x77, 0xf9, 0xda, 0x33, 0x13, 0xa8, 0x84, 0xd4, 0x3b,
+	0x57, 0xb5, 0xc2, 0xb5, 0x28, 0xf8, 0x05, 0x75, 0x62, 0x96, 0xdc, 0x36, 0x14, 0x64, 0x67, 0xaa,
+	0x5b, 0x83, 0x47, 0xd5, 0xcd, 0x54, 0x71, 0x85, 0x89, 0xda, 0xbf, 0x67, 0x42, 0xff, 0x00, 0x26,
+	0x30, 0x27, 0x7b, 0xe6, 0x51, 0x1e, 0x87, 0xac, 0x55, 0xc7, 0x67, 0x3e, 0x55, 0x90, 0x2e, 0xdc,
+	0x49, 0xaf, 0x7f, 0x8a, 0x03, 0xc4, 0x91, 0xb9, 0x69, 0x94, 0x58, 0x8c, 0xdf, 0x4a, 0x00, 0xe9,
+	0xe7, 0xe8, 0x43, 0xe8, 0xdd, 0x86, 0xc6, 0x69, 0xe8, 0xbb, 0x63, 0xd5, 0x3f, 0xe5, 0x1b, 0xfa,
+	0x07, 0x04, 0x58, 0xae, 0xc9, 0x16, 0xd4, 0xb9, 0x3f, 0x7e, 0xcf, 0xc6, 0xd3, 0xb9, 0xaf, 0xdc,
+	0x5a, 0x50, 0xe3, 0xa1, 0x3d, 0x9b, 0xb1, 0x10, 0xcb, 0x54, 0x37, 0x13, 0x51, 0x94, 0x8f, 0x85,
+	0xa1, 0x1f, 0x62, 0x91, 0xea, 0xa6, 0x14, 0xc4, 0xad, 0xb0, 0xe2, 0x90, 0x72, 0xdb, 0xc7, 0x3b,
+	0x2b, 0xdf, 0x41, 0x48, 0x54, 0x07, 0x57, 0x7b, 0x59, 0xff, 0x80, 0x0a, 0x7e, 0xfa, 0x57, 0x11,
+	0x1a, 0x0b, 0x59, 0x92, 0x16, 0xdc, 0x35, 0x0f, 0x4f, 0x86, 0xbb, 0xe3, 0xd1, 0x71, 0xff, 0xf8,
+	0x64, 0x34, 0x3e, 0x19, 0xfe, 0x30, 0x3c, 0xfc, 0x69, 0xd8, 0x2c, 0x90, 0x7b, 0xb0, 0x96, 0xb1,
+	0x7c, 0x7f, 0xb8, 0x37, 0x6c, 0x16, 0xc9, 0x3a, 0xdc, 0x59, 0x52, 0x0f, 0x76, 0x9b, 0xa5, 0xa5,
+	0x9d, 0x76, 0x0e, 0xf7, 0xf7, 0x07, 0x3b, 0xc7, 0xcd, 0x32, 0x69, 0xc3, 0xfd, 0x3c, 0xcb, 0x60,
+	0xb7, 0x59, 0x59, 0xda, 0x6e, 0x74, 0xf2, 0xf2, 0x60, 0xef, 0xb8, 0xa9, 0x2d, 0x39, 0x49, 0x83,
+	0x70, 0xaa, 0x2e, 0x85, 0x1a, 0x9d, 0xec, 0xec, 0x0c, 0x46, 0xa3, 0x66, 0x6d, 0x69, 0xbb, 0x57,
+	0xfd, 0xbd, 0xfd, 0xc1, 0x6e, 0x53, 0xef, 0xbd, 0xad, 0x42, 0xf5, 0x28, 0x79, 0x3b, 0x2a, 0x47,
+	0xb6, 0x37, 0x23, 0xd9, 0x01, 0x02, 0x47, 0xe2, 0x76, 0x8e, 0xce, 0x28, 0x90, 0x3e, 0xac, 0x8d,
+	0x78, 0xc8, 0xa8, 0x8b, 0xcc, 0x0d, 0x2e, 0x98, 0xc7, 0x23, 0xb2, 0xde, 0x49, 0xe6, 0xef, 0x8e,
+	0x32, 0xb2, 0xd7, 0x31, 0x8b, 0x78, 0x7b, 0x35, 0x35, 0x20, 0xd4, 0x28, 0x3c, 0x2f, 0x92, 0x3e,
+	0xe8, 0xc9, 0xe0, 0x48, 0xb2, 0x3d, 0xb3, 0x30, 0x7f, 0xb6, 0x1f, 0x5c, 0x63, 0x89, 0x02, 0xa3,
+	0x40, 0x5e, 0xc1, 0xad, 0x64, 0x5e, 0xfb, 0x4f, 0xfb, 0xc8, 0x54, 0xe4, 0x83, 0xbc, 0xb4, 0x45,
+	0x32, 0xa1, 0xb6, 0x1f, 0x5c, 0x63, 0xc1, 0x2d, 0xb6, 0xa1, 0xa6, 0xfe, 0x39, 0xe4, 0xb2, 0xb8,
+	0xb4, 0x6b, 0xf2, 0x1f, 0xc3, 0x28, 0x90, 0x2d, 0xd0, 0xf0, 0x0f, 0x45, 0xae, 0x63, 0xf6, 0x6b,
+	0x3e, 0xff, 0xe3, 0x61, 0x14, 0xc8, 0x17, 0xa0, 0xe1, 0x20, 0x4f, 0xee, 0x65, 0x20, 0xc9, 0x70,
+	0x7f, 0x4d, 0xe1, 0x8e, 0x52, 0xca, 0xe4, 0x3b, 0xf1, 0x51, 0xee, 0xa9, 0x92, 0x69, 0xb9, 0xfd,
+	0xf0, 0x5d, 0x66, 0xcc, 0xe3, 0x6b, 0xa8, 0xca, 0xb9, 0x95, 0x64, 0x73, 0x9d, 0x4f, 0xc1, 0xed,
+	0x56, 0x8e, 0x1e, 0x87, 0x5c, 0xec, 0x83, 0xaf, 0x90, 0x7c, 0xf9, 0x69, 0xcd, 0x63, 0x60, 0x89,
+	0xf6, 0xf9, 0xfc, 0x6b, 0x14, 0xc8, 0x37, 0x00, 0x38, 0x5f, 0xca, 0x49, 0x28, 0x3b, 0xca, 0xa4,
+	0x03, 0x6f, 0x3b, 0xcf, 0x20, 0xdc, 0x8c, 0xc2, 0x4b, 0xf8, 0x59, 0x4f, 0x6c, 0x93, 0x2a, 0xbe,
+	0x14, 0x9f, 0xff, 0x33, 0x00, 0x0c, 0xc3, 0x0b, 0xa0, 0x7d, 0x0e, 0x00, 0x00,
+}
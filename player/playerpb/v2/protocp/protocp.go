@@ -0,0 +1,318 @@
+// Package protocp converts between the Player's types and the second version
+// of its protobuf API.
+package protocp
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/luno/jettison/errors"
+	"unsure/player"
+	pb "unsure/player/playerpb/v2"
+)
+
+// roundStatuses maps each round status to its enum value in the API.
+var roundStatuses = map[player.RoundStatus]pb.RoundStatus{
+	player.RoundStatusJoin:      pb.RoundStatus_ROUND_STATUS_JOIN,
+	player.RoundStatusJoined:    pb.RoundStatus_ROUND_STATUS_JOINED,
+	player.RoundStatusCollect:   pb.RoundStatus_ROUND_STATUS_COLLECT,
+	player.RoundStatusCollected: pb.RoundStatus_ROUND_STATUS_COLLECTED,
+	player.RoundStatusSubmit:    pb.RoundStatus_ROUND_STATUS_SUBMIT,
+	player.RoundStatusSubmitted: pb.RoundStatus_ROUND_STATUS_SUBMITTED,
+	player.RoundStatusSuccess:   pb.RoundStatus_ROUND_STATUS_SUCCESS,
+	player.RoundStatusFailed:    pb.RoundStatus_ROUND_STATUS_FAILED,
+}
+
+// RoundStatusToProto converts a player.RoundStatus to a pb.RoundStatus. It
+// returns pb.RoundStatus_ROUND_STATUS_UNKNOWN for unknown statuses.
+func RoundStatusToProto(in player.RoundStatus) pb.RoundStatus {
+	return roundStatuses[in]
+}
+
+// RoundStatusFromProto converts a pb.RoundStatus to a player.RoundStatus.
+// It returns player.RoundStatusUnknown for statuses added by newer builds.
+func RoundStatusFromProto(in pb.RoundStatus) player.RoundStatus {
+	for st, v := range roundStatuses {
+		if v == in {
+			return st
+		}
+	}
+
+	return player.RoundStatusUnknown
+}
+
+// PartFromProto converts a pb.Part to a player.Part.
+func PartFromProto(in *pb.Part) (*player.Part, error) {
+	createdAt, err := ptypes.Timestamp(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	updatedAt, err := ptypes.Timestamp(in.UpdatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &player.Part{
+		ID:        in.Id,
+		RoundID:   in.RoundId,
+		Player:    in.Player,
+		Rank:      in.Rank,
+		Value:     in.Value,
+		Submitted: in.Submitted,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+
+		Signature:          in.Signature,
+		SubmittedSignature: in.SubmittedSignature,
+	}, nil
+}
+
+// PartToProto converts a player.Part to a pb.Part.
+func PartToProto(in *player.Part) (*pb.Part, error) {
+	createdAt, err := ptypes.TimestampProto(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	updatedAt, err := ptypes.TimestampProto(in.UpdatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &pb.Part{
+		Id:        in.ID,
+		RoundId:   in.RoundID,
+		Player:    in.Player,
+		Rank:      in.Rank,
+		Value:     in.Value,
+		Submitted: in.Submitted,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+
+		Signature:          in.Signature,
+		SubmittedSignature: in.SubmittedSignature,
+	}, nil
+}
+
+func RoundFromProto(in *pb.Round) (*player.Round, error) {
+	createdAt, err := ptypes.Timestamp(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	updatedAt, err := ptypes.Timestamp(in.UpdatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &player.Round{
+		ID:         in.Id,
+		ExternalID: in.ExternalId,
+		Player:     in.Player,
+		Status:     RoundStatusFromProto(in.Status),
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}, nil
+}
+
+func RoundToProto(in *player.Round) (*pb.Round, error) {
+	createdAt, err := ptypes.TimestampProto(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	updatedAt, err := ptypes.TimestampProto(in.UpdatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &pb.Round{
+		Id:         in.ID,
+		ExternalId: in.ExternalID,
+		Player:     in.Player,
+		Status:     RoundStatusToProto(in.Status),
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}, nil
+}
+
+// RoundAuditFromProto converts a pb.RoundAudit to a player.RoundAudit.
+func RoundAuditFromProto(in *pb.RoundAudit) (*player.RoundAudit, error) {
+	createdAt, err := ptypes.Timestamp(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &player.RoundAudit{
+		ID:        in.Id,
+		RoundID:   in.RoundId,
+		From:      RoundStatusFromProto(in.FromStatus),
+		To:        RoundStatusFromProto(in.ToStatus),
+		Trigger:   in.Trigger,
+		Error:     in.Error,
+		Duration:  time.Duration(in.DurationMs) * time.Millisecond,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// RoundAuditToProto converts a player.RoundAudit to a pb.RoundAudit.
+func RoundAuditToProto(in *player.RoundAudit) (*pb.RoundAudit, error) {
+	createdAt, err := ptypes.TimestampProto(in.CreatedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert timestamp")
+	}
+
+	return &pb.RoundAudit{
+		Id:         in.ID,
+		RoundId:    in.RoundID,
+		FromStatus: RoundStatusToProto(in.From),
+		ToStatus:   RoundStatusToProto(in.To),
+		Trigger:    in.Trigger,
+		Error:      in.Error,
+		DurationMs: int64(in.Duration / time.Millisecond),
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+// HelloFromProto converts a pb.HelloResp to a player.Hello.
+func HelloFromProto(in *pb.HelloResp) *player.Hello {
+	return &player.Hello{
+		PlayerName:      in.PlayerName,
+		TeamName:        in.TeamName,
+		BuildVersion:    in.BuildVersion,
+		ProtocolVersion: int(in.ProtocolVersion),
+		Capabilities:    in.Capabilities,
+	}
+}
+
+// HelloToProto converts a player.Hello to a pb.HelloResp.
+func HelloToProto(in *player.Hello) *pb.HelloResp {
+	return &pb.HelloResp{
+		PlayerName:      in.PlayerName,
+		TeamName:        in.TeamName,
+		BuildVersion:    in.BuildVersion,
+		ProtocolVersion: int32(in.ProtocolVersion),
+		Capabilities:    in.Capabilities,
+	}
+}
+
+// TotalClaimFromProto converts a pb.TotalClaim to a player.TotalClaim.
+func TotalClaimFromProto(in *pb.TotalClaim) player.TotalClaim {
+	return player.TotalClaim{
+		ExternalID: in.ExternalId,
+		Player:     in.Player,
+		Total:      in.Total,
+		Parts:      in.Parts,
+	}
+}
+
+// TotalClaimToProto converts a player.TotalClaim to a pb.TotalClaim.
+func TotalClaimToProto(in player.TotalClaim) *pb.TotalClaim {
+	return &pb.TotalClaim{
+		ExternalId: in.ExternalID,
+		Player:     in.Player,
+		Total:      in.Total,
+		Parts:      in.Parts,
+	}
+}
+
+// TotalCheckFromProto converts a pb.TotalCheck to a player.TotalCheck.
+func TotalCheckFromProto(in *pb.TotalCheck) *player.TotalCheck {
+	return &player.TotalCheck{
+		Peer:   in.Peer,
+		Known:  in.Known,
+		Agreed: in.Agreed,
+		Total:  in.Total,
+		Parts:  in.Parts,
+	}
+}
+
+// TotalCheckToProto converts a player.TotalCheck to a pb.TotalCheck.
+func TotalCheckToProto(in *player.TotalCheck) *pb.TotalCheck {
+	return &pb.TotalCheck{
+		Peer:   in.Peer,
+		Known:  in.Known,
+		Agreed: in.Agreed,
+		Total:  in.Total,
+		Parts:  in.Parts,
+	}
+}
+
+// StatsFromProto converts a pb.Stats to a player.Stats.
+func StatsFromProto(in *pb.Stats) *player.Stats {
+	res := player.Stats{
+		Succeeded:      in.Succeeded,
+		Failed:         in.Failed,
+		Excluded:       in.Excluded,
+		Parts:          in.Parts,
+		PartsTotal:     in.PartsTotal,
+		SubmittedTotal: in.SubmittedTotal,
+	}
+
+	for _, ps := range in.Phases {
+		res.Phases = append(res.Phases, player.PhaseStats{
+			Status: RoundStatusFromProto(ps.Status),
+			Count:  ps.Count,
+			Mean:   time.Duration(ps.MeanMs) * time.Millisecond,
+			P95:    time.Duration(ps.P95Ms) * time.Millisecond,
+		})
+	}
+
+	for _, ps := range in.Peers {
+		res.Peers = append(res.Peers, player.PeerStats{
+			Player:          ps.Player,
+			LateSubmissions: ps.LateSubmissions,
+		})
+	}
+
+	for _, ms := range in.Matches {
+		res.Matches = append(res.Matches, player.MatchStats{
+			MatchID:   ms.MatchId,
+			Succeeded: ms.Succeeded,
+			Failed:    ms.Failed,
+			Excluded:  ms.Excluded,
+		})
+	}
+
+	return &res
+}
+
+// StatsToProto converts a player.Stats to a pb.Stats.
+func StatsToProto(in *player.Stats) *pb.Stats {
+	res := pb.Stats{
+		Succeeded:      in.Succeeded,
+		Failed:         in.Failed,
+		Excluded:       in.Excluded,
+		Parts:          in.Parts,
+		PartsTotal:     in.PartsTotal,
+		SubmittedTotal: in.SubmittedTotal,
+	}
+
+	for _, ps := range in.Phases {
+		res.Phases = append(res.Phases, &pb.PhaseStats{
+			Status: RoundStatusToProto(ps.Status),
+			Count:  ps.Count,
+			MeanMs: int64(ps.Mean / time.Millisecond),
+			P95Ms:  int64(ps.P95 / time.Millisecond),
+		})
+	}
+
+	for _, ps := range in.Peers {
+		res.Peers = append(res.Peers, &pb.PeerStats{
+			Player:          ps.Player,
+			LateSubmissions: ps.LateSubmissions,
+		})
+	}
+
+	for _, ms := range in.Matches {
+		res.Matches = append(res.Matches, &pb.MatchStats{
+			MatchId:   ms.MatchID,
+			Succeeded: ms.Succeeded,
+			Failed:    ms.Failed,
+			Excluded:  ms.Excluded,
+		})
+	}
+
+	return &res
+}
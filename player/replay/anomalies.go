@@ -0,0 +1,145 @@
+package replay
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"unsure/player"
+)
+
+// anomalies returns the problems found in the round's history.
+func (rd *round) anomalies(playerName string) []string {
+	var res []string
+
+	if rd.r.ID == 0 {
+		res = append(res, "round not found locally, only in peer events")
+	}
+
+	// Record when the round first reached each status.
+	reached := make(map[player.RoundStatus]time.Time)
+	for _, t := range rd.transitions {
+		if _, ok := reached[t.Status]; !ok {
+			reached[t.Status] = t.Timestamp
+		}
+	}
+
+	// Split our own parts from our peers'.
+	var (
+		own    []player.Part
+		others = make(map[string]player.Part)
+	)
+	for _, p := range rd.parts {
+		if strings.EqualFold(p.Player, playerName) {
+			own = append(own, p)
+		} else {
+			others[strings.ToLower(p.Player)] = p
+		}
+	}
+
+	// Submitting requires our parts to be collected from the engine first.
+	_, collected := reached[player.RoundStatusCollected]
+	for _, st := range []player.RoundStatus{player.RoundStatusSubmit,
+		player.RoundStatusSubmitted} {
+		if _, ok := reached[st]; ok && !collected {
+			res = append(res, fmt.Sprintf("reached %s without a collect", st))
+		}
+	}
+	if collected && len(own) == 0 {
+		res = append(res, "collected but none of our parts are stored")
+	}
+
+	// Peers which collected should have had their parts collected by us.
+	var (
+		peerSubmitted = make(map[string]time.Time)
+		unconsumed    = make(map[string]int)
+		peers         []string
+	)
+	for _, pe := range rd.peerEvents {
+		peer := strings.ToLower(pe.peer)
+		if !pe.consumed {
+			if unconsumed[pe.peer] == 0 {
+				peers = append(peers, pe.peer)
+			}
+			unconsumed[pe.peer]++
+		}
+
+		switch pe.event.Status {
+		case player.RoundStatusCollected:
+			if _, ok := others[peer]; !ok && rd.r.ID != 0 {
+				res = append(res, fmt.Sprintf("peer %s collected at %s "+
+					"but its parts were never stored", pe.peer,
+					formatTime(pe.event.Timestamp)))
+			}
+		case player.RoundStatusSubmitted:
+			if _, ok := peerSubmitted[peer]; !ok {
+				peerSubmitted[peer] = pe.event.Timestamp
+			}
+		}
+	}
+	for _, peer := range peers {
+		res = append(res, fmt.Sprintf("peer %s has %d unconsumed events",
+			peer, unconsumed[peer]))
+	}
+
+	res = append(res, rd.submitOrderAnomalies(reached, own, others,
+		peerSubmitted)...)
+
+	return res
+}
+
+// submitOrderAnomalies returns the lower-ranked peers which hadn't submitted
+// by the time we decided to submit.
+func (rd *round) submitOrderAnomalies(
+	reached map[player.RoundStatus]time.Time, own []player.Part,
+	others map[string]player.Part,
+	peerSubmitted map[string]time.Time) []string {
+	submitAt, ok := reached[player.RoundStatusSubmit]
+	if !ok {
+		submitAt, ok = reached[player.RoundStatusSubmitted]
+	}
+	if !ok {
+		return nil
+	}
+
+	var rank int64
+	for _, p := range own {
+		if p.Rank != 0 {
+			rank = p.Rank
+		}
+	}
+	if rank == 0 {
+		return []string{"submitted without knowing our rank"}
+	}
+
+	var res []string
+	for _, p := range rd.parts {
+		peer := strings.ToLower(p.Player)
+		if _, ok := others[peer]; !ok || p.Rank == 0 || p.Rank >= rank {
+			continue
+		}
+
+		// Prefer the peer's own event, falling back to when we recorded
+		// its acknowledgement.
+		at, ok := peerSubmitted[peer]
+		if !ok && p.Submitted {
+			at, ok = p.UpdatedAt, true
+		}
+
+		if !ok {
+			res = append(res, fmt.Sprintf("submitted at %s but lower-ranked "+
+				"peer %s (rank %d) never submitted", formatTime(submitAt),
+				p.Player, p.Rank))
+		} else if at.After(submitAt) {
+			res = append(res, fmt.Sprintf("submitted at %s before "+
+				"lower-ranked peer %s (rank %d) at %s", formatTime(submitAt),
+				p.Player, p.Rank, formatTime(at)))
+		}
+	}
+
+	return res
+}
+
+func formatTime(t time.Time) string {
+	return t.Format("15:04:05.000")
+}
@@ -0,0 +1,124 @@
+package replay
+
+import (
+	"context"
+	"time"
+
+	"github.com/corverroos/unsure"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/reflex"
+
+	"unsure/player"
+	"unsure/player/playerpb/protocp"
+)
+
+// PeerEvents defines the round events read from a peer.
+type PeerEvents struct {
+	Name   string
+	Events []PeerEvent
+}
+
+// PeerEvent defines a peer's round event.
+type PeerEvent struct {
+	ID         string
+	ExternalID int64
+	Status     player.RoundStatus
+	Timestamp  time.Time
+}
+
+// ReadPeer reads all of a peer's round events. Since the stream never ends,
+// reading stops once no event has been received for the idle duration.
+func ReadPeer(ctx context.Context, c player.Client, idle time.Duration) (
+	*PeerEvents, error) {
+	// Replays shouldn't be subject to fate.
+	ctx = unsure.ContextWithFate(ctx, 0)
+
+	name, err := c.GetName(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get peer name")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sc, err := c.StreamEvents(ctx, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stream peer events",
+			j.KV("peer", name))
+	}
+
+	type result struct {
+		e   *reflex.Event
+		err error
+	}
+
+	results := make(chan result)
+	go func() {
+		for {
+			e, err := sc.Recv()
+			select {
+			case results <- result{e: e, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	pe := PeerEvents{Name: name}
+	// Cache the external ids of events without metadata by foreign id.
+	externalIDs := make(map[int64]int64)
+	for {
+		var res result
+		select {
+		case res = <-results:
+		case <-time.After(idle):
+			return &pe, nil
+		}
+		if res.err != nil {
+			return nil, errors.Wrap(res.err, "failed to receive peer event",
+				j.KV("peer", name))
+		}
+
+		externalID, err := peerExternalID(ctx, c, res.e, externalIDs)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve peer round",
+				j.KV("peer", name), j.KV("event", res.e.ID))
+		}
+
+		pe.Events = append(pe.Events, PeerEvent{
+			ID:         res.e.ID,
+			ExternalID: externalID,
+			Status:     player.RoundStatus(res.e.Type.ReflexType()),
+			Timestamp:  res.e.Timestamp,
+		})
+	}
+}
+
+// peerExternalID returns the external id of the round a peer's event refers
+// to, calling back into the peer if the event carries no metadata.
+func peerExternalID(ctx context.Context, c player.Client, e *reflex.Event,
+	cache map[int64]int64) (int64, error) {
+	meta, err := protocp.UnmarshalRoundMeta(e.MetaData)
+	if err != nil {
+		return 0, err
+	}
+	if meta != nil {
+		return meta.ExternalID, nil
+	}
+
+	if id, ok := cache[e.ForeignIDInt()]; ok {
+		return id, nil
+	}
+
+	r, err := c.GetRound(ctx, e.ForeignIDInt())
+	if err != nil {
+		return 0, err
+	}
+	cache[e.ForeignIDInt()] = r.ExternalID
+
+	return r.ExternalID, nil
+}
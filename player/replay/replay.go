@@ -0,0 +1,266 @@
+// Package replay reconstructs what happened to a Player's rounds for post
+// mortems. It merges the Player's round events, audit trail and parts, along
+// with its peers' round events if provided, into a single ordered timeline
+// per round and reports anomalies found along the way.
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+	"unsure/player/internal/db/cursors"
+	"unsure/player/internal/db/parts"
+	"unsure/player/internal/db/rounds"
+	"unsure/player/ops"
+)
+
+// listBatch is the number of rounds read from the DB at a time.
+const listBatch = 1000
+
+// Report defines the reconstructed history of a Player's rounds.
+type Report struct {
+	PlayerName string
+	// Cursors are the current positions of the Player's consumers. Cursor
+	// history isn't stored, so only the latest positions are known.
+	Cursors   []cursors.Cursor
+	Timelines []Timeline
+}
+
+// Timeline defines the merged history of a single round.
+type Timeline struct {
+	ExternalID int64
+	// RoundID is the local round's id, or zero if the round is only known
+	// from peer events.
+	RoundID   int64
+	Status    player.RoundStatus
+	Entries   []Entry
+	Anomalies []string
+}
+
+// Entry defines something that happened to a round.
+type Entry struct {
+	Timestamp time.Time
+	// Source is where the entry was read from, e.g. "local" or "peer:bob".
+	Source string
+	Detail string
+}
+
+// Build returns the report of the Player's rounds, or only of the round with
+// the given external id if non-zero. Peer events are merged into the
+// timelines of the rounds they refer to.
+func Build(ctx context.Context, dbc *sql.DB, playerName string,
+	externalID int64, peers []PeerEvents) (*Report, error) {
+	cl, err := cursors.List(ctx, dbc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list cursors")
+	}
+
+	rl, err := listRounds(ctx, dbc, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := Report{PlayerName: playerName, Cursors: cl}
+
+	byExternalID := make(map[int64]*round)
+	var order []int64
+	for _, r := range rl {
+		rd, err := loadRound(ctx, dbc, r)
+		if err != nil {
+			return nil, err
+		}
+		byExternalID[r.ExternalID] = rd
+		order = append(order, r.ExternalID)
+	}
+
+	for _, pe := range peers {
+		for _, e := range pe.Events {
+			if externalID != 0 && e.ExternalID != externalID {
+				continue
+			}
+
+			rd, ok := byExternalID[e.ExternalID]
+			if !ok {
+				rd = &round{r: player.Round{ExternalID: e.ExternalID}}
+				byExternalID[e.ExternalID] = rd
+				order = append(order, e.ExternalID)
+			}
+			rd.peerEvents = append(rd.peerEvents, peerEvent{
+				peer:     pe.Name,
+				consumed: consumed(cl, pe.Name, e.ID),
+				event:    e,
+			})
+		}
+	}
+
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	for _, id := range order {
+		report.Timelines = append(report.Timelines,
+			byExternalID[id].timeline(playerName))
+	}
+
+	return &report, nil
+}
+
+// listRounds returns the round with the given external id, or all rounds if
+// zero.
+func listRounds(ctx context.Context, dbc *sql.DB, externalID int64) (
+	[]player.Round, error) {
+	if externalID != 0 {
+		r, err := rounds.LookupByExternalID(ctx, dbc, externalID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The round may still be known from peer events.
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to lookup round",
+				j.KV("external_id", externalID))
+		}
+		return []player.Round{*r}, nil
+	}
+
+	var (
+		res     []player.Round
+		afterID int64
+	)
+	for {
+		rl, err := rounds.List(ctx, dbc, afterID, listBatch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list rounds")
+		}
+		res = append(res, rl...)
+		if len(rl) < listBatch {
+			return res, nil
+		}
+		afterID = rl[len(rl)-1].ID
+	}
+}
+
+// round gathers everything known about a round.
+type round struct {
+	r           player.Round
+	transitions []player.RoundTransition
+	audit       []player.RoundAudit
+	parts       []player.Part
+	peerEvents  []peerEvent
+}
+
+type peerEvent struct {
+	peer     string
+	consumed bool
+	event    PeerEvent
+}
+
+func loadRound(ctx context.Context, dbc *sql.DB, r player.Round) (
+	*round, error) {
+	tl, err := rounds.ListTransitions(ctx, dbc, r.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list transitions",
+			j.KV("round", r.ID))
+	}
+
+	al, err := rounds.ListAudit(ctx, dbc, r.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list audit",
+			j.KV("round", r.ID))
+	}
+
+	pl, err := parts.ListByRound(ctx, dbc, r.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list parts",
+			j.KV("round", r.ID))
+	}
+
+	return &round{r: r, transitions: tl, audit: al, parts: pl}, nil
+}
+
+func (rd *round) timeline(playerName string) Timeline {
+	tl := Timeline{
+		ExternalID: rd.r.ExternalID,
+		RoundID:    rd.r.ID,
+		Status:     rd.r.Status,
+	}
+
+	// The audit trail is recorded along with each transition, but may only
+	// cover the latest ones if the round predates it, so align it from the
+	// end.
+	offset := len(rd.transitions) - len(rd.audit)
+	for i, t := range rd.transitions {
+		detail := t.Status.String()
+		if i >= offset && rd.audit[i-offset].To == t.Status {
+			a := rd.audit[i-offset]
+			detail += fmt.Sprintf(" (from %s after %s, trigger %s)",
+				a.From, a.Duration, a.Trigger)
+			if a.Error != "" {
+				detail += ": " + a.Error
+			}
+		}
+		tl.Entries = append(tl.Entries, Entry{
+			Timestamp: t.Timestamp,
+			Source:    "local",
+			Detail:    detail,
+		})
+	}
+
+	for _, p := range rd.parts {
+		tl.Entries = append(tl.Entries, Entry{
+			Timestamp: p.CreatedAt,
+			Source:    "part",
+			Detail: fmt.Sprintf("%s rank %d value %d", p.Player, p.Rank,
+				p.Value),
+		})
+		if p.Submitted && !strings.EqualFold(p.Player, playerName) {
+			tl.Entries = append(tl.Entries, Entry{
+				Timestamp: p.UpdatedAt,
+				Source:    "part",
+				Detail:    p.Player + " acknowledged as submitted",
+			})
+		}
+	}
+
+	for _, pe := range rd.peerEvents {
+		detail := pe.event.Status.String()
+		if !pe.consumed {
+			detail += " (not consumed)"
+		}
+		tl.Entries = append(tl.Entries, Entry{
+			Timestamp: pe.event.Timestamp,
+			Source:    "peer:" + pe.peer,
+			Detail:    fmt.Sprintf("event %s %s", pe.event.ID, detail),
+		})
+	}
+
+	sort.SliceStable(tl.Entries, func(i, j int) bool {
+		return tl.Entries[i].Timestamp.Before(tl.Entries[j].Timestamp)
+	})
+
+	tl.Anomalies = rd.anomalies(playerName)
+
+	return tl
+}
+
+// consumed returns whether the Player's consumer of the peer's events has
+// passed the event.
+func consumed(cl []cursors.Cursor, peer, eventID string) bool {
+	id, err := strconv.ParseInt(eventID, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	for _, c := range cl {
+		if c.Name == ops.PeerConsumerName(peer).String() {
+			return c.LastEventID >= id
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,37 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Write writes the report as text.
+func (r *Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Player %s\n\nCursors\n", r.PlayerName)
+	for _, c := range r.Cursors {
+		fmt.Fprintf(tw, "  %s\t%d\t%s\n", c.Name, c.LastEventID,
+			formatTime(c.UpdatedAt))
+	}
+
+	var anomalies int
+	for _, t := range r.Timelines {
+		fmt.Fprintf(tw, "\nRound %d (local id %d, %s)\n", t.ExternalID,
+			t.RoundID, t.Status)
+		for _, e := range t.Entries {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", formatTime(e.Timestamp),
+				e.Source, e.Detail)
+		}
+		for _, a := range t.Anomalies {
+			fmt.Fprintf(tw, "  ANOMALY\t%s\n", a)
+		}
+		anomalies += len(t.Anomalies)
+	}
+
+	fmt.Fprintf(tw, "\n%d rounds, %d anomalies\n", len(r.Timelines),
+		anomalies)
+
+	return tw.Flush()
+}
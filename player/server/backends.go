@@ -5,6 +5,8 @@ import (
 	"github.com/corverroos/unsure/engine"
 
 	"unsure/player"
+	"unsure/player/internal/signing"
+	"unsure/player/ops"
 )
 
 // Backends defines the interface for the client dependencies required for
@@ -13,4 +15,7 @@ type Backends interface {
 	PlayerDB() *sql.DB
 	EngineClient() engine.Client
 	Peers() []player.Client
+	Keyring() *signing.Keyring
+	Departures() *ops.Departures
+	Standby() *ops.Standby
 }
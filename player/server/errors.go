@@ -0,0 +1,45 @@
+package server
+
+import (
+	"github.com/luno/jettison/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"unsure/player"
+)
+
+// statusCodes maps domain errors to the gRPC status codes returned to
+// callers.
+var statusCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{player.ErrRoundNotFound, codes.NotFound},
+	{player.ErrNotFound, codes.NotFound},
+	{player.ErrInvalidArgument, codes.InvalidArgument},
+	{player.ErrRoundNotReady, codes.FailedPrecondition},
+	{player.ErrNotReady, codes.FailedPrecondition},
+	{player.ErrPermissionDenied, codes.PermissionDenied},
+	{player.ErrStandby, codes.Unavailable},
+}
+
+// toStatus converts domain errors into gRPC status errors with the
+// appropriate code. The jettison details are preserved so that callers can
+// still match the original error with errors.Is.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, sc := range statusCodes {
+		if !errors.Is(err, sc.err) {
+			continue
+		}
+
+		p := status.Convert(err).Proto()
+		p.Code = int32(sc.code)
+		return status.ErrorProto(p)
+	}
+
+	return err
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/corverroos/unsure"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/log"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// playerServices are the names of the Player's gRPC services, one per
+// version of the API, as reported by the health service.
+var playerServices = []string{"playerpb.Player", "playerpb.v2.Player"}
+
+const healthCheckInterval = 5 * time.Second
+
+// Health reports the Player's readiness using the standard grpc.health.v1
+// service. The Player is NOT_SERVING until its database and the Unsure Engine
+// are reachable and its consumers have started.
+type Health struct {
+	*health.Server
+	b       Backends
+	started int32
+	stop    chan struct{}
+}
+
+// NewHealth returns a health service for the Player which reports
+// NOT_SERVING until the Player is ready.
+func NewHealth(b Backends) *Health {
+	h := &Health{
+		Server: health.NewServer(),
+		b:      b,
+		stop:   make(chan struct{}),
+	}
+	h.setServing(false)
+
+	return h
+}
+
+// SetStarted marks the Player's consumers as started.
+func (h *Health) SetStarted() {
+	atomic.StoreInt32(&h.started, 1)
+}
+
+// CheckForever periodically checks the Player's dependencies and updates
+// the serving status until stopped.
+func (h *Health) CheckForever() {
+	t := time.NewTicker(healthCheckInterval)
+	defer t.Stop()
+
+	var serving bool
+	for {
+		err := h.check()
+		if err != nil && serving {
+			log.Error(nil, errors.Wrap(err, "player not serving"))
+		} else if err == nil && !serving {
+			log.Info(nil, "Player serving")
+		}
+
+		serving = err == nil
+		h.setServing(serving)
+
+		select {
+		case <-h.stop:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Stop stops checking and reports NOT_SERVING from now on.
+func (h *Health) Stop() {
+	close(h.stop)
+	h.Shutdown()
+}
+
+func (h *Health) check() error {
+	// Health checks shouldn't be subject to fate.
+	ctx, cancel := context.WithTimeout(
+		unsure.ContextWithFate(context.Background(), 0), healthCheckInterval)
+	defer cancel()
+
+	if err := h.b.PlayerDB().PingContext(ctx); err != nil {
+		return errors.Wrap(err, "failed to ping player db")
+	}
+
+	if err := h.b.EngineClient().Ping(ctx); err != nil {
+		return errors.Wrap(err, "failed to ping engine")
+	}
+
+	if atomic.LoadInt32(&h.started) == 0 {
+		return errors.New("consumers not started")
+	}
+
+	return nil
+}
+
+func (h *Health) setServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	h.SetServingStatus("", status)
+	for _, s := range playerServices {
+		h.SetServingStatus(s, status)
+	}
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// TestHealthServices checks that every version of the Player's service
+// reports a serving status, rather than being unknown to the health service.
+func TestHealthServices(t *testing.T) {
+	h := NewHealth(nil)
+
+	for _, service := range []string{"", "playerpb.Player",
+		"playerpb.v2.Player"} {
+		res, err := h.Check(context.Background(),
+			&healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Errorf("%q: %v", service, err)
+			continue
+		}
+		if res.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("%q: got %v, want %v", service, res.Status,
+				healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+	}
+}
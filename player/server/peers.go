@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+
+	"unsure/player"
+	"unsure/player/internal/grpctls"
+	"unsure/player/ops"
+)
+
+// leavingPeer returns the name of the peer announcing that it is leaving.
+// Peers may only announce their own departure, so the name must be that of
+// the authenticated caller, unless running in insecure mode where callers
+// aren't authenticated.
+func leavingPeer(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		return "", errors.Wrap(player.ErrInvalidArgument, "name required")
+	}
+
+	caller, ok := grpctls.CallerFromContext(ctx)
+	if !ok {
+		return name, nil
+	}
+
+	if !strings.EqualFold(caller, name) {
+		return "", errors.Wrap(player.ErrPermissionDenied,
+			"peers may only leave themselves",
+			j.MKV{"caller": caller, "name": name})
+	}
+
+	return caller, nil
+}
+
+// greeted records that the authenticated caller greeted the Player, so it
+// has rejoined if it was leaving. Callers aren't known in insecure mode.
+func greeted(ctx context.Context, b Backends) {
+	caller, ok := grpctls.CallerFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	ops.PeerJoined(ctx, b, caller)
+}
@@ -2,93 +2,228 @@ package server
 
 import (
 	"context"
-	"github.com/luno/jettison/j"
-	"unsure/player/ops"
-	"unsure/player/playerpb/protocp"
 
-	"github.com/luno/jettison/errors"
 	"github.com/luno/reflex"
 	"github.com/luno/reflex/reflexpb"
 
+	"unsure/player"
 	"unsure/player/internal/db/rounds"
 	pb "unsure/player/playerpb"
+	"unsure/player/playerpb/protocp"
+	pbv2 "unsure/player/playerpb/v2"
+	protocpv2 "unsure/player/playerpb/v2/protocp"
 )
 
 var _ pb.PlayerServer = (*Server)(nil)
 
-// Server defines the dependencies required for a Player's gRPC server.
+// Server serves the first version of the Player's gRPC API for peers on
+// older builds, translating to and from the second version's messages
+// served by V2.
 type Server struct {
-	b       Backends
-	rserver *reflex.Server
-	stream  reflex.StreamFunc
+	v2 *V2
 }
 
 // New returns an instance to the Player's gRPC server.
-func New(b Backends) *Server {
-	return &Server{
+func New(b Backends, conf player.Config) *Server {
+	return &Server{v2: &V2{
 		b:       b,
+		conf:    conf,
 		rserver: reflex.NewServer(),
 		stream:  rounds.EventStream(b.PlayerDB()),
-	}
+	}}
 }
 
 func (srv *Server) Stop() {
-	srv.rserver.Stop()
+	srv.v2.rserver.Stop()
 }
 
 // Ping returns an empty response.
 func (srv *Server) Ping(ctx context.Context, req *pb.Empty) (*pb.Empty, error) {
-	return req, nil
+	_, err := srv.v2.Ping(ctx, &pbv2.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Empty{}, nil
 }
 
-// StreamRoundEvents returns a reflex.StreamClient that can be used to
-// stream reflex events from a Player.
+// StreamRoundEvents streams the Player's round events, each carrying the
+// round's metadata, to the caller. Event types are sent as the raw
+// player.RoundStatus values stored with the events.
 func (srv *Server) StreamRoundEvents(req *reflexpb.StreamRequest,
 	ss pb.Player_StreamRoundEventsServer) error {
-	return nil
+	return srv.v2.rserver.Stream(srv.v2.stream, req, ss)
 }
 
 // GetName returns the Player's name.
 func (srv *Server) GetName(ctx context.Context, req *pb.Empty) (*pb.GetNameResp,
 	error) {
-	return &pb.GetNameResp{Name: ops.GetName()}, nil
+	res, err := srv.v2.GetName(ctx, &pbv2.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetNameResp{Name: res.Name}, nil
+}
+
+// Hello returns the Player's identity, protocol version and capabilities.
+// A peer greeting the Player has rejoined if it was leaving.
+func (srv *Server) Hello(ctx context.Context, req *pb.Empty) (*pb.HelloResp,
+	error) {
+	res, err := srv.v2.Hello(ctx, &pbv2.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return protocp.HelloToProto(protocpv2.HelloFromProto(res)), nil
+}
+
+// Leave records that the calling peer is shutting down.
+func (srv *Server) Leave(ctx context.Context, req *pb.LeaveReq) (*pb.Empty,
+	error) {
+	_, err := srv.v2.Leave(ctx, &pbv2.LeaveReq{Name: req.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Empty{}, nil
 }
 
 // GetParts returns a Player's parts received for a given round.
 func (srv *Server) GetParts(ctx context.Context, req *pb.GetPartsReq) (
 	*pb.GetPartsResp, error) {
-	pl, err := ops.GetParts(ctx, srv.b, req.ExternalId)
+	res, err := srv.v2.GetParts(ctx, &pbv2.GetPartsReq{
+		ExternalId: req.ExternalId,
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to list parts for round",
-			j.KV("external_id", req.ExternalId))
+		return nil, err
 	}
 
-	// Convert parts to proto.
-	var parts []*pb.Part
-	for _, p := range pl {
-		partProto, err := protocp.PartToProto(&p)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to convert part to proto")
-		}
+	return partsToV1(res)
+}
 
-		parts = append(parts, partProto)
+// GetRoundParts returns every part a Player holds for a given round.
+func (srv *Server) GetRoundParts(ctx context.Context, req *pb.GetPartsReq) (
+	*pb.GetPartsResp, error) {
+	res, err := srv.v2.GetRoundParts(ctx, &pbv2.GetPartsReq{
+		ExternalId: req.ExternalId,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &pb.GetPartsResp{Parts: parts}, nil
+	return partsToV1(res)
 }
 
 // GetRound returns a local rounds from a Player's DB.
 func (srv *Server) GetRound(ctx context.Context, req *pb.GetRoundReq) (
 	*pb.GetRoundResp, error) {
-	r, err := rounds.Lookup(ctx, srv.b.PlayerDB(), req.RoundId)
+	res, err := srv.v2.GetRound(ctx, &pbv2.GetRoundReq{RoundId: req.RoundId})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to lookup round")
+		return nil, err
 	}
 
-	// Convert round to proto.
-	roundProto, err := protocp.RoundToProto(r)
+	r, err := protocpv2.RoundFromProto(res.Round)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert round to proto")
+		return nil, err
 	}
-	return &pb.GetRoundResp{Round: roundProto}, nil
+
+	round, err := protocp.RoundToProto(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetRoundResp{Round: round}, nil
+}
+
+// GetRoundAudit returns the audit trail of a local round.
+func (srv *Server) GetRoundAudit(ctx context.Context,
+	req *pb.GetRoundAuditReq) (*pb.GetRoundAuditResp, error) {
+	res, err := srv.v2.GetRoundAudit(ctx, &pbv2.GetRoundAuditReq{
+		RoundId: req.RoundId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp pb.GetRoundAuditResp
+	for _, a := range res.Audit {
+		audit, err := protocpv2.RoundAuditFromProto(a)
+		if err != nil {
+			return nil, err
+		}
+
+		auditProto, err := protocp.RoundAuditToProto(audit)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Audit = append(resp.Audit, auditProto)
+	}
+
+	return &resp, nil
+}
+
+// GetStats returns the aggregate results of the Player's completed rounds.
+func (srv *Server) GetStats(ctx context.Context, req *pb.Empty) (
+	*pb.GetStatsResp, error) {
+	res, err := srv.v2.GetStats(ctx, &pbv2.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetStatsResp{
+		Stats: protocp.StatsToProto(protocpv2.StatsFromProto(res.Stats)),
+	}, nil
+}
+
+// CheckTotal compares a peer's claimed total for a round against the parts
+// the Player collected from it.
+func (srv *Server) CheckTotal(ctx context.Context, req *pb.TotalClaim) (
+	*pb.TotalCheck, error) {
+	res, err := srv.v2.CheckTotal(ctx, protocpv2.TotalClaimToProto(
+		protocp.TotalClaimFromProto(req)))
+	if err != nil {
+		return nil, err
+	}
+
+	return protocp.TotalCheckToProto(protocpv2.TotalCheckFromProto(res)), nil
+}
+
+// Export streams the Player's round results or summary, encoded as
+// CSV or JSON, to the caller in chunks.
+func (srv *Server) Export(req *pb.ExportReq, ss pb.Player_ExportServer) error {
+	return srv.v2.Export(&pbv2.ExportReq{
+		Format: req.Format,
+		Kind:   req.Kind,
+	}, legacyExportServer{ss})
+}
+
+// legacyExportServer sends the second version's export chunks as the first
+// version's.
+type legacyExportServer struct {
+	pb.Player_ExportServer
+}
+
+func (ss legacyExportServer) Send(chunk *pbv2.ExportChunk) error {
+	return ss.Player_ExportServer.Send(&pb.ExportChunk{Data: chunk.Data})
+}
+
+func partsToV1(in *pbv2.GetPartsResp) (*pb.GetPartsResp, error) {
+	var res pb.GetPartsResp
+	for _, p := range in.Parts {
+		part, err := protocpv2.PartFromProto(p)
+		if err != nil {
+			return nil, err
+		}
+
+		partProto, err := protocp.PartToProto(part)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Parts = append(res.Parts, partProto)
+	}
+
+	return &res, nil
 }
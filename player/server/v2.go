@@ -0,0 +1,254 @@
+package server
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/reflex"
+	"github.com/luno/reflex/reflexpb"
+
+	"unsure/player"
+	"unsure/player/export"
+	"unsure/player/ops"
+	pb "unsure/player/playerpb/v2"
+	"unsure/player/playerpb/v2/protocp"
+)
+
+var _ pb.PlayerServer = (*V2)(nil)
+
+// V2 serves the second version of the Player's gRPC API. The first version,
+// served by Server for peers on older builds, translates to and from it.
+type V2 struct {
+	b       Backends
+	conf    player.Config
+	rserver *reflex.Server
+	stream  reflex.StreamFunc
+}
+
+// NewV2 returns the second version of the Player's gRPC server, which srv
+// translates the first version to, so stopping srv stops both.
+func NewV2(srv *Server) *V2 {
+	return srv.v2
+}
+
+// Ping returns an empty response.
+func (srv *V2) Ping(ctx context.Context, req *pb.Empty) (*pb.Empty, error) {
+	return req, nil
+}
+
+// StreamRoundEvents streams the Player's round events, each carrying the
+// round's metadata, to the caller. Event types are sent as pb.RoundStatus
+// values rather than player.RoundStatus.
+func (srv *V2) StreamRoundEvents(req *reflexpb.StreamRequest,
+	ss pb.Player_StreamRoundEventsServer) error {
+	return srv.rserver.Stream(srv.stream, req, enumEventsServer{ss})
+}
+
+// enumEventsServer converts the type of each event it sends from a
+// player.RoundStatus to its pb.RoundStatus.
+type enumEventsServer struct {
+	pb.Player_StreamRoundEventsServer
+}
+
+func (s enumEventsServer) Send(e *reflexpb.Event) error {
+	e.Type = int32(protocp.RoundStatusToProto(player.RoundStatus(e.Type)))
+	return s.Player_StreamRoundEventsServer.Send(e)
+}
+
+// GetName returns the Player's name.
+func (srv *V2) GetName(ctx context.Context, req *pb.Empty) (*pb.GetNameResp,
+	error) {
+	return &pb.GetNameResp{Name: srv.conf.PlayerName}, nil
+}
+
+// Hello returns the Player's identity, protocol version and capabilities.
+// A peer greeting the Player has rejoined if it was leaving.
+func (srv *V2) Hello(ctx context.Context, req *pb.Empty) (*pb.HelloResp,
+	error) {
+	greeted(ctx, srv.b)
+
+	return protocp.HelloToProto(player.NewHello(srv.conf)), nil
+}
+
+// Leave records that the calling peer is shutting down.
+func (srv *V2) Leave(ctx context.Context, req *pb.LeaveReq) (*pb.Empty,
+	error) {
+	name, err := leavingPeer(ctx, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := ops.PeerLeaving(ctx, srv.b, name); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// GetParts returns a Player's parts received for a given round.
+func (srv *V2) GetParts(ctx context.Context, req *pb.GetPartsReq) (
+	*pb.GetPartsResp, error) {
+	if req.ExternalId <= 0 {
+		return nil, toStatus(errors.Wrap(player.ErrInvalidArgument,
+			"external_id required"))
+	}
+
+	pl, err := ops.GetParts(ctx, srv.b, srv.conf, req.ExternalId)
+	if err != nil {
+		return nil, toStatus(errors.Wrap(err, "failed to list parts for round",
+			j.KV("external_id", req.ExternalId)))
+	}
+
+	// Convert parts to proto.
+	var parts []*pb.Part
+	for _, p := range pl {
+		partProto, err := protocp.PartToProto(&p)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert part to proto")
+		}
+
+		parts = append(parts, partProto)
+	}
+
+	return &pb.GetPartsResp{Parts: parts}, nil
+}
+
+// GetRoundParts returns every part a Player holds for a given round.
+func (srv *V2) GetRoundParts(ctx context.Context, req *pb.GetPartsReq) (
+	*pb.GetPartsResp, error) {
+	if req.ExternalId <= 0 {
+		return nil, toStatus(errors.Wrap(player.ErrInvalidArgument,
+			"external_id required"))
+	}
+
+	pl, err := ops.GetRoundParts(ctx, srv.b, req.ExternalId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	// Convert parts to proto.
+	var parts []*pb.Part
+	for _, p := range pl {
+		partProto, err := protocp.PartToProto(&p)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert part to proto")
+		}
+
+		parts = append(parts, partProto)
+	}
+
+	return &pb.GetPartsResp{Parts: parts}, nil
+}
+
+// GetRound returns a local rounds from a Player's DB.
+func (srv *V2) GetRound(ctx context.Context, req *pb.GetRoundReq) (
+	*pb.GetRoundResp, error) {
+	if req.RoundId <= 0 {
+		return nil, toStatus(errors.Wrap(player.ErrInvalidArgument,
+			"round_id required"))
+	}
+
+	r, err := ops.GetRound(ctx, srv.b, req.RoundId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	// Convert round to proto.
+	roundProto, err := protocp.RoundToProto(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert round to proto")
+	}
+	return &pb.GetRoundResp{Round: roundProto}, nil
+}
+
+// GetRoundAudit returns the audit trail of a local round.
+func (srv *V2) GetRoundAudit(ctx context.Context,
+	req *pb.GetRoundAuditReq) (*pb.GetRoundAuditResp, error) {
+	if req.RoundId <= 0 {
+		return nil, toStatus(errors.Wrap(player.ErrInvalidArgument,
+			"round_id required"))
+	}
+
+	al, err := ops.GetRoundAudit(ctx, srv.b, req.RoundId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	// Convert audit to proto.
+	var audit []*pb.RoundAudit
+	for _, a := range al {
+		auditProto, err := protocp.RoundAuditToProto(&a)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert audit to proto")
+		}
+
+		audit = append(audit, auditProto)
+	}
+
+	return &pb.GetRoundAuditResp{Audit: audit}, nil
+}
+
+// GetStats returns the aggregate results of the Player's completed rounds.
+func (srv *V2) GetStats(ctx context.Context, req *pb.Empty) (
+	*pb.GetStatsResp, error) {
+	s, err := ops.GetStats(ctx, srv.b)
+	if err != nil {
+		return nil, toStatus(errors.Wrap(err, "failed to get stats"))
+	}
+
+	return &pb.GetStatsResp{Stats: protocp.StatsToProto(s)}, nil
+}
+
+// CheckTotal compares a peer's claimed total for a round against the parts
+// the Player collected from it.
+func (srv *V2) CheckTotal(ctx context.Context, req *pb.TotalClaim) (
+	*pb.TotalCheck, error) {
+	check, err := ops.CheckTotal(ctx, srv.b, srv.conf,
+		protocp.TotalClaimFromProto(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return protocp.TotalCheckToProto(check), nil
+}
+
+// Export streams the Player's round results or summary, encoded as
+// CSV or JSON, to the caller in chunks.
+func (srv *V2) Export(req *pb.ExportReq, ss pb.Player_ExportServer) error {
+	format, kind := export.Format(req.Format), export.Kind(req.Kind)
+	if err := export.Validate(format, kind); err != nil {
+		return toStatus(err)
+	}
+
+	w := bufio.NewWriterSize(chunkWriter(func(data []byte) error {
+		return ss.Send(&pb.ExportChunk{Data: data})
+	}), exportChunkSize)
+
+	err := export.Write(ss.Context(), w, srv.b.PlayerDB(), srv.conf, format,
+		kind)
+	if err != nil {
+		return toStatus(errors.Wrap(err, "failed to export"))
+	}
+
+	return w.Flush()
+}
+
+// exportChunkSize is the maximum size of each streamed export chunk.
+const exportChunkSize = 32 << 10
+
+// chunkWriter sends each write to the stream as an export chunk.
+type chunkWriter func(data []byte) error
+
+func (send chunkWriter) Write(b []byte) (int, error) {
+	// The buffer is reused after Write returns, so send a copy.
+	data := make([]byte, len(b))
+	copy(data, b)
+
+	if err := send(data); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
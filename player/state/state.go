@@ -2,60 +2,104 @@ package state
 
 import (
 	"database/sql"
-	"flag"
 	"unsure/player/internal/db"
-	"strings"
+	"unsure/player/internal/signing"
 
 	"github.com/corverroos/unsure/engine"
 	engine_client "github.com/corverroos/unsure/engine/client"
 	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
 
 	"unsure/player"
 	player_client "unsure/player/client/grpc"
+	"unsure/player/client/logical"
+	"unsure/player/ops"
 )
 
-var peers = flag.String("peers", "", "List of peer addresses (comma separated)")
-
 // State defines all the internal client dependencies for a Player.
 type State struct {
 	playerDB     *sql.DB
 	engineClient engine.Client
 	peers        []player.Client
+	keyring      *signing.Keyring
+	departures   ops.Departures
+	standby      ops.Standby
 }
 
 // New attempts to create clients to all the Player's dependencies and returns
-// a state for the service.
-func New() (*State, error) {
-	playerDB, err := db.Connect()
+// a state for the service as configured.
+func New(conf player.Config) (*State, error) {
+	s, err := newState(conf)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to player db")
+		return nil, err
 	}
 
+	for _, p := range conf.PeerAddresses() {
+		c, err := player_client.New(player_client.WithAddress(p),
+			player_client.WithTLS(conf.TLS))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create player client")
+		}
+		s.peers = append(s.peers, c)
+	}
+
+	return s, nil
+}
+
+// NewTeam returns the states of a team of Players running in a single
+// process. Each Player has its own database and is connected to the others
+// using in-process clients rather than gRPC.
+func NewTeam(confs []player.Config) ([]*State, error) {
+	var states []*State
+	for _, conf := range confs {
+		s, err := newState(conf)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create player state",
+				j.KV("player", conf.PlayerName))
+		}
+		states = append(states, s)
+	}
+
+	for i, s := range states {
+		for k, peer := range states {
+			if i == k {
+				continue
+			}
+			s.peers = append(s.peers, logical.New(peer, confs[k]))
+		}
+	}
+
+	return states, nil
+}
+
+// newState returns a state without any peers.
+func newState(conf player.Config) (*State, error) {
+	playerDB, err := db.Connect(conf.PlayerDB)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to player db")
+	}
 
 	ec, err := engine_client.New()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create engine client")
 	}
 
-	peerAddresses := strings.Split(*peers, ",")
-	if len(peerAddresses) == 0 {
-		return nil, errors.New("at least one peer is required")
+	keyring, err := signing.Load(conf.SigningKey, conf.PeerKeys,
+		conf.AllowUnsigned)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load signing keys")
 	}
 
-	var peerClients []player.Client
-	for _, p := range peerAddresses {
-		c, err := player_client.New(player_client.WithAddress(p))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to create player client")
-		}
-		peerClients = append(peerClients, c)
+	s := &State{
+		playerDB:     playerDB,
+		engineClient: ec,
+		keyring:      keyring,
 	}
 
-	return &State{
-		playerDB: playerDB,
-		engineClient: ec,
-		peers: peerClients,
-	}, nil
+	// Replicas stand by until they take the lease.
+	s.standby.Set(conf.ReplicaID != "")
+
+	return s, nil
 }
 
 // PlayerDB returns a connection to the Player's MySQL database.
@@ -72,3 +116,20 @@ func (s *State) EngineClient() engine.Client {
 func (s *State) Peers() []player.Client {
 	return s.peers
 }
+
+// Departures returns the peers that have announced they are leaving.
+func (s *State) Departures() *ops.Departures {
+	return &s.departures
+}
+
+// Standby returns whether the Player is a replica standing by for its
+// lease.
+func (s *State) Standby() *ops.Standby {
+	return &s.standby
+}
+
+// Keyring returns the keys used to sign the Player's parts and verify those
+// of its peers.
+func (s *State) Keyring() *signing.Keyring {
+	return s.keyring
+}
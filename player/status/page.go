@@ -0,0 +1,148 @@
+package status
+
+// page is the status page. It renders /status.json and refreshes it every
+// two seconds.
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Player status</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 1em 2em; }
+h1 { font-size: 20px; }
+h2 { font-size: 16px; margin-top: 1.5em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; text-align: left;
+  vertical-align: top; }
+.ok { color: #080; }
+.bad { color: #c00; }
+.muted { color: #888; }
+</style>
+</head>
+<body>
+<h1 id="title">Player status</h1>
+<div id="updated" class="muted"></div>
+
+<h2>Match</h2>
+<div id="match"></div>
+<div class="muted">Coordinator: <span id="coordinator"></span></div>
+
+<h2>Peers</h2>
+<table>
+<thead><tr><th>Name</th><th>Health</th><th>Latency</th><th>Error</th></tr>
+</thead>
+<tbody id="peers"></tbody>
+</table>
+
+<h2>Consumers</h2>
+<div class="muted">Events head: <span id="head"></span></div>
+<table>
+<thead><tr><th>Name</th><th>Cursor</th><th>Last event</th><th>Lag</th>
+<th>Handled at</th></tr></thead>
+<tbody id="consumers"></tbody>
+</table>
+
+<h2>Rounds</h2>
+<table>
+<thead><tr><th>ID</th><th>External ID</th><th>Status</th><th>Timeline</th>
+<th>Parts (player: rank / value)</th></tr></thead>
+<tbody id="rounds"></tbody>
+</table>
+
+<h2>Recent errors</h2>
+<table>
+<thead><tr><th>Time</th><th>Error</th></tr></thead>
+<tbody id="errors"></tbody>
+</table>
+
+<script>
+function esc(s) {
+  return String(s).replace(/[&<>"]/g, function(c) {
+    return {"&": "&amp;", "<": "&lt;", ">": "&gt;", '"': "&quot;"}[c];
+  });
+}
+
+function time(t) {
+  return t ? new Date(t).toLocaleTimeString() : "";
+}
+
+function row(cells) {
+  return "<tr>" + cells.map(function(c) {
+    return "<td>" + c + "</td>";
+  }).join("") + "</tr>";
+}
+
+function render(s) {
+  document.title = s.team_name + "/" + s.player_name;
+  document.getElementById("title").textContent =
+    "Player " + s.player_name + " of team " + s.team_name;
+  document.getElementById("updated").textContent =
+    "Updated " + time(s.timestamp) + (s.replica_id ? " by replica " +
+    s.replica_id + (s.standby ? " (standby)" : " (active)") : "");
+
+  document.getElementById("match").innerHTML = s.match_started_at ?
+    "Active since " + esc(time(s.match_started_at)) :
+    '<span class="muted">Not started</span>';
+  document.getElementById("coordinator").textContent =
+    s.coordinator || "electing";
+
+  document.getElementById("peers").innerHTML = s.peers.map(function(p) {
+    var health = p.left ? '<span class="muted">left</span>' :
+      p.healthy ? '<span class="ok">healthy</span>' :
+      '<span class="bad">unhealthy</span>';
+    return row([esc(p.name || "unknown"), health,
+      p.latency_ms.toFixed(1) + " ms", esc(p.error || "")]);
+  }).join("");
+
+  document.getElementById("head").textContent = s.events_head;
+  document.getElementById("consumers").innerHTML =
+    s.consumers.map(function(c) {
+      return row([esc(c.name), esc(c.cursor), esc(c.event_id || ""),
+        c.lag_ms.toFixed(0) + " ms", esc(time(c.handled_at))]);
+    }).join("");
+
+  document.getElementById("rounds").innerHTML = s.rounds.map(function(r) {
+    var timeline = r.transitions.map(function(t) {
+      return esc(t.status_name) + ' <span class="muted">' +
+        esc(time(t.timestamp)) + "</span>";
+    }).join(" &rarr; ");
+    var parts = r.parts.map(function(p) {
+      return esc(p.player) + ": " + p.rank + " / " + p.value +
+        (p.submitted ? " &check;" : "");
+    }).join("<br>");
+    var cls = r.status_name === "Success" ? "ok" :
+      r.status_name === "Failed" ? "bad" : "";
+    return row([r.id, r.external_id,
+      '<span class="' + cls + '">' + esc(r.status_name) + "</span>",
+      timeline, parts]);
+  }).join("");
+
+  document.getElementById("errors").innerHTML =
+    s.errors.slice().reverse().map(function(e) {
+      return row([esc(time(e.timestamp)), esc(e.message)]);
+    }).join("");
+}
+
+function refresh() {
+  fetch("status.json", {cache: "no-store"})
+    .then(function(resp) {
+      if (!resp.ok) {
+        throw new Error(resp.status + " " + resp.statusText);
+      }
+      return resp.json();
+    })
+    .then(render)
+    .catch(function(err) {
+      document.getElementById("updated").innerHTML =
+        '<span class="bad">Failed to refresh: ' + esc(err) + "</span>";
+    })
+    .then(function() {
+      setTimeout(refresh, 2000);
+    });
+}
+
+refresh();
+</script>
+</body>
+</html>
+`
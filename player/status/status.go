@@ -0,0 +1,205 @@
+// Package status serves a Player's status page, which shows the current
+// match, the latest rounds with their status timelines and parts, peer
+// health, consumer progress and recent errors. The page refreshes itself
+// from a JSON endpoint.
+//
+// Like the gateway, the status page is not authenticated, so it is only
+// served on a loopback address.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/log"
+
+	"unsure/player/gateway"
+	"unsure/player/ops"
+)
+
+// Source provides the Player's status, either its ops.Loops or, when the
+// Player runs as replicas, its ops.Replica.
+type Source interface {
+	Status(ctx context.Context) (*ops.Status, error)
+}
+
+// Server serves the status page at /status and its data at /status.json.
+type Server struct {
+	src Source
+}
+
+// New returns the status page for the Player reported by the source.
+func New(src Source) *Server {
+	return &Server{src: src}
+}
+
+// ServeHTTP implements http.Handler.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/status":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(page))
+	case "/status.json":
+		srv.serveJSON(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (srv *Server) serveJSON(w http.ResponseWriter, r *http.Request) {
+	s, err := srv.src.Status(r.Context())
+	if err != nil {
+		log.Error(r.Context(), errors.Wrap(err, "failed to get status"))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if err := json.NewEncoder(w).Encode(toJSON(s)); err != nil {
+		log.Error(r.Context(), errors.Wrap(err, "failed to write status"))
+	}
+}
+
+// Status is the JSON representation of an ops.Status.
+type Status struct {
+	TeamName       string     `json:"team_name"`
+	PlayerName     string     `json:"player_name"`
+	Timestamp      time.Time  `json:"timestamp"`
+	MatchStartedAt *time.Time `json:"match_started_at,omitempty"`
+	Coordinator    string     `json:"coordinator,omitempty"`
+	ReplicaID      string     `json:"replica_id,omitempty"`
+	Standby        bool       `json:"standby,omitempty"`
+	Rounds         []Round    `json:"rounds"`
+	Peers          []Peer     `json:"peers"`
+	EventsHead     int64      `json:"events_head"`
+	Consumers      []Consumer `json:"consumers"`
+	Errors         []Error    `json:"errors"`
+}
+
+// Round is the JSON representation of an ops.RoundProgress.
+type Round struct {
+	gateway.Round
+	Transitions []Transition   `json:"transitions"`
+	Parts       []gateway.Part `json:"parts"`
+}
+
+// Transition is the JSON representation of a player.RoundTransition.
+type Transition struct {
+	Status     int       `json:"status"`
+	StatusName string    `json:"status_name"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Peer is the JSON representation of an ops.PeerHealth.
+type Peer struct {
+	Name      string  `json:"name"`
+	Healthy   bool    `json:"healthy"`
+	Left      bool    `json:"left"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Consumer is the JSON representation of an ops.ConsumerProgress.
+type Consumer struct {
+	Name      string     `json:"name"`
+	Cursor    string     `json:"cursor"`
+	EventID   string     `json:"event_id,omitempty"`
+	EventTime *time.Time `json:"event_time,omitempty"`
+	HandledAt *time.Time `json:"handled_at,omitempty"`
+	LagMS     float64    `json:"lag_ms"`
+}
+
+// Error is the JSON representation of an ops.RecentError.
+type Error struct {
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func toJSON(s *ops.Status) Status {
+	res := Status{
+		TeamName:       s.TeamName,
+		PlayerName:     s.PlayerName,
+		Timestamp:      s.Timestamp,
+		MatchStartedAt: timeOrNil(s.MatchStartedAt),
+		Coordinator:    s.Coordinator,
+		ReplicaID:      s.ReplicaID,
+		Standby:        s.Standby,
+		Rounds:         []Round{},
+		Peers:          []Peer{},
+		EventsHead:     s.EventsHead,
+		Consumers:      []Consumer{},
+		Errors:         []Error{},
+	}
+
+	for _, rp := range s.Rounds {
+		r := Round{
+			Round:       gateway.RoundToJSON(&rp.Round),
+			Transitions: []Transition{},
+			Parts:       []gateway.Part{},
+		}
+		for _, t := range rp.Transitions {
+			r.Transitions = append(r.Transitions, Transition{
+				Status:     int(t.Status),
+				StatusName: t.Status.String(),
+				Timestamp:  t.Timestamp,
+			})
+		}
+		for _, p := range rp.Parts {
+			r.Parts = append(r.Parts, gateway.PartToJSON(&p))
+		}
+		res.Rounds = append(res.Rounds, r)
+	}
+
+	for _, p := range s.Peers {
+		res.Peers = append(res.Peers, Peer{
+			Name:      p.Name,
+			Healthy:   p.Healthy,
+			Left:      p.Left,
+			LatencyMS: millis(p.Latency),
+			Error:     p.Error,
+		})
+	}
+
+	for _, c := range s.Consumers {
+		res.Consumers = append(res.Consumers, Consumer{
+			Name:      c.Name,
+			Cursor:    c.Cursor,
+			EventID:   c.EventID,
+			EventTime: timeOrNil(c.EventTime),
+			HandledAt: timeOrNil(c.HandledAt),
+			LagMS:     millis(c.Lag()),
+		})
+	}
+
+	for _, e := range s.Errors {
+		res.Errors = append(res.Errors, Error{
+			Message:   e.Message,
+			Timestamp: e.Timestamp,
+		})
+	}
+
+	return res
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
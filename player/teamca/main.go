@@ -0,0 +1,197 @@
+// Command teamca generates a local team certificate authority along with a
+// certificate and key per player, for use with the Player's --tls_ca,
+// --tls_cert and --tls_key flags.
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+)
+
+// caName is the file name of the team CA's certificate and key.
+const caName = "ca"
+
+var (
+	outDir  = flag.String("out_dir", "certs", "Directory to write certificates to")
+	team    = flag.String("team_name", "", "Name of the team")
+	players = flag.String("players", "", "List of player names (comma separated)")
+	hosts   = flag.String("hosts", "localhost,127.0.0.1",
+		"List of hosts the players serve on (comma separated)")
+	validFor = flag.Duration("valid_for", 365*24*time.Hour,
+		"Duration the certificates are valid for")
+)
+
+func main() {
+	flag.Parse()
+
+	if *team == "" || *players == "" {
+		log.Fatal(errors.New("team_name and players are required"))
+	}
+
+	var names []string
+	for _, p := range strings.Split(*players, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		// The CA's own files are written as ca.pem and ca-key.pem.
+		if strings.EqualFold(p, caName) {
+			log.Fatal(errors.New("player name reserved for the team CA",
+				j.KV("player", p)))
+		}
+
+		names = append(names, p)
+	}
+
+	err := os.MkdirAll(*outDir, 0700)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to create output directory"))
+	}
+
+	ca, caKey, err := generateCA(*team)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "failed to generate team CA"))
+	}
+
+	err = writeKeyPair(caName, ca, caKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, p := range names {
+		cert, key, err := generatePlayer(p, ca, caKey)
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "failed to generate player certificate",
+				j.KV("player", p)))
+		}
+
+		err = writeKeyPair(p, cert, key)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	log.Info(nil, "Generated team certificates", j.KV("out_dir", *outDir))
+}
+
+func generateCA(team string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	tmpl, err := template(team + " CA")
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl.Subject.Organization = []string{team}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	return sign(tmpl, nil, nil)
+}
+
+func generatePlayer(name string, ca *x509.Certificate,
+	caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	tmpl, err := template(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl.Subject.Organization = ca.Subject.Organization
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth,
+		x509.ExtKeyUsageClientAuth}
+
+	for _, h := range strings.Split(*hosts, ",") {
+		h = strings.TrimSpace(h)
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else if h != "" {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+
+	return sign(tmpl, ca, caKey)
+}
+
+func template(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate serial number")
+	}
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(*validFor),
+	}, nil
+}
+
+// sign generates a key for the template and signs it with the parent, or
+// self-signs it if no parent is provided.
+func sign(tmpl, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (
+	*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate key")
+	}
+
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent,
+		&key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse certificate")
+	}
+
+	return cert, key, nil
+}
+
+// writeKeyPair writes <name>.pem and <name>-key.pem to the output directory.
+func writeKeyPair(name string, cert *x509.Certificate,
+	key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal key", j.KV("name", name))
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE",
+		Bytes: cert.Raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY",
+		Bytes: der})
+
+	err = ioutil.WriteFile(filepath.Join(*outDir, name+".pem"), certPEM, 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to write certificate",
+			j.KV("name", name))
+	}
+
+	err = ioutil.WriteFile(filepath.Join(*outDir, name+"-key.pem"), keyPEM,
+		0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to write key", j.KV("name", name))
+	}
+
+	return nil
+}
@@ -12,34 +12,38 @@ const (
 	// data.
 	RoundStatusUnknown RoundStatus = 0
 
-	// RoundStatusReady indicates that a player is online and ready to join a
-	// round.
-	RoundStatusReady RoundStatus = 1
+	// RoundStatusJoin indicates that a player has been notified of a new
+	// round and should attempt to join it.
+	RoundStatusJoin RoundStatus = 1
 
 	// RoundStatusJoined indicates that a player has successfully joined a
 	// round.
 	RoundStatusJoined RoundStatus = 2
 
-	// RoundStatusExcluded indicates that a player has been excluded from a
-	// round.
-	RoundStatusExcluded RoundStatus = 3
+	// RoundStatusCollect indicates that a player should collect its parts
+	// from the engine.
+	RoundStatusCollect RoundStatus = 3
 
 	// RoundStatusCollected indicates that a player has successfully collected
 	// parts from the engine.
 	RoundStatusCollected RoundStatus = 4
 
+	// RoundStatusSubmit indicates that a player should submit its parts to
+	// the engine.
+	RoundStatusSubmit RoundStatus = 5
+
 	// RoundStatusSubmitted indicates that a player has successfully submitted
 	// their parts to the engine.
-	RoundStatusSubmitted RoundStatus = 5
+	RoundStatusSubmitted RoundStatus = 6
 
 	// RoundStatusSuccess indicates that a player successfully passed a round.
-	RoundStatusSuccess RoundStatus = 6
+	RoundStatusSuccess RoundStatus = 7
 
 	// RoundStatusFailed indicates that a player failed a round.
-	RoundStatusFailed RoundStatus = 7
+	RoundStatusFailed RoundStatus = 8
 
 	// must be last.
-	roundStatusSentinel = 8
+	roundStatusSentinel = 9
 )
 
 // Valid returns whether "rs" is a declared RoundStatus constant.
@@ -66,9 +70,15 @@ type Round struct {
 	ID int64
 	// RoundID on the Unreal Engine.
 	ExternalID int64
+	// MatchID on the Unsure Engine of the match the round belongs to, zero
+	// if the Player didn't see the match start.
+	MatchID int64
 	// Unique player name.
 	Player    string
 	Status    RoundStatus
+	// Excluded is true if the Unsure Engine excluded the player from the
+	// round.
+	Excluded bool
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -86,6 +96,209 @@ type Part struct {
 	Value int64
 	Submitted bool
 
+	// Signature of the part by the player it belongs to.
+	Signature []byte
+	// Signature of the player's submission acknowledgement.
+	SubmittedSignature []byte
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// RoundMeta defines the metadata embedded in every round event so that peers
+// consuming the event don't need to call back for the round's details.
+type RoundMeta struct {
+	// RoundID on the Unreal Engine.
+	ExternalID int64
+	// Unique player name.
+	Player string
+	// Rank of the player within the round, zero if not yet collected.
+	Rank int64
+	// Values of the player's own parts.
+	Parts []int64
+	// Signatures of the player's own parts, aligned with Parts.
+	PartSignatures [][]byte
+	// Whether the player has submitted its parts.
+	Submitted bool
+	// Signature of the player's submission acknowledgement.
+	SubmittedSignature []byte
+}
+
+// RoundTransition defines a round's shift into a status, as recorded by its
+// round event.
+type RoundTransition struct {
+	Status    RoundStatus
+	Timestamp time.Time
+}
+
+// TransitionReason describes why a round shifted status. It is recorded in
+// the round's audit trail.
+type TransitionReason struct {
+	// Trigger identifies what caused the transition, e.g.
+	// "engine_event:42", "peer:bob:17" or "admin:replay".
+	Trigger string
+	// Error describes the failure which caused the transition, if any.
+	Error string
+}
+
+// EngineEventReason returns the reason for a transition triggered by an
+// Unsure Engine event.
+func EngineEventReason(eventID string) TransitionReason {
+	return TransitionReason{Trigger: "engine_event:" + eventID}
+}
+
+// LocalEventReason returns the reason for a transition triggered by one of
+// the Player's own round events.
+func LocalEventReason(eventID string) TransitionReason {
+	return TransitionReason{Trigger: "local_event:" + eventID}
+}
+
+// PeerEventReason returns the reason for a transition triggered by a peer's
+// round event.
+func PeerEventReason(peer, eventID string) TransitionReason {
+	return TransitionReason{Trigger: "peer:" + peer + ":" + eventID}
+}
+
+// AdminReason returns the reason for a transition triggered by an operator.
+func AdminReason(action string) TransitionReason {
+	return TransitionReason{Trigger: "admin:" + action}
+}
+
+// RecoveryReason returns the reason for a transition triggered while
+// recovering the Player's state on startup.
+func RecoveryReason(action string) TransitionReason {
+	return TransitionReason{Trigger: "recovery:" + action}
+}
+
+// TimerReason returns the reason for a transition triggered by the passing
+// of time rather than an event.
+func TimerReason(name string) TransitionReason {
+	return TransitionReason{Trigger: "timer:" + name}
+}
+
+// WithError returns a copy of the reason describing the error which caused
+// the transition.
+func (tr TransitionReason) WithError(err error) TransitionReason {
+	tr.Error = err.Error()
+	return tr
+}
+
+// RoundAudit defines an entry in a round's audit trail, recorded with every
+// status transition.
+type RoundAudit struct {
+	ID      int64
+	RoundID int64
+	From    RoundStatus
+	To      RoundStatus
+	Trigger string
+	Error   string
+	// Duration the round spent in the From status.
+	Duration time.Duration
+
+	CreatedAt time.Time
+}
+
+// Stats defines the aggregate results of a Player's completed rounds.
+type Stats struct {
+	Succeeded int64
+	Failed    int64
+	// Excluded is the number of rounds the Unsure Engine excluded the Player
+	// from.
+	Excluded int64
+	// Matches are the results of each match, ordered by match.
+	Matches []MatchStats
+	// Phases is the time rounds spent in each status, in status order.
+	Phases []PhaseStats
+	// Parts is the number of the Player's own parts collected.
+	Parts int64
+	// PartsTotal is the sum of the values of the Player's own parts.
+	PartsTotal int64
+	// SubmittedTotal is the sum of the Player's own submitted parts.
+	SubmittedTotal int64
+	// Peers are the late submissions of each peer, ordered by name.
+	Peers []PeerStats
+}
+
+// SuccessRate returns the fraction of completed rounds which succeeded.
+func (s *Stats) SuccessRate() float64 {
+	if s.Succeeded+s.Failed == 0 {
+		return 0
+	}
+
+	return float64(s.Succeeded) / float64(s.Succeeded+s.Failed)
+}
+
+// MeanPartValue returns the mean value of the Player's own parts.
+func (s *Stats) MeanPartValue() float64 {
+	if s.Parts == 0 {
+		return 0
+	}
+
+	return float64(s.PartsTotal) / float64(s.Parts)
+}
+
+// MatchStats defines the results of the completed rounds of a match.
+type MatchStats struct {
+	// MatchID on the Unsure Engine, zero for rounds the Player couldn't
+	// attribute to a match.
+	MatchID   int64
+	Succeeded int64
+	Failed    int64
+	Excluded  int64
+}
+
+// SuccessRate returns the fraction of the match's completed rounds which
+// succeeded.
+func (ms *MatchStats) SuccessRate() float64 {
+	if ms.Succeeded+ms.Failed == 0 {
+		return 0
+	}
+
+	return float64(ms.Succeeded) / float64(ms.Succeeded+ms.Failed)
+}
+
+// PhaseStats defines the time completed rounds spent in a status.
+type PhaseStats struct {
+	Status RoundStatus
+	// Count is the number of rounds which passed through the status.
+	Count int64
+	Mean  time.Duration
+	// P95 is the upper bound of the histogram bucket containing the 95th
+	// percentile, so it overestimates by at most the bucket's width.
+	P95 time.Duration
+}
+
+// PeerStats defines a peer's submission record over completed rounds.
+type PeerStats struct {
+	Player string
+	// LateSubmissions is the number of rounds which completed before the
+	// peer's submission was acknowledged. Rounds the peer never submitted
+	// in aren't counted.
+	LateSubmissions int64
+}
+
+// TotalClaim defines the total and parts a Player computed for a round,
+// published to its peers before it submits.
+type TotalClaim struct {
+	// RoundID on the Unsure Engine.
+	ExternalID int64
+	// Unique name of the player about to submit.
+	Player string
+	Total  int64
+	// Values of the parts making up the total.
+	Parts []int64
+}
+
+// TotalCheck defines a peer's comparison of a TotalClaim against the
+// claimant's parts it collected.
+type TotalCheck struct {
+	// Unique name of the peer which checked the claim.
+	Peer string
+	// Whether the peer has collected the claimant's parts yet.
+	Known bool
+	// Whether the peer's view matches the claim.
+	Agreed bool
+	// Total and values of the claimant's parts as collected by the peer.
+	Total int64
+	Parts []int64
+}